@@ -2,23 +2,30 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
+	_ "time/tzdata" // Base IANA embebida: las zonas de los usuarios no dependen del sistema
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"uniflow-api/internal/application"
 	ports "uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/blob"
+	"uniflow-api/internal/infrastructure/broadcast"
+	"uniflow-api/internal/infrastructure/cache"
 	"uniflow-api/internal/infrastructure/handlers"
 	"uniflow-api/internal/infrastructure/middleware"
 	"uniflow-api/internal/infrastructure/persistence"            // Mongo repo
+	"uniflow-api/internal/infrastructure/persistence/bolt"       // Repo de tareas en archivo local
 	mem "uniflow-api/internal/infrastructure/persistence/memory" // Repo en memoria
+	"uniflow-api/internal/infrastructure/persistence/postgres"   // Repo de tareas en PostgreSQL
 )
 
 func main() {
@@ -27,6 +34,12 @@ func main() {
 		log.Println("No se encontró archivo .env, usando variables de entorno del sistema")
 	}
 
+	// Subcomando: `api migrate [up|status]`
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrateCommand(os.Args[2:])
+		return
+	}
+
 	// 2) Puerto
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -41,39 +54,108 @@ func main() {
 	// 4) Crear el repositorio (memoria por defecto si no hay MONGO_URI)
 	mongoURI := os.Getenv("MONGO_URI")
 	var repo ports.TaskRepository
+	var calendarTokenRepo ports.CalendarTokenRepository
+	var webhookRepo ports.WebhookRepository
+	var savedFilterRepo ports.SavedFilterRepository
+	var preferencesRepo ports.PreferencesRepository
+	var invitationRepo ports.InvitationRepository
+	var activityRepo ports.ActivityRepository
+	var attachmentRepo ports.AttachmentRepository
+	var templateRepo ports.TemplateRepository
+	var eventBroadcaster ports.EventBroadcaster = broadcast.NewLocal()
 
 	if mongoURI == "" {
-		log.Println("MONGO_URI no configurada → usando repositorio EN MEMORIA")
-		repo = mem.NewRepo()
+		// Con BOLT_PATH las tareas persisten en un archivo local (un solo nodo)
+		if boltPath := os.Getenv("BOLT_PATH"); boltPath != "" {
+			log.Printf("MONGO_URI no configurada → tareas en archivo local %s", boltPath)
+			boltRepo, err := bolt.Open(boltPath)
+			if err != nil {
+				log.Fatalf("ERROR al abrir BOLT_PATH: %v", err)
+			}
+			defer boltRepo.Close()
+			repo = boltRepo
+		} else {
+			log.Println("MONGO_URI no configurada → usando repositorio EN MEMORIA")
+			repo = mem.NewRepo()
+		}
+		calendarTokenRepo = mem.NewCalendarTokenRepo()
+		webhookRepo = mem.NewWebhookRepo()
+		savedFilterRepo = mem.NewSavedFilterRepo()
+		preferencesRepo = mem.NewPreferencesRepo()
+		invitationRepo = mem.NewInvitationRepo()
+		activityRepo = mem.NewActivityRepo()
+		attachmentRepo = mem.NewAttachmentRepo()
+		templateRepo = mem.NewTemplateRepo()
 	} else {
 		log.Println("Inicializando repositorio Mongo…")
 
-		mongoDB := os.Getenv("MONGO_DB")
-		if mongoDB == "" {
-			mongoDB = "uniflowdb" // default sensato
+		client, db := connectMongo(mongoURI)
+		defer func() {
+			_ = client.Disconnect(context.Background())
+		}()
+
+		// Índices y backfills pendientes (MIGRATE_ON_START=false para
+		// aplicarlas aparte con el subcomando migrate)
+		if os.Getenv("MIGRATE_ON_START") != "false" {
+			if err := runMigrations(db); err != nil {
+				log.Fatalf("ERROR al aplicar migraciones: %v", err)
+			}
 		}
 
-		// Conectar a Mongo con timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		// Selección de colecciones y repos
+		repo = persistence.NewMongoTaskRepository(db.Collection("tasks"))
+		calendarTokenRepo = persistence.NewMongoCalendarTokenRepository(db.Collection("calendar_tokens"))
+		webhookRepo = persistence.NewMongoWebhookRepository(db.Collection("webhooks"), db.Collection("webhook_deliveries"))
+		savedFilterRepo = persistence.NewMongoSavedFilterRepository(db.Collection("saved_filters"))
+		preferencesRepo = persistence.NewMongoPreferencesRepository(db.Collection("user_preferences"))
+		invitationRepo = persistence.NewMongoInvitationRepository(db.Collection("task_invitations"))
+		activityRepo = persistence.NewMongoActivityRepository(db.Collection("task_activity"))
+		attachmentRepo = persistence.NewMongoAttachmentRepository(db.Collection("task_attachments"))
+		templateRepo = persistence.NewMongoTemplateRepository(db.Collection("task_templates"))
 
-		client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
-		if err != nil {
-			log.Fatalf("ERROR al conectar a MongoDB: %v", err)
+		// Con varias réplicas los eventos SSE se reparten vía change streams
+		if os.Getenv("EVENTS_BROADCASTER") == "mongo" {
+			log.Println("Eventos en tiempo real vía change streams de MongoDB")
+			eventBroadcaster = persistence.NewMongoEventBroadcaster(db.Collection("task_events"))
 		}
-		defer func() {
-			_ = client.Disconnect(context.Background())
-		}()
+	}
+
+	// Con DATABASE_URL las tareas se guardan en PostgreSQL; el resto de los
+	// repos sigue en Mongo (o en memoria)
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		log.Println("Inicializando repositorio de tareas PostgreSQL…")
+		pool := connectPostgres(databaseURL)
+		defer pool.Close()
 
-		// Ping
-		if err := client.Ping(ctx, nil); err != nil {
-			log.Fatalf("ERROR al hacer ping a MongoDB: %v", err)
+		if os.Getenv("MIGRATE_ON_START") != "false" {
+			if err := runPostgresMigrations(pool); err != nil {
+				log.Fatalf("ERROR al aplicar migraciones SQL: %v", err)
+			}
 		}
-		log.Println("✅ Conectado a MongoDB")
+		repo = postgres.NewTaskRepository(pool)
+	}
 
-		// Selección de colección y repo
-		coll := client.Database(mongoDB).Collection("tasks")
-		repo = persistence.NewMongoTaskRepository(coll)
+	// Caché de lecturas frecuentes (dashboard y listados), invalidada por
+	// usuario en cada mutación. En memoria cada réplica tiene la suya (las
+	// demás pueden servir datos de hasta TASK_CACHE_TTL de antigüedad); con
+	// Redis la comparten todas.
+	var taskCache *cache.TaskRepository
+	ttl, _ := time.ParseDuration(os.Getenv("TASK_CACHE_TTL"))
+	switch os.Getenv("TASK_CACHE") {
+	case "memory":
+		size, _ := strconv.Atoi(os.Getenv("TASK_CACHE_SIZE"))
+		taskCache = cache.NewTaskRepository(repo, cache.NewLRU(size), cache.Config{TTL: ttl})
+		repo = taskCache
+		log.Println("Caché de tareas en memoria habilitada")
+	case "redis":
+		backend, err := cache.NewRedis(os.Getenv("TASK_CACHE_REDIS_URL"))
+		if err != nil {
+			log.Fatalf("ERROR en TASK_CACHE_REDIS_URL: %v", err)
+		}
+		defer backend.Close()
+		taskCache = cache.NewTaskRepository(repo, backend, cache.Config{TTL: ttl})
+		repo = taskCache
+		log.Println("Caché de tareas en Redis habilitada")
 	}
 
 	// 5) Configurar Azure Queue Storage (opcional)
@@ -107,14 +189,75 @@ func main() {
 		log.Println("ℹ️ AZURE_STORAGE_CONNECTION_STRING no configurada → recordatorios deshabilitados")
 	}
 
+	// Zona horaria de los usuarios sin preferencia guardada
+	defaultLoc, err := domain.LoadTimeZone(os.Getenv("DEFAULT_TZ"))
+	if err != nil {
+		log.Fatalf("ERROR DEFAULT_TZ: %v", err)
+	}
+	preferencesService := application.NewPreferencesService(preferencesRepo, defaultLoc)
+
+	// Adjuntos: contenido en disco local o en Azure Blob Storage (Azurite en desarrollo)
+	blobStore, attachmentCfg, err := attachmentStorage(azureStorageConnStr)
+	if err != nil {
+		log.Fatalf("ERROR adjuntos: %v", err)
+	}
+
 	// 6) Servicio + Router + Handlers
-	taskService := application.NewTaskService(repo, queueClient)
+	taskService := application.NewTaskService(repo, queueClient).
+		WithPreferences(preferencesService).
+		WithInvitations(invitationRepo).
+		WithActivity(activityRepo).
+		WithAttachments(attachmentRepo, blobStore, attachmentCfg)
 	r := gin.Default()
 
-	taskHandler := handlers.NewTaskHandler(taskService)
+	calendarService := application.NewCalendarService(repo, calendarTokenRepo)
+
+	// Webhooks: los eventos de tareas se entregan en segundo plano. Los de
+	// usuario solo pueden apuntar a IPs públicas (WEBHOOKS_ALLOW_PRIVATE=true en desarrollo)
+	webhookService := application.NewWebhookService(webhookRepo, application.WebhookConfig{
+		AllowPrivateNetworks: os.Getenv("WEBHOOKS_ALLOW_PRIVATE") == "true",
+	})
+	webhookService.Start()
+	defer webhookService.Stop()
+	taskService.AddEventPublisher(webhookService)
+
+	// Eventos en tiempo real (SSE)
+	eventHub := application.NewEventHub(eventBroadcaster, application.EventHubConfig{})
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+	go eventHub.Run(hubCtx)
+	taskService.AddEventPublisher(eventHub)
+
+	savedFilterService := application.NewSavedFilterService(savedFilterRepo)
+	templateService := application.NewTemplateService(templateRepo, taskService)
+
+	taskHandler := handlers.NewTaskHandler(taskService).
+		WithSavedFilters(savedFilterService).
+		WithPreferences(preferencesService).
+		WithTemplates(templateService)
+	preferencesHandler := handlers.NewPreferencesHandler(preferencesService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	streamHandler := handlers.NewStreamHandler(eventHub, 0)
 
 	// 7) Rutas públicas (sin autenticación)
 	r.GET("/health", handlers.HealthHandler)
+	// Feed ICS: los clientes de calendario no envían headers X-User-*,
+	// se autentica con un token secreto revocable en la URL (/calendar/<token>.ics)
+	r.GET("/calendar/:token", calendarHandler.GetFeed)
+	// Descarga de adjuntos con enlace firmado y temporal (se obtiene en /tasks/:id/attachments)
+	r.GET("/attachments/:id/download", taskHandler.DownloadAttachment)
+
+	// Webhooks de sistema (servicio a servicio, protegidos con ADMIN_API_KEY)
+	if adminKey := os.Getenv("ADMIN_API_KEY"); adminKey != "" {
+		admin := r.Group("/admin", middleware.AdminKeyMiddleware(adminKey))
+		registerWebhookRoutes(admin.Group("/webhooks"), webhookHandler)
+		if taskCache != nil {
+			admin.GET("/cache/stats", handlers.NewCacheHandler(taskCache).GetStats)
+		}
+	} else {
+		log.Println("ℹ️ ADMIN_API_KEY no configurada → webhooks de sistema deshabilitados")
+	}
 
 	// 8) Middleware de autenticación (headers de API Management)
 	// En desarrollo, DevAuthBypass permite usar X-Dev-User-ID
@@ -129,6 +272,13 @@ func main() {
 	r.GET("/tasks/overdue", taskHandler.GetOverdue)
 	r.GET("/tasks/completed", taskHandler.GetCompleted)
 	r.GET("/tasks/dashboard", taskHandler.GetDashboard)
+	r.GET("/tasks/agenda", taskHandler.GetAgenda)
+	r.GET("/tasks/next", taskHandler.GetNextTasks)
+	r.GET("/tasks/export", taskHandler.ExportTasks)
+	r.POST("/tasks/import", taskHandler.ImportTasks)
+	r.POST("/tasks/import/ics", taskHandler.ImportICS)
+	r.POST("/tasks/quick", taskHandler.QuickAddTask)
+	r.POST("/tasks/from-template/:id", taskHandler.CreateFromTemplate)
 	r.GET("/tasks/by-subject/:subjectId", taskHandler.GetBySubject)
 	r.GET("/tasks/by-period/:periodId", taskHandler.GetByPeriod)
 
@@ -141,9 +291,127 @@ func main() {
 	r.PATCH("/tasks/:id/complete", taskHandler.CompleteTask)
 	r.DELETE("/tasks/:id", taskHandler.DeleteTask)
 
+	// Tareas compartidas: colaboradores e invitaciones por email
+	r.GET("/tasks/:id/collaborators", taskHandler.ListCollaborators)
+	r.POST("/tasks/:id/collaborators", taskHandler.AddCollaborator)
+	r.PATCH("/tasks/:id/collaborators/:member", taskHandler.UpdateCollaborator)
+	r.DELETE("/tasks/:id/collaborators/:member", taskHandler.RemoveCollaborator)
+	r.GET("/me/invitations", taskHandler.ListInvitations)
+	r.POST("/me/invitations/:id/accept", taskHandler.AcceptInvitation)
+	r.DELETE("/me/invitations/:id", taskHandler.DeclineInvitation)
+
+	// Comentarios y actividad (hilo compartido por los miembros de la tarea)
+	r.GET("/tasks/:id/comments", taskHandler.ListComments)
+	r.POST("/tasks/:id/comments", taskHandler.AddComment)
+	r.PATCH("/tasks/:id/comments/:commentId", taskHandler.UpdateComment)
+	r.DELETE("/tasks/:id/comments/:commentId", taskHandler.DeleteComment)
+	r.GET("/tasks/:id/activity", taskHandler.GetActivity)
+
+	// Adjuntos (multipart, campo "file")
+	r.GET("/tasks/:id/attachments", taskHandler.ListAttachments)
+	r.POST("/tasks/:id/attachments", taskHandler.UploadAttachment)
+	r.GET("/tasks/:id/attachments/:attachmentId", taskHandler.GetAttachment)
+	r.DELETE("/tasks/:id/attachments/:attachmentId", taskHandler.DeleteAttachment)
+
+	// Clonar la estructura de un período en otro (con vista previa)
+	r.POST("/periods/:from/clone", taskHandler.ClonePeriod)
+
+	// Plantillas (predefinidas y del usuario)
+	r.GET("/templates", taskHandler.ListTemplates)
+	r.POST("/templates", taskHandler.CreateTemplate)
+	r.GET("/templates/:id", taskHandler.GetTemplate)
+	r.PUT("/templates/:id", taskHandler.UpdateTemplate)
+	r.DELETE("/templates/:id", taskHandler.DeleteTemplate)
+	r.POST("/tasks/:id/template", taskHandler.SaveTaskAsTemplate)
+
+	// Listas guardadas (se usan con GET /tasks?view=<id>)
+	r.GET("/saved-filters", taskHandler.ListSavedFilters)
+	r.POST("/saved-filters", taskHandler.CreateSavedFilter)
+	r.GET("/saved-filters/:id", taskHandler.GetSavedFilter)
+	r.PUT("/saved-filters/:id", taskHandler.UpdateSavedFilter)
+	r.DELETE("/saved-filters/:id", taskHandler.DeleteSavedFilter)
+
+	// Preferencias del usuario (zona horaria)
+	r.GET("/me", preferencesHandler.GetProfile)
+	r.GET("/me/preferences", preferencesHandler.GetPreferences)
+	r.PUT("/me/preferences", preferencesHandler.UpdatePreferences)
+
+	// Tokens del feed de calendario
+	r.GET("/calendar/tokens", calendarHandler.ListTokens)
+	r.POST("/calendar/tokens", calendarHandler.CreateToken)
+	r.DELETE("/calendar/tokens/:id", calendarHandler.RevokeToken)
+
+	// Eventos en tiempo real del usuario (SSE)
+	r.GET("/events/stream", streamHandler.Stream)
+
+	// Webhooks del usuario
+	registerWebhookRoutes(r.Group("/webhooks"), webhookHandler)
+
 	// 10) Levantar server
 	fmt.Printf("Servidor escuchando en puerto %s\n", port)
 	if err := r.Run(":" + port); err != nil {
 		log.Fatalf("ERROR al levantar servidor: %v", err)
 	}
 }
+
+// registerWebhookRoutes rutas de webhooks (compartidas por /webhooks y /admin/webhooks)
+func registerWebhookRoutes(g *gin.RouterGroup, h *handlers.WebhookHandler) {
+	g.GET("", h.ListWebhooks)
+	g.POST("", h.CreateWebhook)
+	g.GET("/:id", h.GetWebhook)
+	g.PATCH("/:id", h.UpdateWebhook)
+	g.DELETE("/:id", h.DeleteWebhook)
+	g.GET("/:id/deliveries", h.ListDeliveries)
+	g.POST("/:id/test", h.TestWebhook)
+}
+
+// attachmentStorage BlobStore y límites de los adjuntos según el entorno:
+// ATTACHMENTS_STORE=azure usa AZURE_STORAGE_CONNECTION_STRING (contenedor
+// ATTACHMENTS_CONTAINER); si no, archivos bajo ATTACHMENTS_DIR
+func attachmentStorage(azureConnStr string) (ports.BlobStore, application.AttachmentConfig, error) {
+	var cfg application.AttachmentConfig
+	cfg.LinkTTL, _ = time.ParseDuration(os.Getenv("ATTACHMENT_LINK_TTL"))
+	if mb, _ := strconv.Atoi(os.Getenv("ATTACHMENT_MAX_MB")); mb > 0 {
+		cfg.MaxBytes = int64(mb) << 20
+	}
+
+	if key := os.Getenv("ATTACHMENT_SIGNING_KEY"); key != "" {
+		cfg.SigningKey = []byte(key)
+	} else {
+		// Los enlaces emitidos dejan de valer al reiniciar y no sirven entre réplicas
+		cfg.SigningKey = make([]byte, 32)
+		if _, err := rand.Read(cfg.SigningKey); err != nil {
+			return nil, cfg, err
+		}
+		log.Println("ℹ️ ATTACHMENT_SIGNING_KEY no configurada → clave aleatoria por proceso")
+	}
+
+	if os.Getenv("ATTACHMENTS_STORE") == "azure" {
+		if azureConnStr == "" {
+			return nil, cfg, fmt.Errorf("ATTACHMENTS_STORE=azure requiere AZURE_STORAGE_CONNECTION_STRING")
+		}
+		containerName := os.Getenv("ATTACHMENTS_CONTAINER")
+		if containerName == "" {
+			containerName = "attachments"
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		store, err := blob.NewAzureStore(ctx, azureConnStr, containerName)
+		if err != nil {
+			return nil, cfg, err
+		}
+		log.Printf("✅ Adjuntos en Azure Blob Storage (contenedor '%s')", containerName)
+		return store, cfg, nil
+	}
+
+	dir := os.Getenv("ATTACHMENTS_DIR")
+	if dir == "" {
+		dir = "data/attachments"
+	}
+	store, err := blob.NewFSStore(dir)
+	if err != nil {
+		return nil, cfg, err
+	}
+	log.Printf("✅ Adjuntos en disco local (%s)", dir)
+	return store, cfg, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"uniflow-api/internal/infrastructure/persistence/migrations"
+	"uniflow-api/internal/infrastructure/persistence/postgres"
+)
+
+// migrateTimeout tope para aplicar migraciones (incluye esperar el lock
+// si otra réplica está migrando)
+const migrateTimeout = 10 * time.Minute
+
+// connectMongo conecta y hace ping; devuelve el cliente y la base MONGO_DB
+func connectMongo(mongoURI string) (*mongo.Client, *mongo.Database) {
+	mongoDB := os.Getenv("MONGO_DB")
+	if mongoDB == "" {
+		mongoDB = "uniflowdb" // default sensato
+	}
+
+	// Conectar a Mongo con timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("ERROR al conectar a MongoDB: %v", err)
+	}
+
+	// Ping
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("ERROR al hacer ping a MongoDB: %v", err)
+	}
+	log.Println("✅ Conectado a MongoDB")
+
+	return client, client.Database(mongoDB)
+}
+
+// runMigrations aplica las migraciones pendientes
+func runMigrations(db *mongo.Database) error {
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	applied, err := migrator.Run(ctx)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.Println("✅ Esquema al día (sin migraciones pendientes)")
+	} else {
+		log.Printf("✅ Migraciones aplicadas: %v", applied)
+	}
+	return nil
+}
+
+// connectPostgres abre el pool de DATABASE_URL
+func connectPostgres(databaseURL string) *pgxpool.Pool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool, err := postgres.Connect(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("ERROR al conectar a PostgreSQL: %v", err)
+	}
+	log.Println("✅ Conectado a PostgreSQL")
+	return pool
+}
+
+// runPostgresMigrations aplica las migraciones SQL pendientes
+func runPostgresMigrations(pool *pgxpool.Pool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	applied, err := postgres.Migrate(ctx, pool)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.Println("✅ Esquema SQL al día (sin migraciones pendientes)")
+	} else {
+		log.Printf("✅ Migraciones SQL aplicadas: %v", applied)
+	}
+	return nil
+}
+
+// migrateCommand subcomando `migrate [up|status]`: aplica las migraciones
+// (o muestra su estado) y termina sin levantar el servidor. Con DATABASE_URL
+// `up` también aplica las migraciones SQL de PostgreSQL.
+func migrateCommand(args []string) {
+	mongoURI := os.Getenv("MONGO_URI")
+	databaseURL := os.Getenv("DATABASE_URL")
+	if mongoURI == "" && databaseURL == "" {
+		log.Fatal("migrate: MONGO_URI y DATABASE_URL no configuradas")
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	if action != "up" && action != "status" {
+		log.Fatalf("migrate: acción desconocida %q (usar up o status)", action)
+	}
+
+	if databaseURL != "" && action == "up" {
+		pool := connectPostgres(databaseURL)
+		defer pool.Close()
+		if err := runPostgresMigrations(pool); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+	}
+	if mongoURI == "" {
+		return
+	}
+
+	client, db := connectMongo(mongoURI)
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	switch action {
+	case "up":
+		if err := runMigrations(db); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+	case "status":
+		migrator, err := migrations.NewMigrator(db, migrations.All())
+		if err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pendiente"
+			if s.AppliedAt != nil {
+				state = "aplicada " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%3d  %-22s  %s\n", s.Version, state, s.Description)
+		}
+	}
+}
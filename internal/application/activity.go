@@ -0,0 +1,235 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// Límites de paginación del hilo de una tarea
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+)
+
+// WithActivity habilita los comentarios y el registro de cambios de estado
+func (ts *TaskService) WithActivity(repo ports.ActivityRepository) *TaskService {
+	ts.activity = repo
+	return ts
+}
+
+// threadTask tarea taskID visible para userID. El hilo es el de la tarea del
+// dueño: para una copia de colaborador se usa la original.
+func (ts *TaskService) threadTask(ctx context.Context, taskID, userID string) (task *domain.Task, ownerID, ownerTaskID string, err error) {
+	task, err = ts.repo.GetByID(ctx, taskID, userID)
+	if err != nil {
+		return nil, "", "", domain.ErrTaskNotFound
+	}
+	if task.IsSharedCopy() {
+		return task, task.OwnerID, task.SharedTaskID, nil
+	}
+	return task, task.UserID, task.ID, nil
+}
+
+// ListActivity página del hilo de la tarea (kind vacío = comentarios y
+// cambios de estado), de la entrada más reciente a la más antigua
+func (ts *TaskService) ListActivity(ctx context.Context, taskID, userID, kind string, page, limit int) ([]domain.Activity, domain.PageInfo, error) {
+	ctx = ensureContext(ctx)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+	if ts.activity == nil {
+		return []domain.Activity{}, domain.PageInfo{Page: page, Limit: limit}, nil
+	}
+
+	_, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	entries, total, err := ts.activity.List(ctx, domain.ActivityQuery{
+		OwnerID: ownerID,
+		TaskID:  ownerTaskID,
+		Kind:    kind,
+		Page:    page,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+	return entries, domain.PageInfo{
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    int64(page) < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// AddComment agrega un comentario al hilo. Cualquier miembro de la tarea
+// (también un viewer) puede comentar.
+func (ts *TaskService) AddComment(ctx context.Context, taskID string, author *domain.UserContext, body string) (*domain.Activity, error) {
+	ctx = ensureContext(ctx)
+
+	body, err := domain.NormalizeComment(body)
+	if err != nil {
+		return nil, err
+	}
+	if ts.activity == nil {
+		return nil, errors.New("los comentarios no están habilitados")
+	}
+	task, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := &domain.Activity{
+		TaskID:     ownerTaskID,
+		OwnerID:    ownerID,
+		Kind:       domain.ActivityComment,
+		AuthorID:   author.ID,
+		AuthorName: authorName(author),
+		Body:       body,
+		Mentions:   domain.ParseMentions(body, task.GroupMembers),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := ts.activity.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// UpdateComment reemplaza el texto de un comentario propio y lo marca como editado
+func (ts *TaskService) UpdateComment(ctx context.Context, taskID, commentID, userID, body string) (*domain.Activity, error) {
+	ctx = ensureContext(ctx)
+
+	body, err := domain.NormalizeComment(body)
+	if err != nil {
+		return nil, err
+	}
+	task, comment, err := ts.threadComment(ctx, taskID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != userID {
+		return nil, domain.ErrForbidden
+	}
+
+	comment.Body = body
+	comment.Mentions = domain.ParseMentions(body, task.GroupMembers)
+	comment.Edited = true
+	comment.UpdatedAt = time.Now()
+	if err := ts.activity.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment elimina un comentario; lo puede hacer su autor o el dueño de la tarea
+func (ts *TaskService) DeleteComment(ctx context.Context, taskID, commentID, userID string) error {
+	ctx = ensureContext(ctx)
+
+	task, comment, err := ts.threadComment(ctx, taskID, commentID, userID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID && task.AccessRole() != domain.RoleOwner {
+		return domain.ErrForbidden
+	}
+	return ts.activity.Delete(ctx, comment.OwnerID, comment.TaskID, comment.ID)
+}
+
+// threadComment comentario commentID del hilo de la tarea; los cambios de
+// estado no se editan ni se eliminan
+func (ts *TaskService) threadComment(ctx context.Context, taskID, commentID, userID string) (*domain.Task, *domain.Activity, error) {
+	if ts.activity == nil {
+		return nil, nil, domain.ErrCommentNotFound
+	}
+	task, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := ts.activity.GetByID(ctx, ownerID, ownerTaskID, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comment.Kind != domain.ActivityComment {
+		return nil, nil, domain.ErrCommentNotFound
+	}
+	return task, comment, nil
+}
+
+// previousStatus estado guardado de la tarea antes de un cambio, solo si
+// se registra la actividad
+func (ts *TaskService) previousStatus(ctx context.Context, task *domain.Task) string {
+	if ts.activity == nil {
+		return ""
+	}
+	prev, err := ts.repo.GetByID(ctx, task.ID, task.UserID)
+	if err != nil {
+		return ""
+	}
+	return prev.Status
+}
+
+// recordStatusChange agrega el cambio de estado al hilo de la tarea. Un
+// fallo no revierte el cambio.
+func (ts *TaskService) recordStatusChange(ctx context.Context, task *domain.Task, from string) {
+	if ts.activity == nil || from == task.Status {
+		return
+	}
+
+	ownerID, ownerTaskID := task.UserID, task.ID
+	if task.IsSharedCopy() {
+		ownerID, ownerTaskID = task.OwnerID, task.SharedTaskID
+	}
+	entry := &domain.Activity{
+		TaskID:     ownerTaskID,
+		OwnerID:    ownerID,
+		Kind:       domain.ActivityStatus,
+		AuthorID:   task.UserID,
+		FromStatus: from,
+		ToStatus:   task.Status,
+		CreatedAt:  task.UpdatedAt,
+		UpdatedAt:  task.UpdatedAt,
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt, entry.UpdatedAt = time.Now(), time.Now()
+	}
+	if err := ts.activity.Create(ctx, entry); err != nil {
+		log.Printf("⚠️ No se pudo registrar el cambio de estado de %s: %v", task.ID, err)
+	}
+}
+
+// deleteActivity elimina el hilo de una tarea del dueño
+func (ts *TaskService) deleteActivity(ctx context.Context, ownerID, taskID string) {
+	if ts.activity == nil {
+		return
+	}
+	if err := ts.activity.DeleteByTask(ctx, ownerID, taskID); err != nil {
+		log.Printf("⚠️ No se pudo eliminar la actividad de %s: %v", taskID, err)
+	}
+}
+
+// authorName nombre visible del autor de un comentario
+func authorName(u *domain.UserContext) string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.Email
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// agendaPageSize tamaño de página al leer las tareas de la agenda
+const agendaPageSize = 100
+
+// GetAgenda tareas de los próximos days días (hoy incluido) agrupadas por
+// día local en loc, con las vencidas pendientes aparte
+func (ts *TaskService) GetAgenda(ctx context.Context, userID string, loc *time.Location, days int) (*domain.Agenda, error) {
+	ctx = ensureContext(ctx)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if days <= 0 {
+		days = domain.DefaultAgendaDays
+	}
+	if days > domain.MaxAgendaDays {
+		days = domain.MaxAgendaDays
+	}
+
+	now := time.Now()
+	startOfToday, endOfToday := domain.DayRange(now, loc)
+
+	tasks, err := ts.repo.DueToday(ctx, userID, loc)
+	if err != nil {
+		return nil, err
+	}
+	if days > 1 {
+		later, err := ts.findAll(ctx, ports.TaskFilter{
+			UserID:      userID,
+			DueDateFrom: endOfToday,
+			DueDateTo:   startOfToday.AddDate(0, 0, days).Add(-time.Millisecond),
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, later...)
+	}
+
+	overdue, err := ts.findAll(ctx, ports.TaskFilter{
+		UserID:    userID,
+		Status:    []string{domain.StatusTodo, domain.StatusInProgress, domain.StatusInReview},
+		DueDateTo: startOfToday.Add(-time.Millisecond),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	agenda := domain.BuildAgenda(now, loc, days, overdue, tasks)
+	return &agenda, nil
+}
+
+// findAll todas las páginas de filter, por dueDate
+func (ts *TaskService) findAll(ctx context.Context, filter ports.TaskFilter) ([]domain.Task, error) {
+	filter.SortBy, filter.SortOrder = "dueDate", "asc"
+	filter.Limit, filter.SkipCount = agendaPageSize, true
+
+	all := make([]domain.Task, 0)
+	for {
+		tasks, pageInfo, err := ts.repo.FindByFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks...)
+		if !pageInfo.HasNext || pageInfo.NextCursor == "" {
+			return all, nil
+		}
+		filter.After = pageInfo.NextCursor
+	}
+}
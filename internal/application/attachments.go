@@ -0,0 +1,360 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// AttachmentConfig límites de los adjuntos y firma de los enlaces de descarga
+type AttachmentConfig struct {
+	SigningKey   []byte        // clave HMAC de los enlaces (obligatoria)
+	LinkTTL      time.Duration // validez de un enlace (default 15m)
+	MaxBytes     int64         // tamaño máximo por archivo (default 10 MiB)
+	AllowedTypes []string      // tipos MIME aceptados (default domain.DefaultAttachmentTypes)
+}
+
+func (c *AttachmentConfig) withDefaults() AttachmentConfig {
+	cfg := *c
+	if cfg.LinkTTL <= 0 {
+		cfg.LinkTTL = 15 * time.Minute
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = domain.DefaultMaxAttachmentBytes
+	}
+	if len(cfg.AllowedTypes) == 0 {
+		cfg.AllowedTypes = domain.DefaultAttachmentTypes
+	}
+	return cfg
+}
+
+// sniffedTypes tipos que http.DetectContentType reconoce con certeza: si se
+// declara uno de ellos el contenido tiene que coincidir
+var sniffedTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+}
+
+// WithAttachments habilita la subida y descarga de adjuntos
+func (ts *TaskService) WithAttachments(repo ports.AttachmentRepository, store ports.BlobStore, cfg AttachmentConfig) *TaskService {
+	ts.attachments = repo
+	ts.blobs = store
+	ts.attachmentCfg = cfg.withDefaults()
+	return ts
+}
+
+// MaxAttachmentBytes tamaño máximo de un archivo adjunto
+func (ts *TaskService) MaxAttachmentBytes() int64 {
+	return ts.attachmentCfg.MaxBytes
+}
+
+// sharedOrigin tarea del dueño de task (la misma si no es una copia)
+func (ts *TaskService) sharedOrigin(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if !task.IsSharedCopy() {
+		return task, nil
+	}
+	owned, err := ts.repo.GetByID(ctx, task.SharedTaskID, task.OwnerID)
+	if err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return owned, nil
+}
+
+// ListAttachments adjuntos de la tarea; los ve cualquier miembro
+func (ts *TaskService) ListAttachments(ctx context.Context, taskID, userID string) ([]domain.Attachment, error) {
+	ctx = ensureContext(ctx)
+
+	if ts.attachments == nil {
+		return []domain.Attachment{}, nil
+	}
+	_, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.attachments.ListByTask(ctx, ownerID, ownerTaskID)
+}
+
+// GetAttachment adjunto attachmentID de la tarea
+func (ts *TaskService) GetAttachment(ctx context.Context, taskID, attachmentID, userID string) (*domain.Attachment, error) {
+	ctx = ensureContext(ctx)
+
+	if ts.attachments == nil {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	_, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.taskAttachment(ctx, ownerID, ownerTaskID, attachmentID)
+}
+
+// taskAttachment adjunto que pertenece a la tarea del dueño
+func (ts *TaskService) taskAttachment(ctx context.Context, ownerID, taskID, attachmentID string) (*domain.Attachment, error) {
+	a, err := ts.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if a.OwnerID != ownerID || a.TaskID != taskID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+// AddAttachment sube un archivo a la tarea (dueño o editor). Si la tarea ya
+// tiene un archivo con el mismo contenido lo retorna con created=false.
+func (ts *TaskService) AddAttachment(ctx context.Context, taskID, userID, fileName, contentType string, r io.Reader) (a *domain.Attachment, created bool, err error) {
+	ctx = ensureContext(ctx)
+
+	if ts.attachments == nil || ts.blobs == nil {
+		return nil, false, errors.New("los adjuntos no están habilitados")
+	}
+	task, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !task.CanEdit() {
+		return nil, false, domain.ErrForbidden
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, ts.attachmentCfg.MaxBytes+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > ts.attachmentCfg.MaxBytes {
+		return nil, false, domain.ErrAttachmentTooLarge
+	}
+	fileName = domain.CleanFileName(fileName)
+	contentType, err = ts.attachmentType(contentType, fileName, data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if existing, err := ts.attachments.FindByChecksum(ctx, ownerID, ownerTaskID, checksum); err == nil {
+		return existing, false, nil
+	} else if !errors.Is(err, domain.ErrAttachmentNotFound) {
+		return nil, false, err
+	}
+
+	current, err := ts.attachments.ListByTask(ctx, ownerID, ownerTaskID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(current) >= domain.MaxAttachmentsPerTask {
+		return nil, false, domain.ErrAttachmentLimit
+	}
+
+	// El contenido se comparte entre adjuntos con el mismo checksum. La
+	// referencia se toma antes de decidir si escribirlo: mientras exista,
+	// un borrado concurrente no puede eliminarlo.
+	key, created, err := ts.attachments.AcquireBlob(ctx, checksum, domain.AttachmentBlobKey(checksum, blobVersion()))
+	if err != nil {
+		return nil, false, err
+	}
+	write := created
+	if !created {
+		// Otra subida puede no haber terminado (o haber fallado): misma clave, mismo contenido
+		exists, err := ts.blobs.Exists(ctx, key)
+		if err != nil {
+			ts.releaseBlob(ctx, checksum, key)
+			return nil, false, err
+		}
+		write = !exists
+	}
+	if write {
+		if err := ts.blobs.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+			ts.releaseBlob(ctx, checksum, key)
+			return nil, false, err
+		}
+	}
+
+	a = &domain.Attachment{
+		TaskID:      ownerTaskID,
+		OwnerID:     ownerID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Checksum:    checksum,
+		BlobKey:     key,
+		UploadedBy:  userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := ts.attachments.Create(ctx, a); err != nil {
+		ts.releaseBlob(ctx, checksum, key)
+		return nil, false, err
+	}
+
+	if err := ts.setTaskAttachments(ctx, task, func(ids []string) []string { return append(ids, a.ID) }); err != nil {
+		_ = ts.attachments.Delete(ctx, a.ID)
+		ts.releaseBlob(ctx, checksum, key)
+		return nil, false, err
+	}
+	return a, true, nil
+}
+
+// attachmentType tipo MIME del archivo si está permitido
+func (ts *TaskService) attachmentType(declared, fileName string, data []byte) (string, error) {
+	sniffed := domain.NormalizeContentType(http.DetectContentType(data), "")
+	contentType := domain.NormalizeContentType(declared, fileName)
+	if contentType == "" {
+		contentType = sniffed
+	}
+
+	allowed := false
+	for _, t := range ts.attachmentCfg.AllowedTypes {
+		allowed = allowed || t == contentType
+	}
+	if !allowed || (sniffedTypes[contentType] && sniffed != contentType) {
+		return "", domain.ErrAttachmentType
+	}
+	return contentType, nil
+}
+
+// DeleteAttachment quita un adjunto de la tarea (dueño o editor)
+func (ts *TaskService) DeleteAttachment(ctx context.Context, taskID, attachmentID, userID string) error {
+	ctx = ensureContext(ctx)
+
+	if ts.attachments == nil {
+		return domain.ErrAttachmentNotFound
+	}
+	task, ownerID, ownerTaskID, err := ts.threadTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !task.CanEdit() {
+		return domain.ErrForbidden
+	}
+	a, err := ts.taskAttachment(ctx, ownerID, ownerTaskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.attachments.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	ts.releaseBlob(ctx, a.Checksum, a.BlobKey)
+
+	return ts.setTaskAttachments(ctx, task, func(ids []string) []string {
+		kept := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if id != a.ID {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	})
+}
+
+// setTaskAttachments actualiza la lista de adjuntos de la tarea del dueño y
+// la propaga a las copias de los colaboradores
+func (ts *TaskService) setTaskAttachments(ctx context.Context, task *domain.Task, change func([]string) []string) error {
+	owned, err := ts.sharedOrigin(ctx, task)
+	if err != nil {
+		return err
+	}
+	owned.Attachments = change(owned.Attachments)
+	owned.UpdatedAt = time.Now()
+	if err := ts.repo.UpdateShared(ctx, owned); err != nil {
+		return err
+	}
+	ts.publish(ctx, domain.EventTaskUpdated, owned.UserID, owned.ID, owned)
+	ts.syncShared(ctx, owned)
+	return nil
+}
+
+// blobVersion sufijo aleatorio de la clave de un contenido nuevo
+func blobVersion() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// releaseBlob suelta la referencia y elimina el contenido cuando era la última
+func (ts *TaskService) releaseBlob(ctx context.Context, checksum, key string) {
+	unused, err := ts.attachments.ReleaseBlob(ctx, checksum, key)
+	if err != nil {
+		log.Printf("⚠️ No se pudo liberar el blob %s: %v", key, err)
+		return
+	}
+	if !unused {
+		return
+	}
+	if err := ts.blobs.Delete(ctx, key); err != nil {
+		log.Printf("⚠️ No se pudo eliminar el blob %s: %v", key, err)
+	}
+}
+
+// deleteAttachments elimina los adjuntos (y sus blobs sin otros usos) de
+// una tarea del dueño que se eliminó
+func (ts *TaskService) deleteAttachments(ctx context.Context, ownerID, taskID string) {
+	if ts.attachments == nil {
+		return
+	}
+	list, err := ts.attachments.ListByTask(ctx, ownerID, taskID)
+	if err != nil {
+		log.Printf("⚠️ No se pudieron listar los adjuntos de %s: %v", taskID, err)
+		return
+	}
+	for _, a := range list {
+		if err := ts.attachments.Delete(ctx, a.ID); err != nil {
+			log.Printf("⚠️ No se pudo eliminar el adjunto %s: %v", a.ID, err)
+			continue
+		}
+		ts.releaseBlob(ctx, a.Checksum, a.BlobKey)
+	}
+}
+
+// SignAttachment firma un enlace de descarga del adjunto válido hasta expires
+func (ts *TaskService) SignAttachment(attachmentID string, now time.Time) (expires time.Time, signature string) {
+	expires = now.Add(ts.attachmentCfg.LinkTTL).Truncate(time.Second)
+	return expires, ts.attachmentSignature(attachmentID, expires.Unix())
+}
+
+func (ts *TaskService) attachmentSignature(attachmentID string, expires int64) string {
+	mac := hmac.New(sha256.New, ts.attachmentCfg.SigningKey)
+	mac.Write([]byte(attachmentID + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// OpenSignedAttachment valida un enlace firmado y abre el contenido. No
+// requiere autenticación: la firma prueba que lo generó un miembro.
+func (ts *TaskService) OpenSignedAttachment(ctx context.Context, attachmentID string, expires int64, signature string, now time.Time) (*domain.Attachment, io.ReadCloser, error) {
+	ctx = ensureContext(ctx)
+
+	if ts.attachments == nil || len(ts.attachmentCfg.SigningKey) == 0 {
+		return nil, nil, domain.ErrAttachmentNotFound
+	}
+	expected := ts.attachmentSignature(attachmentID, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, nil, domain.ErrAttachmentLinkInvalid
+	}
+	if now.Unix() > expires {
+		return nil, nil, domain.ErrAttachmentLinkExpired
+	}
+
+	a, err := ts.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := ts.blobs.Get(ctx, a.BlobKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, body, nil
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/blob"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+// Subir un contenido mientras se elimina su última referencia en otra tarea
+// nunca deja un adjunto sin contenido
+func TestAttachmentUploadRacesLastDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := NewTaskService(memory.NewRepo(), nil).
+		WithAttachments(memory.NewAttachmentRepo(), store, AttachmentConfig{SigningKey: []byte("test-key")})
+
+	newTask := func(title string) string {
+		task := &domain.Task{UserID: "user-1", Title: title, SubjectID: "fis-1", DueDate: time.Now().Add(72 * time.Hour),
+			Status: domain.StatusTodo, Priority: domain.PriorityMedium, Type: domain.TypeLab}
+		if err := ts.CreateTask(ctx, task, "user-1", "", ""); err != nil {
+			t.Fatal(err)
+		}
+		return task.ID
+	}
+	from, to := newTask("Informe 1"), newTask("Informe 2")
+	content := []byte("%PDF-1.4 enunciado del laboratorio")
+	add := func(taskID string) *domain.Attachment {
+		a, _, err := ts.AddAttachment(ctx, taskID, "user-1", "enunciado.pdf", "application/pdf", bytes.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+
+	for i := 0; i < 50; i++ {
+		old := add(from)
+
+		var wg sync.WaitGroup
+		var uploaded *domain.Attachment
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := ts.DeleteAttachment(ctx, from, old.ID, "user-1"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			uploaded, _, _ = ts.AddAttachment(ctx, to, "user-1", "copia.pdf", "application/pdf", bytes.NewReader(content))
+		}()
+		wg.Wait()
+
+		if uploaded == nil {
+			t.Fatal("upload failed")
+		}
+		rc, err := store.Get(ctx, uploaded.BlobKey)
+		if err != nil {
+			t.Fatalf("iteration %d: attachment without content: %v", i, err)
+		}
+		got, _ := io.ReadAll(rc)
+		rc.Close()
+		if !bytes.Equal(got, content) {
+			t.Fatalf("iteration %d: content = %q", i, got)
+		}
+
+		if err := ts.DeleteAttachment(ctx, to, uploaded.ID, "user-1"); err != nil {
+			t.Fatal(err)
+		}
+		if ok, _ := store.Exists(ctx, uploaded.BlobKey); ok {
+			t.Fatalf("iteration %d: content kept after its last reference", i)
+		}
+	}
+}
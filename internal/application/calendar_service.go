@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// feedPageSize tamaño de página al recorrer las tareas del feed
+const feedPageSize = 200
+
+// CalendarService gestiona los tokens y el contenido de los feeds ICS
+type CalendarService struct {
+	tasks  ports.TaskRepository
+	tokens ports.CalendarTokenRepository
+}
+
+// NewCalendarService crea una nueva instancia de CalendarService
+func NewCalendarService(tasks ports.TaskRepository, tokens ports.CalendarTokenRepository) *CalendarService {
+	return &CalendarService{
+		tasks:  tasks,
+		tokens: tokens,
+	}
+}
+
+// CreateToken genera un nuevo token secreto para el usuario.
+// Retorna el valor en claro (solo se muestra una vez) y el registro persistido.
+func (cs *CalendarService) CreateToken(ctx context.Context, userID, name string) (string, *domain.CalendarToken, error) {
+	ctx = ensureContext(ctx)
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("error al generar token: %w", err)
+	}
+	plain := base64.RawURLEncoding.EncodeToString(raw)
+
+	if name == "" {
+		name = "Calendario"
+	}
+
+	token := &domain.CalendarToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashCalendarToken(plain),
+		CreatedAt: time.Now(),
+	}
+	if err := cs.tokens.Create(ctx, token); err != nil {
+		return "", nil, err
+	}
+
+	return plain, token, nil
+}
+
+// ListTokens lista los tokens del usuario
+func (cs *CalendarService) ListTokens(ctx context.Context, userID string) ([]domain.CalendarToken, error) {
+	return cs.tokens.ListByUser(ensureContext(ctx), userID)
+}
+
+// RevokeToken revoca un token del usuario; el feed deja de responder de inmediato
+func (cs *CalendarService) RevokeToken(ctx context.Context, tokenID, userID string) error {
+	return cs.tokens.Revoke(ensureContext(ctx), tokenID, userID, time.Now())
+}
+
+// FeedTasks resuelve el token y retorna las tareas del dueño que cumplen el filtro
+func (cs *CalendarService) FeedTasks(ctx context.Context, plainToken string, f domain.CalendarFeedFilter) ([]domain.Task, error) {
+	ctx = ensureContext(ctx)
+
+	token, err := cs.tokens.GetByHash(ctx, hashCalendarToken(plainToken))
+	if err != nil {
+		return nil, err
+	}
+	if token.IsRevoked() {
+		return nil, domain.ErrCalendarTokenNotFound
+	}
+
+	if err := cs.tokens.TouchLastUsed(ctx, token.ID, time.Now()); err != nil {
+		// No bloquea el feed
+		log.Printf("⚠️ Error al registrar uso del token %s: %v", token.ID, err)
+	}
+
+	filter := ports.TaskFilter{
+		UserID:    token.UserID,
+		PeriodID:  f.PeriodID,
+		SubjectID: f.SubjectID,
+		Type:      f.Type,
+		SortBy:    "dueDate",
+		SortOrder: "asc",
+		Limit:     feedPageSize,
+		SkipCount: true,
+	}
+
+	all := make([]domain.Task, 0)
+	for {
+		tasks, pageInfo, err := cs.tasks.FindByFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks...)
+		if !pageInfo.HasNext || pageInfo.NextCursor == "" {
+			break
+		}
+		filter.After = pageInfo.NextCursor
+	}
+
+	return all, nil
+}
+
+// hashCalendarToken calcula el hash que se persiste para un token en claro
+func hashCalendarToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,370 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// WithInvitations habilita las invitaciones por email a tareas compartidas
+func (ts *TaskService) WithInvitations(repo ports.InvitationRepository) *TaskService {
+	ts.invitations = repo
+	return ts
+}
+
+// ownedTask tarea taskID de la que userID es dueño; las copias de
+// colaboradores no se pueden compartir ni administrar
+func (ts *TaskService) ownedTask(ctx context.Context, taskID, userID string) (*domain.Task, error) {
+	task, err := ts.repo.GetByID(ctx, taskID, userID)
+	if err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	if task.IsSharedCopy() {
+		return nil, domain.ErrForbidden
+	}
+	return task, nil
+}
+
+// ListCollaborators miembros de la tarea (dueño primero) con su progreso
+// personal. Las invitaciones pendientes solo las ve el dueño.
+func (ts *TaskService) ListCollaborators(ctx context.Context, taskID, userID string) ([]domain.Collaborator, error) {
+	ctx = ensureContext(ctx)
+
+	task, err := ts.repo.GetByID(ctx, taskID, userID)
+	if err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	owned := task
+	if task.IsSharedCopy() {
+		if owned, err = ts.repo.GetByID(ctx, task.SharedTaskID, task.OwnerID); err != nil {
+			return nil, domain.ErrTaskNotFound
+		}
+	}
+
+	copies, err := ts.repo.ListShared(ctx, owned.UserID, owned.ID)
+	if err != nil {
+		return nil, err
+	}
+	members := []domain.Collaborator{domain.MemberOf(owned)}
+	for i := range copies {
+		members = append(members, domain.MemberOf(&copies[i]))
+	}
+
+	if !task.IsSharedCopy() && ts.invitations != nil {
+		pending, err := ts.invitations.ListByTask(ctx, owned.UserID, owned.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range pending {
+			members = append(members, domain.PendingMember(&pending[i]))
+		}
+	}
+
+	return members, nil
+}
+
+// AddCollaborator comparte la tarea del dueño. Con inviteeID el colaborador
+// recibe su copia de inmediato; con email queda una invitación pendiente.
+func (ts *TaskService) AddCollaborator(ctx context.Context, taskID, ownerID, inviteeID, email, role, invitedBy string) (*domain.Collaborator, error) {
+	ctx = ensureContext(ctx)
+
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+	task, err := ts.ownedTask(ctx, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := ts.repo.ListShared(ctx, ownerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	var pending []domain.Invitation
+	if ts.invitations != nil {
+		if pending, err = ts.invitations.ListByTask(ctx, ownerID, taskID); err != nil {
+			return nil, err
+		}
+	}
+	if len(copies)+len(pending) >= domain.MaxCollaboratorsPerTask {
+		return nil, domain.ErrCollaboratorLimit
+	}
+
+	if !task.IsGroupWork {
+		task.IsGroupWork = true
+		task.UpdatedAt = time.Now()
+		if err := ts.repo.UpdateShared(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	if inviteeID != "" {
+		if inviteeID == ownerID || findCopy(copies, inviteeID) != nil {
+			return nil, domain.ErrAlreadyCollaborator
+		}
+		shared, err := ts.createSharedCopy(ctx, task, inviteeID, role)
+		if err != nil {
+			return nil, err
+		}
+		member := domain.MemberOf(shared)
+		return &member, nil
+	}
+
+	if ts.invitations == nil {
+		return nil, errors.New("las invitaciones por email no están habilitadas")
+	}
+	email = domain.NormalizeEmail(email)
+	for _, inv := range pending {
+		if inv.Email == email {
+			return nil, domain.ErrAlreadyCollaborator
+		}
+	}
+	inv := &domain.Invitation{
+		TaskID:    task.ID,
+		OwnerID:   ownerID,
+		Email:     email,
+		Role:      role,
+		TaskTitle: task.Title,
+		InvitedBy: invitedBy,
+		CreatedAt: time.Now(),
+	}
+	if err := ts.invitations.Create(ctx, inv); err != nil {
+		return nil, err
+	}
+	member := domain.PendingMember(inv)
+	return &member, nil
+}
+
+// createSharedCopy crea la copia del colaborador y la publica en su nombre
+func (ts *TaskService) createSharedCopy(ctx context.Context, owned *domain.Task, userID, role string) (*domain.Task, error) {
+	shared := domain.NewSharedCopy(owned, userID, role, time.Now())
+	if err := ts.repo.Create(ctx, shared); err != nil {
+		return nil, err
+	}
+	ts.publish(ctx, domain.EventTaskCreated, userID, shared.ID, shared)
+	return shared, nil
+}
+
+// findCopy copia del usuario entre las de una tarea
+func findCopy(copies []domain.Task, userID string) *domain.Task {
+	for i := range copies {
+		if copies[i].UserID == userID {
+			return &copies[i]
+		}
+	}
+	return nil
+}
+
+// UpdateCollaboratorRole cambia el rol de un colaborador (no el del dueño)
+func (ts *TaskService) UpdateCollaboratorRole(ctx context.Context, taskID, ownerID, memberID, role string) (*domain.Collaborator, error) {
+	ctx = ensureContext(ctx)
+
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+	if _, err := ts.ownedTask(ctx, taskID, ownerID); err != nil {
+		return nil, err
+	}
+	copies, err := ts.repo.ListShared(ctx, ownerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	shared := findCopy(copies, memberID)
+	if shared == nil {
+		return nil, domain.ErrCollaboratorNotFound
+	}
+
+	shared.Role = role
+	shared.UpdatedAt = time.Now()
+	if err := ts.repo.UpdateShared(ctx, shared); err != nil {
+		return nil, err
+	}
+	ts.publish(ctx, domain.EventTaskUpdated, shared.UserID, shared.ID, shared)
+
+	member := domain.MemberOf(shared)
+	return &member, nil
+}
+
+// RemoveCollaborator quita a un colaborador (member = su userID) o revoca
+// una invitación pendiente (member = email)
+func (ts *TaskService) RemoveCollaborator(ctx context.Context, taskID, ownerID, member string) error {
+	ctx = ensureContext(ctx)
+
+	if _, err := ts.ownedTask(ctx, taskID, ownerID); err != nil {
+		return err
+	}
+
+	if strings.Contains(member, "@") {
+		if ts.invitations == nil {
+			return domain.ErrCollaboratorNotFound
+		}
+		pending, err := ts.invitations.ListByTask(ctx, ownerID, taskID)
+		if err != nil {
+			return err
+		}
+		for _, inv := range pending {
+			if inv.Email == domain.NormalizeEmail(member) {
+				return ts.invitations.Delete(ctx, inv.ID)
+			}
+		}
+		return domain.ErrCollaboratorNotFound
+	}
+
+	copies, err := ts.repo.ListShared(ctx, ownerID, taskID)
+	if err != nil {
+		return err
+	}
+	shared := findCopy(copies, member)
+	if shared == nil {
+		return domain.ErrCollaboratorNotFound
+	}
+	if err := ts.repo.DeleteShared(ctx, shared.ID, shared.UserID); err != nil {
+		return err
+	}
+	ts.publish(ctx, domain.EventTaskDeleted, shared.UserID, shared.ID, nil)
+	return nil
+}
+
+// ListInvitations invitaciones pendientes para el email del usuario
+func (ts *TaskService) ListInvitations(ctx context.Context, email string) ([]domain.Invitation, error) {
+	email = domain.NormalizeEmail(email)
+	if ts.invitations == nil || email == "" {
+		return []domain.Invitation{}, nil
+	}
+	return ts.invitations.ListByEmail(ensureContext(ctx), email)
+}
+
+// invitationFor invitación dirigida a email; la de otro destinatario se
+// trata como inexistente
+func (ts *TaskService) invitationFor(ctx context.Context, invitationID, email string) (*domain.Invitation, error) {
+	if ts.invitations == nil {
+		return nil, domain.ErrInvitationNotFound
+	}
+	inv, err := ts.invitations.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if email == "" || inv.Email != domain.NormalizeEmail(email) {
+		return nil, domain.ErrInvitationNotFound
+	}
+	return inv, nil
+}
+
+// AcceptInvitation crea la copia del usuario y elimina la invitación
+func (ts *TaskService) AcceptInvitation(ctx context.Context, invitationID, userID, email string) (*domain.Task, error) {
+	ctx = ensureContext(ctx)
+
+	inv, err := ts.invitationFor(ctx, invitationID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := ts.repo.GetByID(ctx, inv.TaskID, inv.OwnerID)
+	if err != nil {
+		// La tarea se eliminó después de invitar
+		_ = ts.invitations.Delete(ctx, inv.ID)
+		return nil, domain.ErrInvitationNotFound
+	}
+	copies, err := ts.repo.ListShared(ctx, inv.OwnerID, inv.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == inv.OwnerID || findCopy(copies, userID) != nil {
+		_ = ts.invitations.Delete(ctx, inv.ID)
+		return nil, domain.ErrAlreadyCollaborator
+	}
+
+	shared, err := ts.createSharedCopy(ctx, owned, userID, inv.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.invitations.Delete(ctx, inv.ID); err != nil {
+		log.Printf("⚠️ No se pudo eliminar la invitación aceptada %s: %v", inv.ID, err)
+	}
+	return shared, nil
+}
+
+// DeclineInvitation rechaza una invitación dirigida al email del usuario
+func (ts *TaskService) DeclineInvitation(ctx context.Context, invitationID, email string) error {
+	ctx = ensureContext(ctx)
+
+	inv, err := ts.invitationFor(ctx, invitationID, email)
+	if err != nil {
+		return err
+	}
+	return ts.invitations.Delete(ctx, inv.ID)
+}
+
+// updateFromSharedCopy aplica en la tarea del dueño los datos editados en
+// la copia de un editor y los propaga a todos los miembros
+func (ts *TaskService) updateFromSharedCopy(ctx context.Context, edited *domain.Task) error {
+	owned, err := ts.repo.GetByID(ctx, edited.SharedTaskID, edited.OwnerID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+	owned.CopySharedFields(edited)
+	owned.UpdatedAt = edited.UpdatedAt
+	if err := owned.IsValid(); err != nil {
+		return err
+	}
+	if err := ts.repo.UpdateShared(ctx, owned); err != nil {
+		return err
+	}
+	ts.publish(ctx, domain.EventTaskUpdated, owned.UserID, owned.ID, owned)
+
+	ts.syncShared(ctx, owned)
+	return nil
+}
+
+// syncShared copia los datos compartidos de owned a las copias de sus
+// colaboradores. Un fallo en una copia no revierte la edición del dueño.
+func (ts *TaskService) syncShared(ctx context.Context, owned *domain.Task) {
+	copies, err := ts.repo.ListShared(ctx, owned.UserID, owned.ID)
+	if err != nil {
+		log.Printf("⚠️ No se pudieron listar los colaboradores de %s: %v", owned.ID, err)
+		return
+	}
+	for i := range copies {
+		c := &copies[i]
+		c.CopySharedFields(owned)
+		c.UpdatedAt = owned.UpdatedAt
+		if err := ts.repo.UpdateShared(ctx, c); err != nil {
+			log.Printf("⚠️ No se pudo actualizar la copia %s de %s: %v", c.ID, c.UserID, err)
+			continue
+		}
+		ts.publish(ctx, domain.EventTaskUpdated, c.UserID, c.ID, c)
+	}
+}
+
+// deleteShared elimina las copias y las invitaciones de una tarea del dueño
+func (ts *TaskService) deleteShared(ctx context.Context, ownerID, taskID string) {
+	copies, err := ts.repo.ListShared(ctx, ownerID, taskID)
+	if err != nil {
+		log.Printf("⚠️ No se pudieron listar los colaboradores de %s: %v", taskID, err)
+		return
+	}
+	for _, c := range copies {
+		if err := ts.repo.DeleteShared(ctx, c.ID, c.UserID); err != nil {
+			log.Printf("⚠️ No se pudo eliminar la copia %s de %s: %v", c.ID, c.UserID, err)
+			continue
+		}
+		ts.publish(ctx, domain.EventTaskDeleted, c.UserID, c.ID, nil)
+	}
+
+	if ts.invitations == nil {
+		return
+	}
+	pending, err := ts.invitations.ListByTask(ctx, ownerID, taskID)
+	if err != nil {
+		log.Printf("⚠️ No se pudieron listar las invitaciones de %s: %v", taskID, err)
+		return
+	}
+	for _, inv := range pending {
+		_ = ts.invitations.Delete(ctx, inv.ID)
+	}
+}
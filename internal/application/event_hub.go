@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// EventHubConfig parámetros del hub de eventos en tiempo real
+type EventHubConfig struct {
+	ReplaySize       int           // eventos por usuario disponibles para reanudar (default 256)
+	ReplayWindow     time.Duration // antigüedad máxima del buffer de un usuario (default 15m)
+	SubscriberBuffer int           // eventos pendientes por conexión antes de cortarla (default 64)
+}
+
+func (c *EventHubConfig) withDefaults() EventHubConfig {
+	cfg := *c
+	if cfg.ReplaySize <= 0 {
+		cfg.ReplaySize = 256
+	}
+	if cfg.ReplayWindow <= 0 {
+		cfg.ReplayWindow = 15 * time.Minute
+	}
+	if cfg.SubscriberBuffer <= 0 {
+		cfg.SubscriberBuffer = 64
+	}
+	return cfg
+}
+
+// Subscription conexión de un usuario al stream de eventos.
+// C se cierra si el cliente no consume a tiempo: debe reconectar con Last-Event-ID.
+type Subscription struct {
+	C      <-chan domain.TaskEvent
+	ch     chan domain.TaskEvent
+	userID string
+	closed bool
+}
+
+// replayBuffer últimos eventos de un usuario (ring buffer)
+type replayBuffer struct {
+	events []domain.TaskEvent
+	start  int
+	lastAt time.Time
+}
+
+func (b *replayBuffer) add(e domain.TaskEvent, size int) {
+	if len(b.events) < size {
+		b.events = append(b.events, e)
+	} else {
+		b.events[b.start] = e
+		b.start = (b.start + 1) % size
+	}
+	b.lastAt = time.Now()
+}
+
+// since eventos posteriores a lastID; false si lastID ya no está en el buffer
+func (b *replayBuffer) since(lastID string) ([]domain.TaskEvent, bool) {
+	n := len(b.events)
+	for i := 0; i < n; i++ {
+		if b.events[(b.start+i)%n].ID == lastID {
+			out := make([]domain.TaskEvent, 0, n-i-1)
+			for j := i + 1; j < n; j++ {
+				out = append(out, b.events[(b.start+j)%n])
+			}
+			return out, true
+		}
+	}
+	return nil, false
+}
+
+// EventHub reparte los eventos de tareas a las conexiones SSE de cada usuario.
+// Implementa ports.EventPublisher: publica a través del broadcaster para que
+// todas las réplicas (incluida esta) reciban el evento en Run.
+type EventHub struct {
+	broadcaster ports.EventBroadcaster
+	cfg         EventHubConfig
+
+	mu        sync.Mutex
+	subs      map[string]map[*Subscription]struct{}
+	buffers   map[string]*replayBuffer
+	lastPrune time.Time
+}
+
+// NewEventHub crea una nueva instancia de EventHub
+func NewEventHub(broadcaster ports.EventBroadcaster, cfg EventHubConfig) *EventHub {
+	return &EventHub{
+		broadcaster: broadcaster,
+		cfg:         cfg.withDefaults(),
+		subs:        make(map[string]map[*Subscription]struct{}),
+		buffers:     make(map[string]*replayBuffer),
+	}
+}
+
+// Run recibe los eventos del broadcaster hasta que ctx se cancele
+func (h *EventHub) Run(ctx context.Context) {
+	if err := h.broadcaster.Run(ctx, h.dispatch); err != nil {
+		log.Printf("⚠️ Broadcaster de eventos detenido: %v", err)
+	}
+}
+
+// Publish envía el evento a todas las réplicas (ports.EventPublisher)
+func (h *EventHub) Publish(ctx context.Context, event domain.TaskEvent) {
+	if err := h.broadcaster.Broadcast(ensureContext(ctx), event); err != nil {
+		log.Printf("⚠️ Error al difundir evento %s: %v", event.ID, err)
+	}
+}
+
+// Subscribe registra una conexión del usuario. Si lastEventID no está vacío
+// retorna los eventos posteriores a él; resumed es false si ya no está en el
+// buffer (el cliente debe recargar su estado completo).
+func (h *EventHub) Subscribe(userID, lastEventID string) (sub *Subscription, replay []domain.TaskEvent, resumed bool) {
+	ch := make(chan domain.TaskEvent, h.cfg.SubscriberBuffer)
+	sub = &Subscription{C: ch, ch: ch, userID: userID}
+
+	// Bajo el mismo lock que dispatch: no se pierden eventos entre replay y suscripción
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	resumed = true
+	if lastEventID != "" {
+		resumed = false
+		if buf, ok := h.buffers[userID]; ok {
+			replay, resumed = buf.since(lastEventID)
+		}
+	}
+
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*Subscription]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+
+	return sub, replay, resumed
+}
+
+// Unsubscribe elimina la conexión
+func (h *EventHub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+}
+
+// remove requiere el lock tomado
+func (h *EventHub) remove(sub *Subscription) {
+	if !sub.closed {
+		sub.closed = true
+		close(sub.ch)
+	}
+	if set, ok := h.subs[sub.userID]; ok {
+		delete(set, sub)
+		if len(set) == 0 {
+			delete(h.subs, sub.userID)
+		}
+	}
+}
+
+// Subscribers conexiones activas del usuario
+func (h *EventHub) Subscribers(userID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subs[userID])
+}
+
+// dispatch guarda el evento en el buffer del usuario y lo entrega a sus conexiones
+func (h *EventHub) dispatch(event domain.TaskEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buf, ok := h.buffers[event.UserID]
+	if !ok {
+		buf = &replayBuffer{}
+		h.buffers[event.UserID] = buf
+	}
+	buf.add(event, h.cfg.ReplaySize)
+
+	for sub := range h.subs[event.UserID] {
+		select {
+		case sub.ch <- event:
+		default:
+			// Cliente lento: se corta y reanudará desde el buffer
+			h.remove(sub)
+		}
+	}
+
+	h.prune(time.Now())
+}
+
+// prune descarta buffers inactivos (como máximo una vez por minuto)
+func (h *EventHub) prune(now time.Time) {
+	if now.Sub(h.lastPrune) < time.Minute {
+		return
+	}
+	h.lastPrune = now
+	for userID, buf := range h.buffers {
+		if now.Sub(buf.lastAt) > h.cfg.ReplayWindow {
+			delete(h.buffers, userID)
+		}
+	}
+}
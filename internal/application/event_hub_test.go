@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/broadcast"
+)
+
+func startHub(t *testing.T, cfg EventHubConfig) *EventHub {
+	t.Helper()
+	hub := NewEventHub(broadcast.NewLocal(), cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Run(ctx)
+	return hub
+}
+
+func recv(t *testing.T, sub *Subscription) domain.TaskEvent {
+	t.Helper()
+	select {
+	case e, ok := <-sub.C:
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	return domain.TaskEvent{}
+}
+
+func TestEventHubDeliversOnlyToOwner(t *testing.T) {
+	hub := startHub(t, EventHubConfig{})
+
+	alice, _, _ := hub.Subscribe("alice", "")
+	bob, _, _ := hub.Subscribe("bob", "")
+
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "e1", Type: domain.EventTaskCreated, UserID: "alice"})
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "e2", Type: domain.EventTaskCreated, UserID: "bob"})
+
+	if e := recv(t, alice); e.ID != "e1" {
+		t.Errorf("alice got %s", e.ID)
+	}
+	if e := recv(t, bob); e.ID != "e2" {
+		t.Errorf("bob got %s", e.ID)
+	}
+}
+
+func TestEventHubResumeFromReplayBuffer(t *testing.T) {
+	hub := startHub(t, EventHubConfig{ReplaySize: 3})
+
+	sub, _, _ := hub.Subscribe("alice", "")
+	for _, id := range []string{"e1", "e2", "e3", "e4"} {
+		hub.Publish(context.Background(), domain.TaskEvent{ID: id, Type: domain.EventTaskUpdated, UserID: "alice"})
+		recv(t, sub)
+	}
+	hub.Unsubscribe(sub)
+
+	_, replay, resumed := hub.Subscribe("alice", "e2")
+	if !resumed || len(replay) != 2 || replay[0].ID != "e3" || replay[1].ID != "e4" {
+		t.Errorf("expected replay e3,e4, got %v (resumed=%v)", replay, resumed)
+	}
+
+	// e1 salió del buffer (tamaño 3)
+	if _, _, resumed := hub.Subscribe("alice", "e1"); resumed {
+		t.Error("expected resume to fail for evicted event")
+	}
+}
+
+func TestEventHubDropsSlowSubscribers(t *testing.T) {
+	hub := startHub(t, EventHubConfig{SubscriberBuffer: 1})
+
+	slow, _, _ := hub.Subscribe("alice", "")
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "e1", UserID: "alice"})
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "e2", UserID: "alice"})
+
+	deadline := time.After(time.Second)
+	for hub.Subscribers("alice") != 0 {
+		select {
+		case <-deadline:
+			t.Fatal("slow subscriber was not dropped")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	// El evento pendiente se entrega y luego el canal se cierra
+	if e := <-slow.C; e.ID != "e1" {
+		t.Errorf("expected e1, got %s", e.ID)
+	}
+	if _, open := <-slow.C; open {
+		t.Error("expected channel closed")
+	}
+}
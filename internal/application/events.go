@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// AddEventPublisher registra un destino para los eventos de tareas
+// (webhooks, streaming). Debe llamarse antes de atender requests.
+func (ts *TaskService) AddEventPublisher(p ports.EventPublisher) {
+	ts.publishers = append(ts.publishers, p)
+}
+
+// publish notifica un cambio de tarea a todos los publishers registrados
+func (ts *TaskService) publish(ctx context.Context, eventType, userID, taskID string, task *domain.Task) {
+	if len(ts.publishers) == 0 {
+		return
+	}
+
+	event := domain.TaskEvent{
+		ID:         newEventID(),
+		Type:       eventType,
+		UserID:     userID,
+		TaskID:     taskID,
+		OccurredAt: time.Now(),
+	}
+	if task != nil {
+		cp := *task
+		event.Task = &cp
+	}
+
+	// El evento sobrevive a la cancelación del request que lo originó
+	ctx = context.WithoutCancel(ensureContext(ctx))
+	for _, p := range ts.publishers {
+		p.Publish(ctx, event)
+	}
+}
+
+// statusEventType evento correspondiente a un cambio de estado
+func statusEventType(task *domain.Task) string {
+	if task.IsCompleted() {
+		return domain.EventTaskCompleted
+	}
+	return domain.EventTaskStatusChanged
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// ImportOptions controla cómo se aplican los borradores importados
+type ImportOptions struct {
+	DryRun bool // solo reporta lo que se crearía/actualizaría
+	Upsert bool // si el externalId ya existe, actualiza en lugar de fallar
+
+	// PreserveProgress conserva estado, prioridad y tiempo real de la tarea
+	// existente al actualizar (útil para calendarios que se reimportan)
+	PreserveProgress bool
+}
+
+// ImportTasks valida y persiste (o simula) un lote de borradores importados.
+// Los ítems inválidos no detienen el lote: se reportan con su línea de origen.
+func (ts *TaskService) ImportTasks(ctx context.Context, userID string, drafts []domain.ImportDraft, opts ImportOptions) (*domain.ImportResult, error) {
+	ctx = ensureContext(ctx)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	result := &domain.ImportResult{
+		DryRun:  opts.DryRun,
+		Created: make([]domain.Task, 0),
+		Updated: make([]domain.Task, 0),
+		Errors:  make([]domain.ImportRowError, 0),
+	}
+	seen := make(map[string]int)
+
+	for _, d := range drafts {
+		task := d.Task
+		task.UserID = userID
+		// El "id" de una exportación identifica la tarea a actualizar; las
+		// tareas nuevas siempre reciben un ID propio
+		exportedID := task.ID
+		task.ID = ""
+
+		rowErr := func(err error) {
+			result.Errors = append(result.Errors, domain.ImportRowError{
+				Line:       d.Line,
+				ExternalID: task.ExternalID,
+				Message:    err.Error(),
+			})
+		}
+
+		if d.Err != nil {
+			rowErr(d.Err)
+			continue
+		}
+		if err := task.IsValid(); err != nil {
+			rowErr(err)
+			continue
+		}
+
+		if key := importKey(task.ExternalID, exportedID); key != "" {
+			if line, dup := seen[key]; dup {
+				rowErr(fmt.Errorf("%s duplicado (ya aparece en la línea %d)", importKeyName(task.ExternalID), line))
+				continue
+			}
+			seen[key] = d.Line
+
+			existing, err := ts.importedTask(ctx, userID, task.ExternalID, exportedID)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				if !opts.Upsert {
+					rowErr(fmt.Errorf("ya existe una tarea con %s %q", importKeyName(task.ExternalID), strings.TrimPrefix(key, "id:")))
+					continue
+				}
+				// Una tarea ya completada o cancelada no se edita ni se reabre al reimportar
+				if existing.IsCompleted() || existing.IsCancelled() {
+					result.Unchanged++
+					continue
+				}
+				updated, changed := mergeImported(existing, &task, opts.PreserveProgress)
+				if !changed {
+					result.Unchanged++
+					continue
+				}
+				// En una tarea compartida solo el dueño o un editor cambian los
+				// datos comunes; un lector puede actualizar su propio estado
+				if _, edits := importedEdit(existing, updated); edits && !existing.CanEdit() {
+					rowErr(domain.ErrForbidden)
+					continue
+				}
+				if !opts.DryRun {
+					if err := ts.applyImported(ctx, existing, updated); err != nil {
+						rowErr(err)
+						continue
+					}
+				}
+				result.Updated = append(result.Updated, *updated)
+				continue
+			}
+		}
+
+		if !opts.DryRun {
+			if err := ts.repo.Create(ctx, &task); err != nil {
+				rowErr(err)
+				continue
+			}
+			ts.publish(ctx, domain.EventTaskCreated, userID, task.ID, &task)
+		}
+		result.Created = append(result.Created, task)
+	}
+
+	return result, nil
+}
+
+// importKey clave de deduplicación de una fila: externalId o, si no lo
+// tiene, el id exportado
+func importKey(externalID, exportedID string) string {
+	switch {
+	case externalID != "":
+		return externalID
+	case exportedID != "":
+		return "id:" + exportedID
+	}
+	return ""
+}
+
+func importKeyName(externalID string) string {
+	if externalID != "" {
+		return "externalId"
+	}
+	return "id"
+}
+
+// importedTask tarea existente que corresponde a la fila: por externalId o,
+// sin él, por el id de una exportación previa del mismo usuario. Un id que no
+// existe (o de otro usuario) no es un error: la fila se crea como tarea nueva.
+func (ts *TaskService) importedTask(ctx context.Context, userID, externalID, exportedID string) (*domain.Task, error) {
+	if externalID != "" {
+		existing, err := ts.repo.GetByExternalID(ctx, userID, externalID)
+		if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, err
+		}
+		return existing, nil
+	}
+	if existing, err := ts.repo.GetByID(ctx, exportedID, userID); err == nil {
+		return existing, nil
+	}
+	return nil, nil
+}
+
+// applyImported persiste la tarea reimportada por las mismas rutas que la
+// API: los datos compartidos como UpdateTask (rol del usuario, tarea del dueño
+// y copias de los colaboradores) y el estado como UpdateTaskStatus, que
+// permite cancelar o completar y lo registra en la actividad
+func (ts *TaskService) applyImported(ctx context.Context, existing, updated *domain.Task) error {
+	if edited, edits := importedEdit(existing, updated); edits {
+		if err := ts.UpdateTask(ctx, edited); err != nil {
+			return err
+		}
+	}
+	if updated.Status != existing.Status {
+		return ts.UpdateTaskStatus(ctx, updated)
+	}
+	return nil
+}
+
+// importedEdit la tarea importada con el estado de existing (solo los datos
+// editables) e indica si esos datos cambiaron
+func importedEdit(existing, updated *domain.Task) (*domain.Task, bool) {
+	edited := *updated
+	edited.Status, edited.CompletedAt = existing.Status, existing.CompletedAt
+	return &edited, !sameImportedFields(existing, &edited)
+}
+
+// mergeImported aplica los campos importados sobre la tarea existente.
+// Retorna la tarea resultante y si hubo cambios.
+func mergeImported(existing, incoming *domain.Task, preserveProgress bool) (*domain.Task, bool) {
+	merged := *existing
+	merged.Title = incoming.Title
+	merged.Description = incoming.Description
+	merged.SubjectID = incoming.SubjectID
+	merged.PeriodID = incoming.PeriodID
+	merged.DueDate = incoming.DueDate
+	merged.Type = incoming.Type
+	merged.Tags = incoming.Tags
+	if incoming.EstimatedTimeHours > 0 {
+		merged.EstimatedTimeHours = incoming.EstimatedTimeHours
+	}
+	if incoming.GradeWeight > 0 {
+		merged.GradeWeight = incoming.GradeWeight
+	}
+
+	if !preserveProgress || incoming.Status == domain.StatusCancelled {
+		merged.Status = incoming.Status
+		merged.CompletedAt = incoming.CompletedAt
+	}
+	if !preserveProgress {
+		merged.Priority = incoming.Priority
+		merged.ActualTimeHours = incoming.ActualTimeHours
+		merged.IsGroupWork = incoming.IsGroupWork
+		merged.GroupMembers = incoming.GroupMembers
+	}
+
+	if sameImportedFields(existing, &merged) {
+		return existing, false
+	}
+
+	merged.UpdatedAt = time.Now()
+	return &merged, true
+}
+
+// sameImportedFields compara los campos que una importación puede modificar
+func sameImportedFields(a, b *domain.Task) bool {
+	return a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.SubjectID == b.SubjectID &&
+		a.PeriodID == b.PeriodID &&
+		a.DueDate.Equal(b.DueDate) &&
+		a.Type == b.Type &&
+		a.Status == b.Status &&
+		a.Priority == b.Priority &&
+		a.EstimatedTimeHours == b.EstimatedTimeHours &&
+		a.GradeWeight == b.GradeWeight &&
+		a.IsGroupWork == b.IsGroupWork &&
+		sameStrings(a.Tags, b.Tags) &&
+		sameStrings(a.GroupMembers, b.GroupMembers)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
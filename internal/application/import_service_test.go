@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+// statusRulesRepo aplica en Update la regla de MongoDB: no se guardan
+// tareas completadas o canceladas por la ruta de edición
+type statusRulesRepo struct {
+	*memory.Repo
+}
+
+func (r statusRulesRepo) Update(ctx context.Context, task *domain.Task) error {
+	if err := task.CanBeModified(); err != nil {
+		return err
+	}
+	return r.Repo.Update(ctx, task)
+}
+
+func importDraft(externalID, status string) domain.ImportDraft {
+	return domain.ImportDraft{Line: 1, Task: domain.Task{
+		Title:      "Parcial 1",
+		SubjectID:  "fis-1",
+		DueDate:    time.Date(2025, 10, 10, 15, 0, 0, 0, time.UTC),
+		Status:     status,
+		Priority:   domain.PriorityMedium,
+		Type:       domain.TypeExam,
+		ExternalID: externalID,
+	}}
+}
+
+func TestImportCancelledWithPreserveProgress(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTaskService(statusRulesRepo{memory.NewRepo()}, nil)
+	opts := ImportOptions{Upsert: true, PreserveProgress: true}
+
+	if _, err := ts.ImportTasks(ctx, "user-1", []domain.ImportDraft{importDraft("uid-1", domain.StatusTodo)}, opts); err != nil {
+		t.Fatal(err)
+	}
+	res, err := ts.ImportTasks(ctx, "user-1", []domain.ImportDraft{importDraft("uid-1", domain.StatusCancelled)}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 || len(res.Updated) != 1 {
+		t.Fatalf("expected the cancellation to be applied, got %+v", res)
+	}
+
+	got, _ := ts.repo.GetByExternalID(ctx, "user-1", "uid-1")
+	if got == nil || got.Status != domain.StatusCancelled {
+		t.Fatalf("expected cancelled task, got %+v", got)
+	}
+}
+
+func TestImportSkipsCompletedTasks(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTaskService(statusRulesRepo{memory.NewRepo()}, nil)
+	opts := ImportOptions{Upsert: true, PreserveProgress: true}
+
+	if _, err := ts.ImportTasks(ctx, "user-1", []domain.ImportDraft{importDraft("uid-1", domain.StatusTodo)}, opts); err != nil {
+		t.Fatal(err)
+	}
+	done, _ := ts.repo.GetByExternalID(ctx, "user-1", "uid-1")
+	done.Status = domain.StatusDone
+	if err := ts.UpdateTaskStatus(ctx, done); err != nil {
+		t.Fatal(err)
+	}
+
+	// El calendario cambió el título: la tarea ya entregada no se toca
+	draft := importDraft("uid-1", domain.StatusTodo)
+	draft.Task.Title = "Parcial 1 (aula 3)"
+	res, err := ts.ImportTasks(ctx, "user-1", []domain.ImportDraft{draft}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 || len(res.Updated) != 0 || res.Unchanged != 1 {
+		t.Fatalf("expected the completed task to be reported unchanged, got %+v", res)
+	}
+
+	got, _ := ts.repo.GetByExternalID(ctx, "user-1", "uid-1")
+	if got.Status != domain.StatusDone || got.Title != "Parcial 1" {
+		t.Errorf("completed task was modified: %+v", got)
+	}
+}
+
+// sharedImportFixture tarea de "owner" compartida con "ana" (lectora)
+func sharedImportFixture(t *testing.T) (*TaskService, *domain.Task, *domain.Task) {
+	t.Helper()
+	ctx := context.Background()
+	ts := NewTaskService(statusRulesRepo{memory.NewRepo()}, nil)
+
+	owned := importDraft("", domain.StatusTodo).Task
+	owned.UserID = "owner"
+	owned.IsGroupWork = true
+	if err := ts.repo.Create(ctx, &owned); err != nil {
+		t.Fatal(err)
+	}
+	viewer := domain.NewSharedCopy(&owned, "ana", domain.RoleViewer, time.Now())
+	if err := ts.repo.Create(ctx, viewer); err != nil {
+		t.Fatal(err)
+	}
+	return ts, &owned, viewer
+}
+
+func TestImportOverViewerCopy(t *testing.T) {
+	ctx := context.Background()
+	ts, _, viewer := sharedImportFixture(t)
+	opts := ImportOptions{Upsert: true}
+
+	// Cambiar los datos compartidos por id exportado: prohibido para un lector
+	draft := importDraft("", domain.StatusTodo)
+	draft.Task.ID = viewer.ID
+	draft.Task.IsGroupWork = true
+	draft.Task.Title = "Parcial 1 (editado)"
+	res, err := ts.ImportTasks(ctx, "ana", []domain.ImportDraft{draft}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 1 || len(res.Updated) != 0 || res.Errors[0].Message != domain.ErrForbidden.Error() {
+		t.Fatalf("expected a forbidden row, got %+v", res)
+	}
+	if got, _ := ts.repo.GetByID(ctx, viewer.ID, "ana"); got.Title != "Parcial 1" {
+		t.Errorf("viewer copy was rewritten: %+v", got)
+	}
+
+	// Su propio estado sí lo puede actualizar
+	draft = importDraft("", domain.StatusInProgress)
+	draft.Task.ID = viewer.ID
+	draft.Task.IsGroupWork = true
+	res, err = ts.ImportTasks(ctx, "ana", []domain.ImportDraft{draft}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 || len(res.Updated) != 1 {
+		t.Fatalf("expected the status update, got %+v", res)
+	}
+	if got, _ := ts.repo.GetByID(ctx, viewer.ID, "ana"); got.Status != domain.StatusInProgress {
+		t.Errorf("viewer status = %s", got.Status)
+	}
+}
+
+func TestImportOverSharedTaskSyncsCopies(t *testing.T) {
+	ctx := context.Background()
+	ts, owned, viewer := sharedImportFixture(t)
+
+	draft := importDraft("", domain.StatusTodo)
+	draft.Task.ID = owned.ID
+	draft.Task.Title = "Parcial 1 (aula 3)"
+	res, err := ts.ImportTasks(ctx, "owner", []domain.ImportDraft{draft}, ImportOptions{Upsert: true, PreserveProgress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 || len(res.Updated) != 1 {
+		t.Fatalf("expected one update, got %+v", res)
+	}
+	if got, _ := ts.repo.GetByID(ctx, viewer.ID, "ana"); got.Title != "Parcial 1 (aula 3)" {
+		t.Errorf("collaborator copy not synced: %+v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// PlanPeriodClone arma la vista previa de clonar un período sin crear nada
+func (ts *TaskService) PlanPeriodClone(ctx context.Context, userID string, opts domain.PeriodCloneOptions) (*domain.PeriodClonePlan, error) {
+	ctx = ensureContext(ctx)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	opts.FromPeriodID = strings.TrimSpace(opts.FromPeriodID)
+	opts.ToPeriodID = strings.TrimSpace(opts.ToPeriodID)
+	if opts.FromPeriodID == "" || opts.ToPeriodID == "" {
+		return nil, fmt.Errorf("%w: se requieren el período origen y el destino", domain.ErrInvalidPeriodClone)
+	}
+	if opts.FromPeriodID == opts.ToPeriodID {
+		return nil, fmt.Errorf("%w: el período destino debe ser distinto del origen", domain.ErrInvalidPeriodClone)
+	}
+	if opts.ToStart.IsZero() {
+		return nil, fmt.Errorf("%w: toStart es requerido", domain.ErrInvalidPeriodClone)
+	}
+
+	filter := ports.TaskFilter{UserID: userID, PeriodID: opts.FromPeriodID}
+	if len(opts.SubjectIDs) == 1 {
+		filter.SubjectID = opts.SubjectIDs[0]
+	}
+	source, err := ts.findAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := ts.findAll(ctx, ports.TaskFilter{UserID: userID, PeriodID: opts.ToPeriodID})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := domain.BuildPeriodClonePlan(source, existing, opts, userID, time.Now())
+	if len(plan.Changes) == 0 {
+		return nil, domain.ErrPeriodEmpty
+	}
+	if plan.CreateCount > domain.MaxPeriodCloneTasks {
+		return nil, domain.ErrPeriodCloneLimit
+	}
+	return &plan, nil
+}
+
+// ClonePeriod crea las tareas del plan en el período destino. Si alguna
+// falla se eliminan las ya creadas para no dejar el período a medias.
+func (ts *TaskService) ClonePeriod(ctx context.Context, userID, userName, userEmail string, opts domain.PeriodCloneOptions) (*domain.PeriodClonePlan, error) {
+	plan, err := ts.PlanPeriodClone(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]string, 0, plan.CreateCount)
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if change.Task == nil {
+			continue
+		}
+		if err := ts.CreateTask(ctx, change.Task, userID, userName, userEmail); err != nil {
+			for _, id := range created {
+				if delErr := ts.DeleteTask(context.WithoutCancel(ctx), id, userID); delErr != nil {
+					log.Printf("⚠️ No se pudo revertir la tarea clonada %s: %v", id, delErr)
+				}
+			}
+			return nil, err
+		}
+		change.TaskID = change.Task.ID
+		created = append(created, change.Task.ID)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// ActivityRepository persiste el hilo de cada tarea: comentarios y cambios de estado
+type ActivityRepository interface {
+	// Create guarda una nueva entrada
+	Create(ctx context.Context, a *domain.Activity) error
+
+	// GetByID obtiene una entrada del hilo de la tarea del dueño
+	GetByID(ctx context.Context, ownerID, taskID, activityID string) (*domain.Activity, error)
+
+	// Update reemplaza el texto de un comentario
+	Update(ctx context.Context, a *domain.Activity) error
+
+	// Delete elimina una entrada
+	Delete(ctx context.Context, ownerID, taskID, activityID string) error
+
+	// List página del hilo (más recientes primero) y el total de entradas
+	List(ctx context.Context, q domain.ActivityQuery) ([]domain.Activity, int64, error)
+
+	// DeleteByTask elimina el hilo completo de una tarea
+	DeleteByTask(ctx context.Context, ownerID, taskID string) error
+}
@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"uniflow-api/internal/domain"
+)
+
+// AttachmentRepository persiste los metadatos de los adjuntos
+type AttachmentRepository interface {
+	// Create guarda un nuevo adjunto
+	Create(ctx context.Context, a *domain.Attachment) error
+
+	// GetByID obtiene un adjunto por su ID
+	GetByID(ctx context.Context, attachmentID string) (*domain.Attachment, error)
+
+	// FindByChecksum adjunto de la tarea con el mismo contenido (ErrAttachmentNotFound si no hay)
+	FindByChecksum(ctx context.Context, ownerID, taskID, checksum string) (*domain.Attachment, error)
+
+	// ListByTask adjuntos de una tarea del dueño, por fecha de subida
+	ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Attachment, error)
+
+	// AcquireBlob suma una referencia al contenido checksum y devuelve la
+	// clave con la que está guardado. Si no tenía referencias registra newKey
+	// y created indica que hay que escribirlo.
+	AcquireBlob(ctx context.Context, checksum, newKey string) (key string, created bool, err error)
+
+	// ReleaseBlob quita una referencia al contenido guardado bajo key; unused
+	// indica que era la última y el contenido ya se puede eliminar
+	ReleaseBlob(ctx context.Context, checksum, key string) (unused bool, err error)
+
+	// Delete elimina un adjunto
+	Delete(ctx context.Context, attachmentID string) error
+}
+
+// BlobStore guarda el contenido de los archivos por clave (sistema de
+// archivos local, Azure Blob Storage o Azurite en desarrollo)
+type BlobStore interface {
+	// Put guarda el contenido bajo key (reemplaza si ya existe)
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get abre el contenido (ErrAttachmentNotFound si no existe)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists indica si hay contenido bajo key
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete elimina el contenido; no es error si no existe
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// CalendarTokenRepository persiste los tokens secretos de los feeds ICS
+type CalendarTokenRepository interface {
+	// Create guarda un nuevo token (solo con su hash)
+	Create(ctx context.Context, token *domain.CalendarToken) error
+
+	// GetByHash busca un token por el hash de su valor en claro
+	GetByHash(ctx context.Context, tokenHash string) (*domain.CalendarToken, error)
+
+	// ListByUser lista los tokens de un usuario (incluye revocados)
+	ListByUser(ctx context.Context, userID string) ([]domain.CalendarToken, error)
+
+	// Revoke marca un token como revocado (solo si pertenece al usuario)
+	Revoke(ctx context.Context, tokenID, userID string, at time.Time) error
+
+	// TouchLastUsed registra el último acceso al feed
+	TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error
+}
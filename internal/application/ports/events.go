@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// EventPublisher recibe los eventos de tareas (webhooks, SSE, etc.).
+// Publish no debe bloquear el caso de uso: los errores se manejan internamente.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.TaskEvent)
+}
+
+// EventBroadcaster distribuye los eventos entre todas las réplicas de la API.
+// Cada réplica publica con Broadcast y recibe los eventos de todas (incluidos
+// los propios) en Run. Implementaciones: en proceso (un solo nodo), change
+// streams de MongoDB o una cola compartida.
+type EventBroadcaster interface {
+	// Broadcast envía el evento a todas las réplicas
+	Broadcast(ctx context.Context, event domain.TaskEvent) error
+
+	// Run entrega los eventos recibidos a deliver hasta que ctx se cancele
+	Run(ctx context.Context, deliver func(domain.TaskEvent)) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// InvitationRepository persiste las invitaciones por email a tareas compartidas
+type InvitationRepository interface {
+	// Create guarda una nueva invitación
+	Create(ctx context.Context, inv *domain.Invitation) error
+
+	// GetByID obtiene una invitación
+	GetByID(ctx context.Context, invitationID string) (*domain.Invitation, error)
+
+	// ListByTask invitaciones pendientes de una tarea del dueño
+	ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Invitation, error)
+
+	// ListByEmail invitaciones pendientes para un email (normalizado)
+	ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error)
+
+	// Delete elimina una invitación (aceptada, rechazada o revocada)
+	Delete(ctx context.Context, invitationID string) error
+}
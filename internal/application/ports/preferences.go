@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// PreferencesRepository persiste las preferencias de cada usuario
+type PreferencesRepository interface {
+	// Get obtiene las preferencias (domain.ErrPreferencesNotFound si no hay)
+	Get(ctx context.Context, userID string) (*domain.UserPreferences, error)
+
+	// Save crea o reemplaza las preferencias del usuario
+	Save(ctx context.Context, p *domain.UserPreferences) error
+}
@@ -18,6 +18,9 @@ type TaskRepository interface {
 	// GetByID obtiene una tarea específica por ID y verifica pertenencia al usuario
 	GetByID(ctx context.Context, taskID, userID string) (*domain.Task, error)
 
+	// GetByExternalID obtiene la tarea importada con ese ID de origen (ICS UID, CSV)
+	GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error)
+
 	// GetAll obtiene todas las tareas de un usuario
 	// Implementa contexto y timeout para evitar bloqueos
 	GetAll(ctx context.Context, userID string) ([]domain.Task, error)
@@ -28,9 +31,26 @@ type TaskRepository interface {
 	// Update actualiza una tarea existente (solo si pertenece al usuario)
 	Update(ctx context.Context, task *domain.Task) error
 
+	// UpdateStatus persiste un cambio de estado (completar, cancelar,
+	// reabrir); a diferencia de Update no rechaza tareas completadas o canceladas
+	UpdateStatus(ctx context.Context, task *domain.Task) error
+
 	// Delete elimina una tarea (solo si pertenece al usuario)
 	Delete(ctx context.Context, taskID, userID string) error
 
+	// UpdateShared reemplaza una tarea compartida (la del dueño o la copia de
+	// un colaborador) sin aplicar las reglas de estado del usuario: las
+	// copias se sincronizan aunque estén completadas o canceladas
+	UpdateShared(ctx context.Context, task *domain.Task) error
+
+	// DeleteShared elimina una tarea compartida aunque esté completada
+	// (al quitar un colaborador o eliminar la tarea del dueño)
+	DeleteShared(ctx context.Context, taskID, userID string) error
+
+	// ListShared copias de los colaboradores de una tarea compartida (solo
+	// las de ese dueño)
+	ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error)
+
 	// Listado con filtros y paginación
 	Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error)
 
@@ -44,8 +64,9 @@ type TaskRepository interface {
 	// podés devolver valores en cero y null.
 	Aggregated(ctx context.Context, userID string, until time.Time) (domain.Stats, error)
 
-	// GetDashboardStats retorna estadísticas para el dashboard
-	GetDashboardStats(ctx context.Context, userID string) (domain.DashboardData, error)
+	// GetDashboardStats retorna estadísticas para el dashboard; "hoy", "esta
+	// semana" y los límites de cada lista vienen de opts (ver DashboardOptions.Windows)
+	GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error)
 
 	FindByFilter(ctx context.Context, filter TaskFilter) ([]domain.Task, domain.PageInfo, error)
 }
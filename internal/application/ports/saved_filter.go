@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// SavedFilterRepository persiste las listas guardadas de cada usuario
+type SavedFilterRepository interface {
+	// Create guarda un nuevo filtro
+	Create(ctx context.Context, f *domain.SavedFilter) error
+
+	// GetByID obtiene un filtro del usuario
+	GetByID(ctx context.Context, filterID, userID string) (*domain.SavedFilter, error)
+
+	// ListByUser lista los filtros del usuario ordenados por fecha de creación
+	ListByUser(ctx context.Context, userID string) ([]domain.SavedFilter, error)
+
+	// Update reemplaza nombre y parámetros
+	Update(ctx context.Context, f *domain.SavedFilter) error
+
+	// Delete elimina un filtro del usuario
+	Delete(ctx context.Context, filterID, userID string) error
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"uniflow-api/internal/domain"
+)
+
+// TemplateRepository persiste las plantillas de tareas de cada usuario
+// (las predefinidas no se guardan)
+type TemplateRepository interface {
+	// Create guarda una nueva plantilla
+	Create(ctx context.Context, t *domain.TaskTemplate) error
+
+	// GetByID obtiene una plantilla del usuario
+	GetByID(ctx context.Context, templateID, userID string) (*domain.TaskTemplate, error)
+
+	// ListByUser lista las plantillas del usuario ordenadas por fecha de creación
+	ListByUser(ctx context.Context, userID string) ([]domain.TaskTemplate, error)
+
+	// Update reemplaza el contenido de la plantilla
+	Update(ctx context.Context, t *domain.TaskTemplate) error
+
+	// Delete elimina una plantilla del usuario
+	Delete(ctx context.Context, templateID, userID string) error
+}
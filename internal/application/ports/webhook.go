@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// WebhookRepository persiste webhooks y su historial de entregas.
+// userID vacío identifica a los webhooks de sistema.
+type WebhookRepository interface {
+	// Create guarda un nuevo webhook
+	Create(ctx context.Context, hook *domain.Webhook) error
+
+	// GetByID obtiene un webhook del dueño indicado
+	GetByID(ctx context.Context, hookID, userID string) (*domain.Webhook, error)
+
+	// ListByUser lista los webhooks del dueño indicado
+	ListByUser(ctx context.Context, userID string) ([]domain.Webhook, error)
+
+	// ListActive lista los webhooks activos del usuario más los de sistema
+	ListActive(ctx context.Context, userID string) ([]domain.Webhook, error)
+
+	// Update reemplaza URL, eventos, descripción y estado del webhook
+	Update(ctx context.Context, hook *domain.Webhook) error
+
+	// Delete elimina un webhook y su historial
+	Delete(ctx context.Context, hookID, userID string) error
+
+	// RecordFailure incrementa los fallos consecutivos y retorna el nuevo total
+	RecordFailure(ctx context.Context, hookID string) (int, error)
+
+	// ResetFailures pone en cero los fallos consecutivos
+	ResetFailures(ctx context.Context, hookID string) error
+
+	// Disable desactiva el webhook indicando el motivo
+	Disable(ctx context.Context, hookID, reason string, at time.Time) error
+
+	// AddDelivery registra un intento de entrega
+	AddDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+
+	// ListDeliveries lista las entregas más recientes de un webhook
+	ListDeliveries(ctx context.Context, hookID string, limit int) ([]domain.WebhookDelivery, error)
+}
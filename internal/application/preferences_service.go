@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// PreferencesService administra las preferencias del usuario y resuelve su
+// zona horaria para los cálculos por día (hoy, esta semana, recordatorios)
+type PreferencesService struct {
+	repo       ports.PreferencesRepository
+	defaultLoc *time.Location
+}
+
+// NewPreferencesService crea una nueva instancia de PreferencesService.
+// defaultLoc es la zona de los usuarios nuevos (nil = UTC).
+func NewPreferencesService(repo ports.PreferencesRepository, defaultLoc *time.Location) *PreferencesService {
+	if defaultLoc == nil {
+		defaultLoc = time.UTC
+	}
+	return &PreferencesService{
+		repo:       repo,
+		defaultLoc: defaultLoc,
+	}
+}
+
+// GetPreferences preferencias del usuario; la primera vez se crean con los
+// valores por defecto
+func (ps *PreferencesService) GetPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	ctx = ensureContext(ctx)
+
+	p, err := ps.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrPreferencesNotFound) {
+		p = domain.DefaultPreferences(userID, ps.defaultLoc.String())
+		p.CreatedAt = time.Now()
+		p.UpdatedAt = p.CreatedAt
+		if err := ps.repo.Save(ctx, p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.ApplyDefaults()
+	return p, nil
+}
+
+// UpdatePreferences aplica apply sobre las preferencias actuales, valida y guarda
+func (ps *PreferencesService) UpdatePreferences(ctx context.Context, userID string, apply func(*domain.UserPreferences)) (*domain.UserPreferences, error) {
+	ctx = ensureContext(ctx)
+
+	p, err := ps.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	apply(p)
+
+	loc, err := domain.LoadTimeZone(p.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	p.TimeZone = loc.String()
+	p.WeekStart = strings.ToLower(p.WeekStart)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	p.UserID = userID
+	p.UpdatedAt = time.Now()
+	if err := ps.repo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Resolve preferencias del usuario y zona horaria a usar en una consulta:
+// la explícita (?tz=) si viene, si no la guardada
+func (ps *PreferencesService) Resolve(ctx context.Context, userID, explicit string) (*domain.UserPreferences, *time.Location, error) {
+	p, err := ps.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if explicit != "" {
+		loc, err := domain.LoadTimeZone(explicit)
+		return p, loc, err
+	}
+	loc, err := domain.LoadTimeZone(p.TimeZone)
+	if err != nil {
+		// Zona guardada que ya no existe en la base IANA del servidor
+		return p, ps.defaultLoc, nil
+	}
+	return p, loc, nil
+}
+
+// Location zona horaria a usar en una consulta (ver Resolve)
+func (ps *PreferencesService) Location(ctx context.Context, userID, explicit string) (*time.Location, error) {
+	_, loc, err := ps.Resolve(ctx, userID, explicit)
+	return loc, err
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// SavedFilterService administra las listas guardadas (filtros con nombre).
+// Los parámetros se validan en la capa HTTP, que es la que los interpreta.
+type SavedFilterService struct {
+	repo ports.SavedFilterRepository
+}
+
+// NewSavedFilterService crea una nueva instancia de SavedFilterService
+func NewSavedFilterService(repo ports.SavedFilterRepository) *SavedFilterService {
+	return &SavedFilterService{
+		repo: repo,
+	}
+}
+
+// CreateSavedFilter guarda un nuevo filtro; el nombre es único por usuario
+func (ss *SavedFilterService) CreateSavedFilter(ctx context.Context, userID, name string, params domain.SavedFilterParams) (*domain.SavedFilter, error) {
+	ctx = ensureContext(ctx)
+
+	existing, err := ss.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= domain.MaxSavedFiltersPerUser {
+		return nil, domain.ErrSavedFilterLimit
+	}
+	name = strings.TrimSpace(name)
+	if nameTaken(existing, name, "") {
+		return nil, domain.ErrSavedFilterNameTaken
+	}
+
+	now := time.Now()
+	f := &domain.SavedFilter{
+		UserID:    userID,
+		Name:      name,
+		Params:    params,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := ss.repo.Create(ctx, f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ListSavedFilters lista los filtros del usuario
+func (ss *SavedFilterService) ListSavedFilters(ctx context.Context, userID string) ([]domain.SavedFilter, error) {
+	return ss.repo.ListByUser(ensureContext(ctx), userID)
+}
+
+// GetSavedFilter obtiene un filtro del usuario
+func (ss *SavedFilterService) GetSavedFilter(ctx context.Context, filterID, userID string) (*domain.SavedFilter, error) {
+	return ss.repo.GetByID(ensureContext(ctx), filterID, userID)
+}
+
+// UpdateSavedFilter reemplaza nombre y parámetros
+func (ss *SavedFilterService) UpdateSavedFilter(ctx context.Context, filterID, userID, name string, params domain.SavedFilterParams) (*domain.SavedFilter, error) {
+	ctx = ensureContext(ctx)
+
+	f, err := ss.repo.GetByID(ctx, filterID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name != f.Name {
+		existing, err := ss.repo.ListByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if nameTaken(existing, name, filterID) {
+			return nil, domain.ErrSavedFilterNameTaken
+		}
+	}
+
+	f.Name = name
+	f.Params = params
+	f.UpdatedAt = time.Now()
+	if err := ss.repo.Update(ctx, f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// DeleteSavedFilter elimina un filtro del usuario
+func (ss *SavedFilterService) DeleteSavedFilter(ctx context.Context, filterID, userID string) error {
+	return ss.repo.Delete(ensureContext(ctx), filterID, userID)
+}
+
+// nameTaken compara nombres sin distinguir mayúsculas, ignorando el filtro exceptID
+func nameTaken(filters []domain.SavedFilter, name, exceptID string) bool {
+	for _, f := range filters {
+		if f.ID != exceptID && strings.EqualFold(f.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"uniflow-api/internal/application/ports"
@@ -27,6 +28,14 @@ func ensureContext(ctx context.Context) context.Context {
 type TaskService struct {
 	repo        ports.TaskRepository
 	queueClient *azqueue.QueueClient
+	publishers  []ports.EventPublisher
+	preferences *PreferencesService        // opcional (WithPreferences)
+	invitations ports.InvitationRepository // opcional (WithInvitations)
+	activity    ports.ActivityRepository   // opcional (WithActivity)
+
+	attachments   ports.AttachmentRepository // opcional (WithAttachments)
+	blobs         ports.BlobStore
+	attachmentCfg AttachmentConfig
 }
 
 // NewTaskService crea una nueva instancia de TaskService
@@ -38,6 +47,26 @@ func NewTaskService(repo ports.TaskRepository, queueClient *azqueue.QueueClient)
 	}
 }
 
+// WithPreferences usa la zona horaria de cada usuario para los recordatorios
+func (ts *TaskService) WithPreferences(ps *PreferencesService) *TaskService {
+	ts.preferences = ps
+	return ts
+}
+
+// userPreferences preferencias y zona horaria del usuario (valores por
+// defecto en UTC sin preferencias configuradas o si no se pueden leer)
+func (ts *TaskService) userPreferences(ctx context.Context, userID string) (*domain.UserPreferences, *time.Location) {
+	if ts.preferences == nil {
+		return domain.DefaultPreferences(userID, "UTC"), time.UTC
+	}
+	p, loc, err := ts.preferences.Resolve(ctx, userID, "")
+	if err != nil {
+		log.Printf("⚠️ No se pudieron obtener las preferencias de %s: %v (usando valores por defecto)", userID, err)
+		return domain.DefaultPreferences(userID, "UTC"), time.UTC
+	}
+	return p, loc
+}
+
 // GetAllTasks obtiene todas las tareas del usuario desde la BD real
 func (ts *TaskService) GetAllTasks(ctx context.Context, userID string) ([]domain.Task, error) {
 	ctx = ensureContext(ctx)
@@ -111,6 +140,8 @@ func (ts *TaskService) CreateTask(ctx context.Context, task *domain.Task, userID
 		return err
 	}
 
+	ts.publish(ctx, domain.EventTaskCreated, task.UserID, task.ID, task)
+
 	// Encolar mensaje de recordatorio si está disponible Azure Queue
 	if ts.queueClient != nil {
 		if err := ts.enqueueDeadlineReminder(ctx, task, userID, userName, userEmail); err != nil {
@@ -122,24 +153,29 @@ func (ts *TaskService) CreateTask(ctx context.Context, task *domain.Task, userID
 	return nil
 }
 
-// enqueueDeadlineReminder encola un mensaje para recordatorio de deadline
+// enqueueDeadlineReminder encola un mensaje por cada recordatorio del
+// usuario (reminderOffsetsHours; por defecto 3 días antes del vencimiento)
 func (ts *TaskService) enqueueDeadlineReminder(ctx context.Context, task *domain.Task, userID, userName, userEmail string) error {
-	// Calcular visibility timeout: 3 días antes del vencimiento
+	prefs, loc := ts.userPreferences(ctx, task.UserID)
 	now := time.Now()
-	timeUntilDue := task.DueDate.Sub(now)
-	threeDays := 3 * 24 * time.Hour
+	for _, r := range prefs.ReminderSchedule(now, task.DueDate, loc) {
+		if err := ts.enqueueReminder(ctx, task, r, now, userID, userName, userEmail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// enqueueReminder encola un recordatorio, invisible hasta r.At
+func (ts *TaskService) enqueueReminder(ctx context.Context, task *domain.Task, r domain.Reminder, now time.Time, userID, userName, userEmail string) error {
 	var visibilityTimeoutSeconds int32
-	if timeUntilDue > threeDays {
-		visibilityTimeoutSeconds = int32((timeUntilDue - threeDays).Seconds())
-	} else {
-		// Si vence en menos de 3 días, hacer visible inmediatamente
-		visibilityTimeoutSeconds = 0
+	if untilReminder := r.At.Sub(now); untilReminder > 0 {
+		visibilityTimeoutSeconds = int32(untilReminder.Seconds())
 	}
 
 	// Construir mensaje JSON (solo los campos que NestJS espera)
-	messageText := fmt.Sprintf("La tarea '%s' está próxima a vencerse. Faltan 3 días", task.Title)
-	
+	messageText := fmt.Sprintf("La tarea '%s' está próxima a vencerse. %s", task.Title, reminderTimeLeft(r.OffsetHours))
+
 	message := map[string]interface{}{
 		"taskId":   task.ID,
 		"userId":   userID,
@@ -171,7 +207,24 @@ func (ts *TaskService) enqueueDeadlineReminder(ctx context.Context, task *domain
 	return nil
 }
 
-// UpdateTask actualiza una tarea existente
+// reminderTimeLeft texto del tiempo restante según el offset del recordatorio
+func reminderTimeLeft(hours int) string {
+	switch {
+	case hours == 0:
+		return "Vence ahora"
+	case hours == 24:
+		return "Falta 1 día"
+	case hours%24 == 0:
+		return fmt.Sprintf("Faltan %d días", hours/24)
+	case hours == 1:
+		return "Falta 1 hora"
+	default:
+		return fmt.Sprintf("Faltan %d horas", hours)
+	}
+}
+
+// UpdateTask actualiza una tarea existente. En tareas compartidas los
+// cambios se propagan a todos los miembros (solo dueño o editor).
 func (ts *TaskService) UpdateTask(ctx context.Context, task *domain.Task) error {
 	ctx = ensureContext(ctx)
 	select {
@@ -180,6 +233,10 @@ func (ts *TaskService) UpdateTask(ctx context.Context, task *domain.Task) error
 	default:
 	}
 
+	if !task.CanEdit() {
+		return domain.ErrForbidden
+	}
+
 	// Validar que puede ser modificada
 	if err := task.CanBeModified(); err != nil {
 		return err
@@ -190,12 +247,20 @@ func (ts *TaskService) UpdateTask(ctx context.Context, task *domain.Task) error
 		return err
 	}
 
+	// Copia de un editor: se edita la tarea del dueño
+	if task.IsSharedCopy() {
+		return ts.updateFromSharedCopy(ctx, task)
+	}
+
 	// Persistir cambios
 	err := ts.repo.Update(ctx, task)
 	if err != nil {
 		return err
 	}
 
+	ts.publish(ctx, domain.EventTaskUpdated, task.UserID, task.ID, task)
+	ts.syncShared(ctx, task)
+
 	return nil
 }
 
@@ -213,16 +278,23 @@ func (ts *TaskService) UpdateTaskStatus(ctx context.Context, task *domain.Task)
 		return err
 	}
 
+	from := ts.previousStatus(ctx, task)
+
 	// Persistir cambios
-	err := ts.repo.Update(ctx, task)
+	err := ts.repo.UpdateStatus(ctx, task)
 	if err != nil {
 		return err
 	}
 
+	ts.publish(ctx, statusEventType(task), task.UserID, task.ID, task)
+	ts.recordStatusChange(ctx, task, from)
+
 	return nil
 }
 
-// DeleteTask elimina una tarea
+// DeleteTask elimina una tarea. Si es compartida también se eliminan las
+// copias de los colaboradores; si es una copia, el colaborador deja la tarea.
+// El hilo de comentarios y los adjuntos se eliminan junto con la tarea del dueño.
 func (ts *TaskService) DeleteTask(ctx context.Context, taskID, userID string) error {
 	ctx = ensureContext(ctx)
 	select {
@@ -236,6 +308,11 @@ func (ts *TaskService) DeleteTask(ctx context.Context, taskID, userID string) er
 		return err
 	}
 
+	ts.publish(ctx, domain.EventTaskDeleted, userID, taskID, nil)
+	ts.deleteShared(ctx, userID, taskID)
+	ts.deleteActivity(ctx, userID, taskID)
+	ts.deleteAttachments(ctx, userID, taskID)
+
 	return nil
 }
 
@@ -256,8 +333,9 @@ func (ts *TaskService) GetTasksFiltered(ctx context.Context, filter ports.TaskFi
 	return tasks, pageInfo, nil
 }
 
-// GetDashboard retorna datos agregados para el dashboard
-func (ts *TaskService) GetDashboard(ctx context.Context, userID string) (*domain.DashboardData, error) {
+// GetDashboard retorna datos agregados para el dashboard; "hoy", "esta
+// semana" y los límites vienen de opts (preferencias del usuario)
+func (ts *TaskService) GetDashboard(ctx context.Context, userID string, opts domain.DashboardOptions) (*domain.DashboardData, error) {
 	ctx = ensureContext(ctx)
 	select {
 	case <-ctx.Done():
@@ -265,10 +343,73 @@ func (ts *TaskService) GetDashboard(ctx context.Context, userID string) (*domain
 	default:
 	}
 
-	data, err := ts.repo.GetDashboardStats(ctx, userID)
+	data, err := ts.repo.GetDashboardStats(ctx, userID, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	return &data, nil
 }
+
+// GetNextTasks retorna las tareas pendientes más urgentes ("¿qué hago ahora?")
+// ordenadas por score de urgencia, junto con la explicación de cada score
+func (ts *TaskService) GetNextTasks(ctx context.Context, userID string, limit int) ([]domain.ScoredTask, error) {
+	ctx = ensureContext(ctx)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	filter := ports.TaskFilter{
+		UserID:    userID,
+		Status:    []string{domain.StatusTodo, domain.StatusInProgress, domain.StatusInReview},
+		SortBy:    "score",
+		SortOrder: "desc",
+		Page:      1,
+		Limit:     limit,
+	}
+
+	tasks, _, err := ts.repo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ranked := make([]domain.ScoredTask, len(tasks))
+	for i := range tasks {
+		ranked[i] = domain.ScoredTask{
+			Task:  tasks[i],
+			Score: domain.ComputeScore(&tasks[i], now),
+		}
+	}
+
+	return ranked, nil
+}
+
+// GetSubjectIDs retorna las materias (subjectId) distintas en las tareas del usuario
+func (ts *TaskService) GetSubjectIDs(ctx context.Context, userID string) ([]string, error) {
+	ctx = ensureContext(ctx)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	tasks, err := ts.repo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	subjects := []string{}
+	for _, t := range tasks {
+		if t.SubjectID != "" && !seen[t.SubjectID] {
+			seen[t.SubjectID] = true
+			subjects = append(subjects, t.SubjectID)
+		}
+	}
+	sort.Strings(subjects)
+
+	return subjects, nil
+}
@@ -31,6 +31,16 @@ func (m *mockRepository) GetByID(ctx context.Context, taskID, userID string) (*d
 	return nil, nil // Implementar si necesario
 }
 
+func (m *mockRepository) GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error) {
+	for _, t := range m.tasks[userID] {
+		if t.ExternalID == externalID {
+			cp := t
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
 func (m *mockRepository) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
 	if tasks, ok := m.tasks[userID]; ok {
 		return tasks, nil
@@ -50,6 +60,18 @@ func (m *mockRepository) Delete(ctx context.Context, taskID, userID string) erro
 	return nil
 }
 
+func (m *mockRepository) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	return nil
+}
+
+func (m *mockRepository) UpdateShared(ctx context.Context, task *domain.Task) error {
+	return nil
+}
+
+func (m *mockRepository) DeleteShared(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
 // Métodos para Fase 3 (stubs)
 func (m *mockRepository) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
 	return []domain.Task{}, domain.PageInfo{}, nil
@@ -59,6 +81,10 @@ func (m *mockRepository) DueToday(ctx context.Context, userID string, loc *time.
 	return []domain.Task{}, nil
 }
 
+func (m *mockRepository) ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error) {
+	return []domain.Task{}, nil
+}
+
 func (m *mockRepository) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
 	return []domain.Task{}, domain.PageInfo{}, nil
 }
@@ -67,7 +93,7 @@ func (m *mockRepository) Aggregated(ctx context.Context, userID string, until ti
 	return domain.Stats{}, nil
 }
 
-func (m *mockRepository) GetDashboardStats(ctx context.Context, userID string) (domain.DashboardData, error) {
+func (m *mockRepository) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
 	return domain.DashboardData{
 		UpcomingTasks:     make([]domain.DashboardTask, 0),
 		TodayTasks:        make([]domain.DashboardTask, 0),
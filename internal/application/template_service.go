@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// TemplateService administra las plantillas de tareas del usuario y crea
+// tareas a partir de ellas (predefinidas o propias)
+type TemplateService struct {
+	repo  ports.TemplateRepository
+	tasks *TaskService
+}
+
+// NewTemplateService crea una nueva instancia de TemplateService
+func NewTemplateService(repo ports.TemplateRepository, tasks *TaskService) *TemplateService {
+	return &TemplateService{
+		repo:  repo,
+		tasks: tasks,
+	}
+}
+
+// ListTemplates plantillas predefinidas seguidas de las del usuario
+func (ts *TemplateService) ListTemplates(ctx context.Context, userID string) ([]domain.TaskTemplate, error) {
+	own, err := ts.repo.ListByUser(ensureContext(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(domain.BuiltInTemplates(), own...), nil
+}
+
+// GetTemplate obtiene una plantilla predefinida o del usuario
+func (ts *TemplateService) GetTemplate(ctx context.Context, templateID, userID string) (*domain.TaskTemplate, error) {
+	if domain.IsBuiltInTemplate(templateID) {
+		if t, ok := domain.BuiltInTemplate(templateID); ok {
+			return t, nil
+		}
+		return nil, domain.ErrTemplateNotFound
+	}
+	return ts.repo.GetByID(ensureContext(ctx), templateID, userID)
+}
+
+// CreateTemplate guarda una plantilla nueva; el nombre es único por usuario
+func (ts *TemplateService) CreateTemplate(ctx context.Context, userID string, t *domain.TaskTemplate) (*domain.TaskTemplate, error) {
+	ctx = ensureContext(ctx)
+
+	t.Normalize()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := ts.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= domain.MaxTemplatesPerUser {
+		return nil, domain.ErrTemplateLimit
+	}
+	if templateNameTaken(existing, t.Name, "") {
+		return nil, domain.ErrTemplateNameTaken
+	}
+
+	now := time.Now()
+	t.ID = ""
+	t.UserID = userID
+	t.BuiltIn = false
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	if err := ts.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// UpdateTemplate reemplaza el contenido de una plantilla del usuario
+func (ts *TemplateService) UpdateTemplate(ctx context.Context, templateID, userID string, t *domain.TaskTemplate) (*domain.TaskTemplate, error) {
+	ctx = ensureContext(ctx)
+	if domain.IsBuiltInTemplate(templateID) {
+		return nil, domain.ErrTemplateReadOnly
+	}
+
+	current, err := ts.repo.GetByID(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Normalize()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(t.Name, current.Name) {
+		existing, err := ts.repo.ListByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if templateNameTaken(existing, t.Name, templateID) {
+			return nil, domain.ErrTemplateNameTaken
+		}
+	}
+
+	t.ID = templateID
+	t.UserID = userID
+	t.BuiltIn = false
+	t.CreatedAt = current.CreatedAt
+	t.UpdatedAt = time.Now()
+	if err := ts.repo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DeleteTemplate elimina una plantilla del usuario
+func (ts *TemplateService) DeleteTemplate(ctx context.Context, templateID, userID string) error {
+	if domain.IsBuiltInTemplate(templateID) {
+		return domain.ErrTemplateReadOnly
+	}
+	return ts.repo.Delete(ensureContext(ctx), templateID, userID)
+}
+
+// InstantiateTemplate crea las tareas de la plantilla. Si alguna falla se
+// eliminan las ya creadas para no dejar la estructura a medias.
+func (ts *TemplateService) InstantiateTemplate(ctx context.Context, templateID string, in domain.TemplateInstance, userName, userEmail string) ([]*domain.Task, error) {
+	ctx = ensureContext(ctx)
+
+	t, err := ts.GetTemplate(ctx, templateID, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if in.Now.IsZero() {
+		in.Now = time.Now()
+	}
+
+	tasks := t.Instantiate(in)
+	for i, task := range tasks {
+		if err := ts.tasks.CreateTask(ctx, task, in.UserID, userName, userEmail); err != nil {
+			for _, created := range tasks[:i] {
+				if delErr := ts.tasks.DeleteTask(context.WithoutCancel(ctx), created.ID, in.UserID); delErr != nil {
+					log.Printf("⚠️ No se pudo revertir la tarea %s de la plantilla %s: %v", created.ID, templateID, delErr)
+				}
+			}
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+// SaveTaskAsTemplate guarda una tarea existente como plantilla de una sola
+// tarea (tipo, prioridad, estimación, etiquetas y descripción)
+func (ts *TemplateService) SaveTaskAsTemplate(ctx context.Context, taskID, userID, name string) (*domain.TaskTemplate, error) {
+	ctx = ensureContext(ctx)
+
+	task, err := ts.tasks.GetTaskByID(ctx, taskID, userID)
+	if err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	return ts.CreateTemplate(ctx, userID, domain.TemplateFromTask(task, userID, name))
+}
+
+// templateNameTaken compara nombres sin distinguir mayúsculas, ignorando la plantilla exceptID
+func templateNameTaken(templates []domain.TaskTemplate, name, exceptID string) bool {
+	for _, t := range templates {
+		if t.ID != exceptID && strings.EqualFold(t.Name, name) {
+			return true
+		}
+	}
+	return false
+}
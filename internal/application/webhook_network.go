@@ -0,0 +1,101 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errWebhookPrivateAddress destino de un webhook de usuario fuera de Internet
+var errWebhookPrivateAddress = errors.New("dirección de destino no permitida (red privada, loopback o link-local)")
+
+// nonPublicNets rangos que no tienen IsPrivate/IsLoopback/... propio en net.IP
+var nonPublicNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",     // "esta red"
+		"100.64.0.0/10", // NAT de operador (CGNAT)
+		"192.0.0.0/24",  // asignaciones de protocolo IETF
+		"198.18.0.0/15", // pruebas de rendimiento
+		"240.0.0.0/4",   // reservado
+		"64:ff9b::/96",  // NAT64 (puede apuntar a IPv4 privadas)
+	} {
+		_, n, _ := net.ParseCIDR(cidr)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// isPublicIP indica si ip es una dirección de Internet (no loopback, privada,
+// link-local —incluida 169.254.169.254, la de metadatos de la nube—,
+// multicast ni reservada)
+func isPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWebhookTarget rechaza al registrar las URLs cuyo host es "localhost"
+// o una IP no pública. Los nombres de dominio se revisan al conectar
+// (publicOnlyControl), porque pueden resolver a otra IP en cada consulta.
+func checkWebhookTarget(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil // IsValid ya reporta la URL inválida
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errWebhookPrivateAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return errWebhookPrivateAddress
+	}
+	return nil
+}
+
+// publicOnlyControl net.Dialer.Control que solo permite conectar a IPs
+// públicas. Recibe la dirección ya resuelta, así un DNS que cambia entre la
+// validación y la conexión (DNS rebinding) no la evita.
+func publicOnlyControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", errWebhookPrivateAddress, host)
+	}
+	return nil
+}
+
+// newWebhookClient cliente HTTP de las entregas. No sigue redirecciones (la
+// respuesta 3xx cuenta como fallo) y, salvo allowPrivate, solo conecta a IPs
+// públicas y sin proxy, para que el destino sea siempre la IP verificada.
+func newWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer.Control = publicOnlyControl
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,515 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// Headers enviados en cada entrega
+const (
+	WebhookSignatureHeader = "X-UniFlow-Signature"
+	WebhookEventHeader     = "X-UniFlow-Event"
+	WebhookDeliveryHeader  = "X-UniFlow-Delivery"
+	WebhookAttemptHeader   = "X-UniFlow-Attempt"
+)
+
+// WebhookTestEvent tipo del evento enviado por "send test event"
+const WebhookTestEvent = "webhook.test"
+
+// maxWebhookResponseBytes bytes de la respuesta que se guardan en el log (solo webhooks de sistema)
+const maxWebhookResponseBytes = 1024
+
+// WebhookConfig parámetros de entrega de webhooks
+type WebhookConfig struct {
+	MaxAttempts  int           // intentos por evento (default 5)
+	BaseBackoff  time.Duration // espera antes del 2º intento, se duplica en cada uno (default 30s)
+	MaxBackoff   time.Duration // espera máxima entre intentos (default 30m)
+	DisableAfter int           // eventos fallidos consecutivos para desactivar (default 10)
+	Timeout      time.Duration // timeout de cada request (default 10s)
+	Workers      int           // entregas concurrentes (default 4)
+	QueueSize    int           // entregas pendientes en memoria (default 1000)
+
+	// AllowPrivateNetworks permite que los webhooks de usuario apunten a
+	// localhost o redes privadas (solo desarrollo y tests). Los de sistema
+	// siempre pueden, porque los registra un administrador.
+	AllowPrivateNetworks bool
+}
+
+func (c *WebhookConfig) withDefaults() WebhookConfig {
+	cfg := *c
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Minute
+	}
+	if cfg.DisableAfter <= 0 {
+		cfg.DisableAfter = 10
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
+	return cfg
+}
+
+// WebhookPatch cambios parciales sobre un webhook (nil = sin cambio)
+type WebhookPatch struct {
+	URL         *string
+	Events      *[]string
+	Description *string
+	Active      *bool
+}
+
+// webhookJob entrega pendiente de un evento a un webhook
+type webhookJob struct {
+	event     *domain.TaskEvent // evento sin resolver: el worker busca los webhooks suscritos
+	hook      domain.Webhook
+	eventID   string
+	eventType string
+	payload   []byte
+	attempt   int
+}
+
+// WebhookService gestiona las suscripciones y entrega los eventos de tareas.
+// Implementa ports.EventPublisher. Las entregas son asíncronas y los reintentos
+// pendientes viven en memoria: se pierden si el proceso se reinicia.
+type WebhookService struct {
+	repo         ports.WebhookRepository
+	client       *http.Client // webhooks de usuario: solo IPs públicas
+	systemClient *http.Client // webhooks de sistema
+	cfg          WebhookConfig
+
+	jobs chan webhookJob
+	done chan struct{}
+	wg   sync.WaitGroup
+	stop sync.Once
+}
+
+// NewWebhookService crea una nueva instancia de WebhookService
+func NewWebhookService(repo ports.WebhookRepository, cfg WebhookConfig) *WebhookService {
+	cfg = cfg.withDefaults()
+	return &WebhookService{
+		repo:         repo,
+		client:       newWebhookClient(cfg.Timeout, cfg.AllowPrivateNetworks),
+		systemClient: newWebhookClient(cfg.Timeout, true),
+		cfg:          cfg,
+		jobs:         make(chan webhookJob, cfg.QueueSize),
+		done:         make(chan struct{}),
+	}
+}
+
+// Start lanza los workers de entrega
+func (ws *WebhookService) Start() {
+	for i := 0; i < ws.cfg.Workers; i++ {
+		ws.wg.Add(1)
+		go ws.worker()
+	}
+}
+
+// Stop detiene los workers; los reintentos programados se descartan
+func (ws *WebhookService) Stop() {
+	ws.stop.Do(func() { close(ws.done) })
+	ws.wg.Wait()
+}
+
+// CreateWebhook registra un webhook y retorna su secreto (solo se muestra una vez)
+func (ws *WebhookService) CreateWebhook(ctx context.Context, hook *domain.Webhook) (string, error) {
+	ctx = ensureContext(ctx)
+
+	if err := ws.validate(hook); err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("error al generar secreto: %w", err)
+	}
+
+	now := time.Now()
+	hook.Secret = "whsec_" + base64.RawURLEncoding.EncodeToString(raw)
+	hook.Active = true
+	hook.FailureCount = 0
+	hook.CreatedAt = now
+	hook.UpdatedAt = now
+	if hook.Events == nil {
+		hook.Events = []string{}
+	}
+
+	if err := ws.repo.Create(ctx, hook); err != nil {
+		return "", err
+	}
+
+	return hook.Secret, nil
+}
+
+// ListWebhooks lista los webhooks del dueño ("" = sistema)
+func (ws *WebhookService) ListWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return ws.repo.ListByUser(ensureContext(ctx), userID)
+}
+
+// GetWebhook obtiene un webhook del dueño
+func (ws *WebhookService) GetWebhook(ctx context.Context, hookID, userID string) (*domain.Webhook, error) {
+	return ws.repo.GetByID(ensureContext(ctx), hookID, userID)
+}
+
+// UpdateWebhook aplica cambios parciales. Reactivar un webhook desactivado
+// reinicia su contador de fallos.
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, hookID, userID string, patch WebhookPatch) (*domain.Webhook, error) {
+	ctx = ensureContext(ctx)
+
+	hook, err := ws.repo.GetByID(ctx, hookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.URL != nil {
+		hook.URL = *patch.URL
+	}
+	if patch.Events != nil {
+		hook.Events = *patch.Events
+	}
+	if patch.Description != nil {
+		hook.Description = *patch.Description
+	}
+	if patch.Active != nil {
+		if *patch.Active && !hook.Active {
+			hook.FailureCount = 0
+			hook.DisabledAt = nil
+			hook.DisabledReason = ""
+		}
+		hook.Active = *patch.Active
+	}
+	hook.UpdatedAt = time.Now()
+
+	if err := ws.validate(hook); err != nil {
+		return nil, err
+	}
+	if err := ws.repo.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+// validate valida el webhook; los de usuario no pueden apuntar a la red interna
+func (ws *WebhookService) validate(hook *domain.Webhook) error {
+	if err := hook.IsValid(); err != nil {
+		return err
+	}
+	if !hook.IsSystem() && !ws.cfg.AllowPrivateNetworks {
+		return checkWebhookTarget(hook.URL)
+	}
+	return nil
+}
+
+// DeleteWebhook elimina un webhook y su historial
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, hookID, userID string) error {
+	return ws.repo.Delete(ensureContext(ctx), hookID, userID)
+}
+
+// ListDeliveries retorna el log de entregas de un webhook del dueño
+func (ws *WebhookService) ListDeliveries(ctx context.Context, hookID, userID string, limit int) ([]domain.WebhookDelivery, error) {
+	ctx = ensureContext(ctx)
+
+	if _, err := ws.repo.GetByID(ctx, hookID, userID); err != nil {
+		return nil, err
+	}
+
+	return ws.repo.ListDeliveries(ctx, hookID, limit)
+}
+
+// SendTest envía un evento de prueba de forma síncrona (un solo intento).
+// El resultado queda en el log pero no afecta el contador de fallos.
+func (ws *WebhookService) SendTest(ctx context.Context, hookID, userID string) (*domain.WebhookDelivery, error) {
+	ctx = ensureContext(ctx)
+
+	hook, err := ws.repo.GetByID(ctx, hookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := domain.TaskEvent{
+		ID:         newEventID(),
+		Type:       WebhookTestEvent,
+		UserID:     hook.UserID,
+		OccurredAt: time.Now(),
+	}
+	payload, err := webhookPayload(event)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := ws.send(ctx, hook, event.ID, event.Type, payload, 1)
+	if err := ws.repo.AddDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish encola el evento (ports.EventPublisher). Los webhooks suscritos
+// se buscan en el worker, así la mutación de la tarea no espera al repositorio.
+func (ws *WebhookService) Publish(_ context.Context, event domain.TaskEvent) {
+	ws.enqueue(webhookJob{event: &event, eventID: event.ID, eventType: event.Type})
+}
+
+// dispatch encola una entrega por cada webhook activo suscrito al evento
+func (ws *WebhookService) dispatch(event *domain.TaskEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hooks, err := ws.repo.ListActive(ctx, event.UserID)
+	if err != nil {
+		log.Printf("⚠️ Error al buscar webhooks para evento %s: %v", event.ID, err)
+		return
+	}
+
+	var payload []byte
+	for _, hook := range hooks {
+		if !hook.Subscribes(event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = webhookPayload(*event); err != nil {
+				log.Printf("⚠️ Error al serializar evento %s: %v", event.ID, err)
+				return
+			}
+		}
+		ws.enqueue(webhookJob{hook: hook, eventID: event.ID, eventType: event.Type, payload: payload, attempt: 1})
+	}
+}
+
+func (ws *WebhookService) enqueue(job webhookJob) {
+	select {
+	case <-ws.done:
+	case ws.jobs <- job:
+	default:
+		if job.event != nil {
+			log.Printf("⚠️ Cola de webhooks llena: se descarta el evento %s", job.eventID)
+			return
+		}
+		log.Printf("⚠️ Cola de webhooks llena: se descarta el evento %s para %s", job.eventID, job.hook.ID)
+	}
+}
+
+func (ws *WebhookService) worker() {
+	defer ws.wg.Done()
+	for {
+		select {
+		case <-ws.done:
+			return
+		case job := <-ws.jobs:
+			ws.process(job)
+		}
+	}
+}
+
+// process reparte un evento entre sus webhooks o realiza un intento de
+// entrega y programa el reintento si corresponde
+func (ws *WebhookService) process(job webhookJob) {
+	if job.event != nil {
+		ws.dispatch(job.event)
+		return
+	}
+	ctx := context.Background()
+
+	// En reintentos se relee el webhook: pudo ser editado, desactivado o eliminado
+	if job.attempt > 1 {
+		hook, err := ws.repo.GetByID(ctx, job.hook.ID, job.hook.UserID)
+		if err != nil || !hook.Active {
+			return
+		}
+		job.hook = *hook
+	}
+
+	delivery := ws.send(ctx, &job.hook, job.eventID, job.eventType, job.payload, job.attempt)
+	if err := ws.repo.AddDelivery(ctx, delivery); err != nil {
+		log.Printf("⚠️ Error al registrar entrega de webhook %s: %v", job.hook.ID, err)
+	}
+
+	if delivery.Success {
+		if job.hook.FailureCount > 0 {
+			if err := ws.repo.ResetFailures(ctx, job.hook.ID); err != nil {
+				log.Printf("⚠️ Error al reiniciar fallos de webhook %s: %v", job.hook.ID, err)
+			}
+		}
+		return
+	}
+
+	if job.attempt < ws.cfg.MaxAttempts && retryableDelivery(delivery) {
+		next := job
+		next.attempt++
+		time.AfterFunc(ws.backoff(job.attempt), func() { ws.enqueue(next) })
+		return
+	}
+
+	// Evento definitivamente fallido
+	failures, err := ws.repo.RecordFailure(ctx, job.hook.ID)
+	if err != nil {
+		log.Printf("⚠️ Error al registrar fallo de webhook %s: %v", job.hook.ID, err)
+		return
+	}
+	if failures >= ws.cfg.DisableAfter {
+		reason := fmt.Sprintf("desactivado tras %d eventos fallidos consecutivos", failures)
+		if err := ws.repo.Disable(ctx, job.hook.ID, reason, time.Now()); err != nil {
+			log.Printf("⚠️ Error al desactivar webhook %s: %v", job.hook.ID, err)
+			return
+		}
+		log.Printf("⚠️ Webhook %s %s", job.hook.ID, reason)
+	}
+}
+
+// backoff espera antes del siguiente intento: base * 2^(intento-1), con tope
+func (ws *WebhookService) backoff(attempt int) time.Duration {
+	d := ws.cfg.BaseBackoff
+	for i := 1; i < attempt && d < ws.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > ws.cfg.MaxBackoff {
+		d = ws.cfg.MaxBackoff
+	}
+	return d
+}
+
+// retryableDelivery errores de red, 408, 429 y 5xx se reintentan; otros 4xx no
+func retryableDelivery(d *domain.WebhookDelivery) bool {
+	switch {
+	case d.StatusCode == 0:
+		return true
+	case d.StatusCode == http.StatusRequestTimeout, d.StatusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return d.StatusCode >= 500
+	}
+}
+
+// send hace el POST firmado y retorna el registro de la entrega
+func (ws *WebhookService) send(ctx context.Context, hook *domain.Webhook, eventID, eventType string, payload []byte, attempt int) *domain.WebhookDelivery {
+	start := time.Now()
+	delivery := &domain.WebhookDelivery{
+		WebhookID: hook.ID,
+		EventID:   eventID,
+		EventType: eventType,
+		Attempt:   attempt,
+		CreatedAt: start,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "UniFlow-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookDeliveryHeader, eventID)
+	req.Header.Set(WebhookAttemptHeader, strconv.Itoa(attempt))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(hook.Secret, start.Unix(), payload))
+
+	client := ws.client
+	if hook.IsSystem() {
+		client = ws.systemClient
+	}
+	resp, err := client.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+
+	delivery.StatusCode = resp.StatusCode
+	// El cuerpo de la respuesta solo se guarda para los webhooks de sistema:
+	// el usuario lo vería en /deliveries y no debe poder leer otros servicios
+	if hook.IsSystem() {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBytes))
+		delivery.ResponseBody = string(body)
+	}
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("respuesta HTTP %d", resp.StatusCode)
+	}
+	return delivery
+}
+
+// webhookPayload cuerpo JSON enviado a los webhooks
+func webhookPayload(event domain.TaskEvent) ([]byte, error) {
+	body := map[string]interface{}{
+		"id":        event.ID,
+		"type":      event.Type,
+		"createdAt": event.OccurredAt,
+		"userId":    event.UserID,
+		"data": map[string]interface{}{
+			"taskId": event.TaskID,
+			"task":   event.Task,
+		},
+	}
+	return json.Marshal(body)
+}
+
+// SignWebhookPayload firma el cuerpo con HMAC-SHA256 sobre "<timestamp>.<body>".
+// Retorna el valor del header X-UniFlow-Signature: "t=<unix>,v1=<hex>".
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// VerifyWebhookSignature valida el header X-UniFlow-Signature recibido.
+// tolerance limita la antigüedad del timestamp para evitar replays.
+func VerifyWebhookSignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts int64
+	var sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts, _ = strconv.ParseInt(v, 10, 64)
+		case "v1":
+			sig = v
+		}
+	}
+	if ts == 0 || sig == "" {
+		return fmt.Errorf("firma mal formada")
+	}
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("firma expirada")
+		}
+	}
+
+	expected := SignWebhookPayload(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(fmt.Sprintf("t=%d,v1=%s", ts, sig))) {
+		return fmt.Errorf("firma inválida")
+	}
+	return nil
+}
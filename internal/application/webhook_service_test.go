@@ -0,0 +1,324 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+// waitFor reintenta cond hasta que se cumpla o expire el timeout
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met before timeout")
+}
+
+func TestWebhookSignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+	header := SignWebhookPayload("whsec_test", now.Unix(), body)
+
+	if err := VerifyWebhookSignature("whsec_test", header, body, now, 5*time.Minute); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+	if err := VerifyWebhookSignature("whsec_test", header, []byte(`{"id":"evt_2"}`), now, 5*time.Minute); err == nil {
+		t.Error("tampered body should be rejected")
+	}
+	if err := VerifyWebhookSignature("otro", header, body, now, 5*time.Minute); err == nil {
+		t.Error("wrong secret should be rejected")
+	}
+	if err := VerifyWebhookSignature("whsec_test", header, body, now.Add(time.Hour), 5*time.Minute); err == nil {
+		t.Error("old signature should be rejected")
+	}
+}
+
+func TestWebhookDeliversSignedTaskEvents(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []map[string]interface{}
+		secret   string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if err := VerifyWebhookSignature(secret, r.Header.Get(WebhookSignatureHeader), body, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload map[string]interface{}
+		_ = json.Unmarshal(body, &payload)
+		received = append(received, payload)
+	}))
+	defer server.Close()
+
+	repo := memory.NewWebhookRepo()
+	ws := NewWebhookService(repo, WebhookConfig{BaseBackoff: time.Millisecond, AllowPrivateNetworks: true})
+	ws.Start()
+	defer ws.Stop()
+
+	hook := &domain.Webhook{UserID: "user-1", URL: server.URL, Events: []string{domain.EventTaskCreated}}
+	s, err := ws.CreateWebhook(context.Background(), hook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	secret = s
+	mu.Unlock()
+
+	taskRepo := memory.NewRepo()
+	ts := NewTaskService(taskRepo, nil)
+	ts.AddEventPublisher(ws)
+
+	task := &domain.Task{UserID: "user-1", Title: "Quiz", SubjectID: "fis", Status: domain.StatusTodo,
+		Priority: domain.PriorityHigh, Type: domain.TypeQuiz, DueDate: time.Now().Add(24 * time.Hour)}
+	if err := ts.CreateTask(context.Background(), task, "user-1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	// No suscrito a task.completed ni pertenece a otro usuario
+	task.Status = domain.StatusDone
+	_ = ts.UpdateTaskStatus(context.Background(), task)
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 1
+	})
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0]["type"] != domain.EventTaskCreated {
+		t.Fatalf("expected a single task.created event, got %+v", received)
+	}
+	data := received[0]["data"].(map[string]interface{})
+	if data["taskId"] != task.ID {
+		t.Errorf("unexpected payload data %+v", data)
+	}
+}
+
+func TestWebhookRetriesAndAutoDisables(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	repo := memory.NewWebhookRepo()
+	ws := NewWebhookService(repo, WebhookConfig{MaxAttempts: 3, BaseBackoff: time.Millisecond, DisableAfter: 2, AllowPrivateNetworks: true})
+	ws.Start()
+	defer ws.Stop()
+
+	hook := &domain.Webhook{UserID: "user-1", URL: server.URL}
+	if _, err := ws.CreateWebhook(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+
+	event := domain.TaskEvent{ID: "evt_1", Type: domain.EventTaskUpdated, UserID: "user-1", TaskID: "t-1"}
+	ws.Publish(context.Background(), event)
+	waitFor(t, func() bool {
+		h, _ := repo.GetByID(context.Background(), hook.ID, "user-1")
+		return h.FailureCount == 1
+	})
+
+	event.ID = "evt_2"
+	ws.Publish(context.Background(), event)
+	waitFor(t, func() bool {
+		h, _ := repo.GetByID(context.Background(), hook.ID, "user-1")
+		return !h.Active
+	})
+
+	mu.Lock()
+	if calls != 6 {
+		t.Errorf("expected 3 attempts per event (6 calls), got %d", calls)
+	}
+	mu.Unlock()
+
+	deliveries, _ := ws.ListDeliveries(context.Background(), hook.ID, "user-1", 0)
+	if len(deliveries) != 6 || deliveries[0].Attempt != 3 || deliveries[0].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected delivery log %+v", deliveries)
+	}
+
+	// Desactivado: no recibe más eventos
+	event.ID = "evt_3"
+	ws.Publish(context.Background(), event)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	if calls != 6 {
+		t.Errorf("disabled webhook should not be called, got %d calls", calls)
+	}
+	mu.Unlock()
+
+	// Reactivar reinicia el contador
+	active := true
+	h, err := ws.UpdateWebhook(context.Background(), hook.ID, "user-1", WebhookPatch{Active: &active})
+	if err != nil || !h.Active || h.FailureCount != 0 || h.DisabledAt != nil {
+		t.Errorf("expected webhook re-enabled, got %+v (%v)", h, err)
+	}
+}
+
+// listCountingRepo cuenta las búsquedas de webhooks activos
+type listCountingRepo struct {
+	*memory.WebhookRepo
+	lists atomic.Int32
+}
+
+func (r *listCountingRepo) ListActive(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	r.lists.Add(1)
+	return r.WebhookRepo.ListActive(ctx, userID)
+}
+
+// Publish solo encola: los suscriptores se buscan en el worker
+func TestWebhookPublishResolvesSubscribersInWorker(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(WebhookEventHeader)
+	}))
+	defer server.Close()
+
+	repo := &listCountingRepo{WebhookRepo: memory.NewWebhookRepo()}
+	ws := NewWebhookService(repo, WebhookConfig{AllowPrivateNetworks: true})
+	hook := &domain.Webhook{UserID: "user-1", URL: server.URL}
+	if _, err := ws.CreateWebhook(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+
+	ws.Publish(context.Background(), domain.TaskEvent{ID: "evt_1", Type: domain.EventTaskCreated, UserID: "user-1", TaskID: "t-1"})
+	if n := repo.lists.Load(); n != 0 {
+		t.Fatalf("Publish queried the repository %d times", n)
+	}
+
+	ws.Start()
+	defer ws.Stop()
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("event not delivered")
+	}
+	if n := repo.lists.Load(); n != 1 {
+		t.Errorf("expected one lookup in the worker, got %d", n)
+	}
+}
+
+func TestWebhookClientErrorIsNotRetried(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	repo := memory.NewWebhookRepo()
+	ws := NewWebhookService(repo, WebhookConfig{BaseBackoff: time.Millisecond, AllowPrivateNetworks: true})
+	ws.Start()
+	defer ws.Stop()
+
+	hook := &domain.Webhook{URL: server.URL} // webhook de sistema
+	if _, err := ws.CreateWebhook(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+
+	ws.Publish(context.Background(), domain.TaskEvent{ID: "evt_1", Type: domain.EventTaskDeleted, UserID: "cualquiera"})
+	waitFor(t, func() bool {
+		h, _ := repo.GetByID(context.Background(), hook.ID, "")
+		return h.FailureCount == 1
+	})
+
+	deliveries, _ := ws.ListDeliveries(context.Background(), hook.ID, "", 0)
+	if len(deliveries) != 1 {
+		t.Errorf("expected a single attempt for 410, got %d", len(deliveries))
+	}
+}
+
+func TestWebhookRejectsPrivateTargets(t *testing.T) {
+	var hits int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	repo := memory.NewWebhookRepo()
+	ws := NewWebhookService(repo, WebhookConfig{})
+
+	for _, url := range []string{server.URL, "http://localhost:8080/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]/", "http://10.0.0.5/"} {
+		if _, err := ws.CreateWebhook(context.Background(), &domain.Webhook{UserID: "user-a", URL: url}); err == nil {
+			t.Errorf("%s: expected the private target to be rejected", url)
+		}
+	}
+
+	// Un nombre que resuelve a loopback (o cambia de IP después de
+	// registrarse) se bloquea al conectar
+	hook := &domain.Webhook{UserID: "user-a", URL: server.URL, Active: true}
+	if err := repo.Create(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+	delivery, err := ws.SendTest(context.Background(), hook.ID, "user-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if delivery.Success || delivery.StatusCode != 0 || hits != 0 {
+		t.Errorf("expected the loopback connection to be blocked, got %+v (hits %d)", delivery, hits)
+	}
+
+	for _, addr := range []string{"127.0.0.1:80", "169.254.169.254:80", "192.168.1.10:443", "[fd00::1]:443", "100.64.0.1:80"} {
+		if err := publicOnlyControl("tcp", addr, nil); err == nil {
+			t.Errorf("%s: expected dial to be refused", addr)
+		}
+	}
+	if err := publicOnlyControl("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("public address should be allowed: %v", err)
+	}
+}
+
+func TestWebhookDoesNotFollowRedirects(t *testing.T) {
+	internalHit := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHit = true
+		_, _ = w.Write([]byte("secreto interno"))
+	}))
+	defer internal.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL+"/latest/meta-data", http.StatusFound)
+	}))
+	defer redirector.Close()
+
+	// AllowPrivateNetworks para poder usar servidores locales; la
+	// redirección no se sigue aunque el destino estuviera permitido
+	ws := NewWebhookService(memory.NewWebhookRepo(), WebhookConfig{AllowPrivateNetworks: true})
+	hook := &domain.Webhook{UserID: "user-a", URL: redirector.URL}
+	if _, err := ws.CreateWebhook(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery, err := ws.SendTest(context.Background(), hook.ID, "user-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if internalHit || delivery.Success || delivery.StatusCode != http.StatusFound || delivery.ResponseBody != "" {
+		t.Errorf("redirect should not be followed nor its body stored, got %+v (internal hit %v)", delivery, internalHit)
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tipos de entrada del hilo de actividad de una tarea
+const (
+	ActivityComment = "comment"
+	ActivityStatus  = "status"
+)
+
+// MaxCommentLength máximo de caracteres de un comentario
+const MaxCommentLength = 5000
+
+// Activity entrada del hilo de una tarea: un comentario o un cambio de
+// estado. El hilo es el de la tarea del dueño, compartido por todos los
+// miembros.
+type Activity struct {
+	ID         string    `bson:"_id,omitempty" json:"id"`
+	TaskID     string    `bson:"taskId" json:"taskId"` // Tarea del dueño
+	OwnerID    string    `bson:"ownerId" json:"-"`
+	Kind       string    `bson:"kind" json:"kind"`
+	AuthorID   string    `bson:"authorId" json:"authorId"`
+	AuthorName string    `bson:"authorName,omitempty" json:"authorName,omitempty"`
+	Body       string    `bson:"body,omitempty" json:"body,omitempty"`
+	Mentions   []string  `bson:"mentions,omitempty" json:"mentions,omitempty"` // Integrantes mencionados con @
+	Edited     bool      `bson:"edited,omitempty" json:"edited,omitempty"`
+	FromStatus string    `bson:"fromStatus,omitempty" json:"fromStatus,omitempty"`
+	ToStatus   string    `bson:"toStatus,omitempty" json:"toStatus,omitempty"`
+	CreatedAt  time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ActivityQuery página del hilo de una tarea, de la más reciente a la más antigua
+type ActivityQuery struct {
+	OwnerID string
+	TaskID  string
+	Kind    string // Vacío = comentarios y cambios de estado
+	Page    int
+	Limit   int
+}
+
+// NormalizeComment valida y recorta el texto de un comentario
+func NormalizeComment(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return body, nil
+}
+
+// ParseMentions integrantes del grupo mencionados en body como @Nombre (sin
+// distinguir mayúsculas). Los nombres largos se buscan primero para que
+// "@Ana María" no cuente también como "@Ana".
+func ParseMentions(body string, members []string) []string {
+	candidates := make([]string, 0, len(members))
+	for _, m := range members {
+		if m = strings.TrimSpace(m); m != "" {
+			candidates = append(candidates, m)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return len(candidates[i]) > len(candidates[j]) })
+
+	text := []rune(strings.ToLower(body))
+	var mentions []string
+	for _, m := range candidates {
+		needle := []rune("@" + strings.ToLower(m))
+		found := false
+		for i := 0; i+len(needle) <= len(text); i++ {
+			if !hasRunesAt(text, needle, i) {
+				continue
+			}
+			end := i + len(needle)
+			if end < len(text) && (unicode.IsLetter(text[end]) || unicode.IsDigit(text[end])) {
+				continue
+			}
+			// Se marca para no volver a coincidir con un nombre más corto
+			for k := i; k < end; k++ {
+				text[k] = ' '
+			}
+			found = true
+		}
+		if found {
+			mentions = append(mentions, m)
+		}
+	}
+	return mentions
+}
+
+func hasRunesAt(text, needle []rune, at int) bool {
+	for k, r := range needle {
+		if text[at+k] != r {
+			return false
+		}
+	}
+	return true
+}
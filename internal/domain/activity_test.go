@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	members := []string{"Ana", "Ana María", "luis@uniflow.edu", " "}
+	cases := map[string][]string{
+		"@Ana revisa":                   {"Ana"},
+		"@ana maría y @ANA":             {"Ana María", "Ana"},
+		"cc @luis@uniflow.edu.":         {"luis@uniflow.edu"},
+		"@Anabel no es integrante":      nil,
+		"sin menciones, ana@ejemplo.cr": nil,
+	}
+	for body, want := range cases {
+		if got := ParseMentions(body, members); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseMentions(%q) = %v, want %v", body, got, want)
+		}
+	}
+}
+
+func TestNormalizeComment(t *testing.T) {
+	if body, err := NormalizeComment("  hola \n"); err != nil || body != "hola" {
+		t.Errorf("unexpected %q %v", body, err)
+	}
+	for _, body := range []string{"", "   ", strings.Repeat("á", MaxCommentLength+1)} {
+		if _, err := NormalizeComment(body); !errors.Is(err, ErrInvalidComment) {
+			t.Errorf("expected ErrInvalidComment for %d chars, got %v", len(body), err)
+		}
+	}
+}
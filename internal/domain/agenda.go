@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Límites de GET /tasks/agenda
+const (
+	DefaultAgendaDays = 7
+	MaxAgendaDays     = 31
+)
+
+// AgendaDay tareas de un día calendario en la zona del usuario
+type AgendaDay struct {
+	Date           time.Time // Medianoche local del día
+	Tasks          []Task    // Por dueDate
+	EstimatedHours int       // Suma de estimatedTimeHours del día
+}
+
+// Agenda próximos días del usuario, incluidos los que no tienen tareas
+type Agenda struct {
+	Location              *time.Location
+	Overdue               []Task // Pendientes que vencieron antes de hoy
+	OverdueEstimatedHours int
+	Days                  []AgendaDay // El primero es hoy
+	CapacityHours         int         // Horas de estudio por día del usuario (0 = sin definir)
+}
+
+// OverCapacity true si las horas estimadas del día superan la capacidad
+func (a *Agenda) OverCapacity(day AgendaDay) bool {
+	return a.CapacityHours > 0 && day.EstimatedHours > a.CapacityHours
+}
+
+// BuildAgenda arma la agenda de days días a partir de hoy (en loc). overdue
+// se toma tal cual; tasks se agrupa por día local y se descartan las
+// canceladas y las que caen fuera del rango.
+func BuildAgenda(now time.Time, loc *time.Location, days int, overdue, tasks []Task) Agenda {
+	if loc == nil {
+		loc = time.UTC
+	}
+	agenda := Agenda{Location: loc, Overdue: overdue, Days: make([]AgendaDay, days)}
+	for _, t := range overdue {
+		agenda.OverdueEstimatedHours += t.EstimatedTimeHours
+	}
+
+	index := make(map[string]int, days)
+	today := StartOfDay(now, loc)
+	for i := range agenda.Days {
+		day := today.AddDate(0, 0, i)
+		agenda.Days[i] = AgendaDay{Date: day, Tasks: []Task{}}
+		index[day.Format("2006-01-02")] = i
+	}
+
+	for _, t := range tasks {
+		if t.Status == StatusCancelled {
+			continue
+		}
+		i, ok := index[t.DueDate.In(loc).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		agenda.Days[i].Tasks = append(agenda.Days[i].Tasks, t)
+		agenda.Days[i].EstimatedHours += t.EstimatedTimeHours
+	}
+	return agenda
+}
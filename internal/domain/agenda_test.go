@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildAgendaGroupsByLocalDay(t *testing.T) {
+	ny, err := LoadTimeZone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sábado 8 de marzo de 2025; el domingo 9 dura 23 horas
+	now := time.Date(2025, 3, 8, 20, 0, 0, 0, ny)
+	at := func(day, hour int) time.Time { return time.Date(2025, 3, day, hour, 30, 0, 0, ny) }
+
+	overdue := []Task{{ID: "late", DueDate: at(6, 9), EstimatedTimeHours: 2}}
+	tasks := []Task{
+		{ID: "sat", DueDate: at(8, 22), EstimatedTimeHours: 1},
+		{ID: "sun-late", DueDate: at(9, 23), EstimatedTimeHours: 3}, // 03:30Z del lunes
+		{ID: "mon", DueDate: at(10, 0), EstimatedTimeHours: 4},
+		{ID: "cancelled", DueDate: at(10, 9), Status: StatusCancelled, EstimatedTimeHours: 8},
+		{ID: "out", DueDate: at(12, 9)},
+	}
+
+	a := BuildAgenda(now.UTC(), ny, 3, overdue, tasks)
+	if len(a.Days) != 3 || a.OverdueEstimatedHours != 2 || len(a.Overdue) != 1 {
+		t.Fatalf("unexpected agenda %+v", a)
+	}
+	want := []struct {
+		date  string
+		ids   []string
+		hours int
+	}{
+		{"2025-03-08", []string{"sat"}, 1},
+		{"2025-03-09", []string{"sun-late"}, 3},
+		{"2025-03-10", []string{"mon"}, 4},
+	}
+	for i, w := range want {
+		day := a.Days[i]
+		if got := day.Date.Format("2006-01-02"); got != w.date || day.Date.In(ny).Hour() != 0 {
+			t.Errorf("day %d: date %s, want local midnight of %s", i, day.Date, w.date)
+		}
+		if len(day.Tasks) != len(w.ids) || day.Tasks[0].ID != w.ids[0] || day.EstimatedHours != w.hours {
+			t.Errorf("day %s: got %+v (%dh)", w.date, day.Tasks, day.EstimatedHours)
+		}
+	}
+
+	// Días vacíos con lista vacía (no nil)
+	empty := BuildAgenda(now, ny, 2, nil, nil)
+	if len(empty.Days) != 2 || empty.Days[1].Tasks == nil {
+		t.Errorf("expected empty days, got %+v", empty.Days)
+	}
+}
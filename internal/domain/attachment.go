@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+// Límites de adjuntos por defecto
+const (
+	DefaultMaxAttachmentBytes = 10 << 20 // 10 MiB
+	MaxAttachmentsPerTask     = 20
+)
+
+// DefaultAttachmentTypes tipos MIME aceptados por defecto
+var DefaultAttachmentTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"text/plain",
+	"text/csv",
+	"application/zip",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// Attachment archivo adjunto a la tarea del dueño. Task.Attachments guarda
+// los IDs; el contenido está en el BlobStore bajo BlobKey, que depende solo
+// del checksum: dos adjuntos con el mismo contenido comparten el blob.
+type Attachment struct {
+	ID          string    `bson:"_id,omitempty" json:"id"`
+	TaskID      string    `bson:"taskId" json:"taskId"` // Tarea del dueño
+	OwnerID     string    `bson:"ownerId" json:"-"`
+	FileName    string    `bson:"fileName" json:"fileName"`
+	ContentType string    `bson:"contentType" json:"contentType"`
+	Size        int64     `bson:"size" json:"size"`
+	Checksum    string    `bson:"checksum" json:"checksum"` // SHA-256 en hex
+	BlobKey     string    `bson:"blobKey" json:"-"`
+	UploadedBy  string    `bson:"uploadedBy" json:"uploadedBy"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// AttachmentBlobKey clave del contenido en el BlobStore. version distingue
+// cada vez que el contenido se vuelve a escribir después de eliminarse, así
+// un borrado tardío nunca alcanza a la copia nueva.
+func AttachmentBlobKey(checksum, version string) string {
+	return "sha256/" + checksum[:2] + "/" + checksum + "-" + version
+}
+
+// NormalizeContentType tipo MIME sin parámetros (charset, etc.); si el
+// declarado es genérico se deduce por la extensión del archivo
+func NormalizeContentType(declared, fileName string) string {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil || mediaType == "application/octet-stream" {
+		mediaType = ""
+		if byExt := mime.TypeByExtension(strings.ToLower(path.Ext(fileName))); byExt != "" {
+			mediaType, _, _ = mime.ParseMediaType(byExt)
+		}
+	}
+	return strings.ToLower(mediaType)
+}
+
+// CleanFileName nombre de archivo sin rutas ni caracteres de control
+func CleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "archivo"
+	}
+	return name
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// CalendarToken es un secreto revocable que permite a un cliente de calendario
+// (Google Calendar, Outlook, Apple Calendar) leer el feed ICS de un usuario
+// sin enviar los headers X-User-* que exige AuthMiddleware.
+// Solo se persiste el hash SHA-256 del token; el valor en claro se entrega una vez.
+type CalendarToken struct {
+	ID         string     `bson:"_id,omitempty" json:"id"`
+	UserID     string     `bson:"userId" json:"-"`
+	Name       string     `bson:"name" json:"name"`
+	TokenHash  string     `bson:"tokenHash" json:"-"`
+	CreatedAt  time.Time  `bson:"createdAt" json:"createdAt"`
+	LastUsedAt *time.Time `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+}
+
+// IsRevoked indica si el token ya no es válido
+func (t *CalendarToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// CalendarFeedFilter filtros opcionales del feed ICS
+type CalendarFeedFilter struct {
+	PeriodID  string
+	SubjectID string
+	Type      []string
+}
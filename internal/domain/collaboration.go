@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Roles en una tarea compartida
+const (
+	RoleOwner  = "owner"  // Creó la tarea: edita, invita y elimina
+	RoleEditor = "editor" // Edita los datos compartidos
+	RoleViewer = "viewer" // Solo ve la tarea
+)
+
+// MaxCollaboratorsPerTask límite de colaboradores (e invitaciones) por tarea
+const MaxCollaboratorsPerTask = 20
+
+// IsValidRole roles que se pueden asignar a un colaborador
+func IsValidRole(role string) bool {
+	return role == RoleEditor || role == RoleViewer
+}
+
+// Una tarea compartida es la del dueño más una copia por colaborador
+// (SharedTaskID apunta a la del dueño). Cada copia pertenece a su usuario:
+// aparece en su GET /tasks y su dashboard con las mismas consultas por
+// userId, y guarda su propio status, actualTimeHours y completedAt. Los
+// datos compartidos (título, fecha, etc.) se copian desde la del dueño cada
+// vez que este o un editor la modifica.
+
+// IsSharedCopy indica si la tarea es la copia de un colaborador
+func (t *Task) IsSharedCopy() bool {
+	return t.SharedTaskID != ""
+}
+
+// AccessRole rol del dueño de la tarea (UserID) sobre ella
+func (t *Task) AccessRole() string {
+	if t.IsSharedCopy() {
+		return t.Role
+	}
+	return RoleOwner
+}
+
+// CanEdit indica si su usuario puede modificar los datos compartidos
+func (t *Task) CanEdit() bool {
+	role := t.AccessRole()
+	return role == RoleOwner || role == RoleEditor
+}
+
+// CopySharedFields copia de src los datos comunes a todos los miembros; no
+// toca dueño, status, tiempo real, completedAt ni externalId
+func (t *Task) CopySharedFields(src *Task) {
+	t.Title = src.Title
+	t.Description = src.Description
+	t.SubjectID = src.SubjectID
+	t.PeriodID = src.PeriodID
+	t.DueDate = src.DueDate
+	t.Priority = src.Priority
+	t.Type = src.Type
+	t.EstimatedTimeHours = src.EstimatedTimeHours
+	t.GradeWeight = src.GradeWeight
+	t.IsBlocked = src.IsBlocked
+	t.Tags = append([]string{}, src.Tags...)
+	t.IsGroupWork = src.IsGroupWork
+	t.GroupMembers = append([]string{}, src.GroupMembers...)
+	t.Attachments = append([]string{}, src.Attachments...)
+}
+
+// NewSharedCopy copia de la tarea del dueño para un colaborador, con su
+// progreso personal en cero
+func NewSharedCopy(owned *Task, userID, role string, now time.Time) *Task {
+	t := &Task{
+		UserID:       userID,
+		SharedTaskID: owned.ID,
+		OwnerID:      owned.UserID,
+		Role:         role,
+		Status:       StatusTodo,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	t.CopySharedFields(owned)
+	return t
+}
+
+// Invitation invitación por email a una tarea compartida. Queda pendiente
+// hasta que la acepta un usuario autenticado con ese email.
+type Invitation struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	TaskID    string    `bson:"taskId" json:"taskId"` // Tarea del dueño
+	OwnerID   string    `bson:"ownerId" json:"-"`
+	Email     string    `bson:"email" json:"email"` // En minúsculas
+	Role      string    `bson:"role" json:"role"`
+	TaskTitle string    `bson:"taskTitle" json:"taskTitle"`
+	InvitedBy string    `bson:"invitedBy" json:"invitedBy"` // Nombre o email del dueño
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// NormalizeEmail email para comparar invitaciones
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Collaborator miembro de una tarea compartida
+type Collaborator struct {
+	UserID          string     `json:"userId,omitempty"`
+	Email           string     `json:"email,omitempty"` // Invitaciones pendientes
+	Role            string     `json:"role"`
+	Pending         bool       `json:"pending"`                   // Invitación por email sin aceptar
+	InvitationID    string     `json:"invitationId,omitempty"`    // Solo pendientes
+	TaskID          string     `json:"taskId,omitempty"`          // Tarea del miembro (la original o su copia)
+	Status          string     `json:"status,omitempty"`          // Progreso personal
+	ActualTimeHours *int       `json:"actualTimeHours,omitempty"` // Tiempo personal
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+	JoinedAt        time.Time  `json:"joinedAt"`
+}
+
+// MemberOf colaborador a partir de su tarea (la del dueño o una copia)
+func MemberOf(t *Task) Collaborator {
+	return Collaborator{
+		UserID:          t.UserID,
+		Role:            t.AccessRole(),
+		TaskID:          t.ID,
+		Status:          t.Status,
+		ActualTimeHours: t.ActualTimeHours,
+		CompletedAt:     t.CompletedAt,
+		JoinedAt:        t.CreatedAt,
+	}
+}
+
+// PendingMember colaborador a partir de una invitación sin aceptar
+func PendingMember(inv *Invitation) Collaborator {
+	return Collaborator{
+		Email:        inv.Email,
+		Role:         inv.Role,
+		Pending:      true,
+		InvitationID: inv.ID,
+		JoinedAt:     inv.CreatedAt,
+	}
+}
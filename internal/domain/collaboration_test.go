@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSharedCopy(t *testing.T) {
+	now := time.Now()
+	owned := &Task{ID: "t1", UserID: "owner", Title: "Proyecto", SubjectID: "s1", Status: StatusDone,
+		ActualTimeHours: new(int), CompletedAt: &now, Tags: []string{"grupal"}, GroupMembers: []string{"Ana"}}
+
+	shared := NewSharedCopy(owned, "ana", RoleViewer, now)
+	if shared.SharedTaskID != "t1" || shared.OwnerID != "owner" || shared.UserID != "ana" || !shared.IsSharedCopy() {
+		t.Fatalf("unexpected copy %+v", shared)
+	}
+	// El progreso es personal
+	if shared.Status != StatusTodo || shared.ActualTimeHours != nil || shared.CompletedAt != nil {
+		t.Errorf("expected personal progress to start empty, got %+v", shared)
+	}
+	if shared.Title != "Proyecto" || shared.SubjectID != "s1" || len(shared.Tags) != 1 {
+		t.Errorf("expected shared fields copied, got %+v", shared)
+	}
+	// Los slices no se comparten con el original
+	shared.Tags[0] = "otro"
+	if owned.Tags[0] != "grupal" {
+		t.Errorf("copy should not alias the owner's tags")
+	}
+
+	if owned.AccessRole() != RoleOwner || !owned.CanEdit() {
+		t.Errorf("owner should have full access")
+	}
+	if shared.AccessRole() != RoleViewer || shared.CanEdit() {
+		t.Errorf("viewer should not edit")
+	}
+	shared.Role = RoleEditor
+	if !shared.CanEdit() {
+		t.Errorf("editor should edit")
+	}
+}
+
+func TestIsValidRole(t *testing.T) {
+	for role, want := range map[string]bool{RoleEditor: true, RoleViewer: true, RoleOwner: false, "": false, "admin": false} {
+		if IsValidRole(role) != want {
+			t.Errorf("IsValidRole(%q) = %v, want %v", role, !want, want)
+		}
+	}
+}
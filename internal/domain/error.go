@@ -25,4 +25,45 @@ var (
 	ErrTaskCancelled        = &DomainError{Code: "TASK_CANCELLED", Message: "la tarea está cancelada"}
 	ErrInvalidTaskData      = &DomainError{Code: "INVALID_TASK", Message: "datos de tarea inválidos"}
 	ErrUnauthorized         = &DomainError{Code: "UNAUTHORIZED", Message: "no autorizado"}
+
+	ErrCalendarTokenNotFound = &DomainError{Code: "CALENDAR_TOKEN_NOT_FOUND", Message: "token de calendario no encontrado o revocado"}
+	ErrWebhookNotFound       = &DomainError{Code: "WEBHOOK_NOT_FOUND", Message: "webhook no encontrado"}
+
+	ErrSavedFilterNotFound  = &DomainError{Code: "SAVED_FILTER_NOT_FOUND", Message: "filtro guardado no encontrado"}
+	ErrSavedFilterNameTaken = &DomainError{Code: "SAVED_FILTER_NAME_TAKEN", Message: "ya existe un filtro guardado con ese nombre"}
+	ErrSavedFilterLimit     = &DomainError{Code: "SAVED_FILTER_LIMIT", Message: "se alcanzó el máximo de filtros guardados"}
+
+	ErrPreferencesNotFound = &DomainError{Code: "PREFERENCES_NOT_FOUND", Message: "el usuario no tiene preferencias guardadas"}
+	ErrInvalidTimeZone     = &DomainError{Code: "INVALID_TIMEZONE", Message: "zona horaria inválida (usar un nombre IANA, ej. America/Costa_Rica)"}
+	ErrInvalidPreferences  = &DomainError{Code: "INVALID_PREFERENCES", Message: "preferencias inválidas"}
+
+	ErrForbidden            = &DomainError{Code: "FORBIDDEN", Message: "el rol del usuario no permite esta operación"}
+	ErrInvalidRole          = &DomainError{Code: "INVALID_ROLE", Message: "rol inválido (editor o viewer)"}
+	ErrAlreadyCollaborator  = &DomainError{Code: "ALREADY_COLLABORATOR", Message: "el usuario ya colabora o está invitado a la tarea"}
+	ErrCollaboratorNotFound = &DomainError{Code: "COLLABORATOR_NOT_FOUND", Message: "colaborador no encontrado"}
+	ErrInvitationNotFound   = &DomainError{Code: "INVITATION_NOT_FOUND", Message: "invitación no encontrada"}
+	ErrCollaboratorLimit    = &DomainError{Code: "COLLABORATOR_LIMIT", Message: "se alcanzó el máximo de colaboradores de la tarea"}
+
+	ErrCommentNotFound = &DomainError{Code: "COMMENT_NOT_FOUND", Message: "comentario no encontrado"}
+	ErrInvalidComment  = &DomainError{Code: "INVALID_COMMENT", Message: "el comentario no puede estar vacío ni superar 5000 caracteres"}
+
+	ErrAttachmentNotFound    = &DomainError{Code: "ATTACHMENT_NOT_FOUND", Message: "adjunto no encontrado"}
+	ErrAttachmentTooLarge    = &DomainError{Code: "ATTACHMENT_TOO_LARGE", Message: "el archivo supera el tamaño máximo permitido"}
+	ErrAttachmentType        = &DomainError{Code: "ATTACHMENT_TYPE_NOT_ALLOWED", Message: "tipo de archivo no permitido"}
+	ErrAttachmentLimit       = &DomainError{Code: "ATTACHMENT_LIMIT", Message: "se alcanzó el máximo de adjuntos de la tarea"}
+	ErrAttachmentLinkExpired = &DomainError{Code: "ATTACHMENT_LINK_EXPIRED", Message: "el enlace de descarga expiró"}
+	ErrAttachmentLinkInvalid = &DomainError{Code: "ATTACHMENT_LINK_INVALID", Message: "firma del enlace de descarga inválida"}
+
+	ErrTemplateNotFound  = &DomainError{Code: "TEMPLATE_NOT_FOUND", Message: "plantilla no encontrada"}
+	ErrTemplateReadOnly  = &DomainError{Code: "TEMPLATE_READ_ONLY", Message: "las plantillas predefinidas no se pueden modificar"}
+	ErrTemplateNameTaken = &DomainError{Code: "TEMPLATE_NAME_TAKEN", Message: "ya existe una plantilla con ese nombre"}
+	ErrTemplateLimit     = &DomainError{Code: "TEMPLATE_LIMIT", Message: "se alcanzó el máximo de plantillas"}
+	ErrInvalidTemplate   = &DomainError{Code: "INVALID_TEMPLATE", Message: "plantilla inválida"}
+
+	ErrPeriodEmpty        = &DomainError{Code: "PERIOD_EMPTY", Message: "el período no tiene tareas para clonar"}
+	ErrPeriodCloneLimit   = &DomainError{Code: "PERIOD_CLONE_LIMIT", Message: "el período supera el máximo de tareas que se pueden clonar"}
+	ErrInvalidPeriodClone = &DomainError{Code: "INVALID_PERIOD_CLONE", Message: "parámetros de clonación inválidos"}
+
+	ErrInvalidCursor     = &DomainError{Code: "INVALID_CURSOR", Message: "cursor inválido o generado con otro orden"}
+	ErrCursorUnsupported = &DomainError{Code: "CURSOR_UNSUPPORTED", Message: "la paginación por cursor no está disponible al ordenar por relevancia"}
 )
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Tipos de eventos de tareas
+const (
+	EventTaskCreated       = "task.created"
+	EventTaskUpdated       = "task.updated"
+	EventTaskStatusChanged = "task.status_changed"
+	EventTaskCompleted     = "task.completed"
+	EventTaskDeleted       = "task.deleted"
+)
+
+// ValidEventTypes tipos de eventos a los que se puede suscribir
+var ValidEventTypes = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskStatusChanged,
+	EventTaskCompleted,
+	EventTaskDeleted,
+}
+
+// TaskEvent cambio ocurrido sobre una tarea
+type TaskEvent struct {
+	ID         string    `bson:"_id" json:"id"`
+	Type       string    `bson:"type" json:"type"`
+	UserID     string    `bson:"userId" json:"userId"`
+	TaskID     string    `bson:"taskId" json:"taskId"`
+	Task       *Task     `bson:"task,omitempty" json:"task,omitempty"` // nil en task.deleted
+	OccurredAt time.Time `bson:"occurredAt" json:"occurredAt"`
+}
+
+// IsValidEventType valida un tipo de evento
+func IsValidEventType(t string) bool {
+	for _, v := range ValidEventTypes {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
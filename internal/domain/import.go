@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+)
+
+// ImportDraft es una tarea candidata a importarse desde un archivo externo
+// (ICS, CSV, JSON). Line identifica el origen para reportar errores.
+type ImportDraft struct {
+	Line int
+	Task Task
+	Err  error
+}
+
+// ImportRowError error de validación de un ítem importado
+type ImportRowError struct {
+	Line       int    `json:"line"`
+	ExternalID string `json:"externalId,omitempty"`
+	Message    string `json:"message"`
+}
+
+// ImportResult resumen de una importación (o de su simulación en dry-run)
+type ImportResult struct {
+	DryRun    bool             `json:"dryRun"`
+	Created   []Task           `json:"created"`
+	Updated   []Task           `json:"updated"`
+	Unchanged int              `json:"unchanged"` // Ya existían sin cambios, o completadas/canceladas
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// typeKeywords palabras clave (es/en) para inferir el tipo de tarea.
+// El orden importa: se evalúan de más a menos específico.
+var typeKeywords = []struct {
+	Type     string
+	Keywords []string
+}{
+	{TypeExam, []string{"parcial", "examen", "exam", "midterm", "final", "certamen"}},
+	{TypeQuiz, []string{"quiz", "quices", "prueba corta", "comprobación"}},
+	{TypeLab, []string{"laboratorio", "lab"}},
+	{TypePresentation, []string{"presentación", "presentacion", "exposición", "exposicion", "presentation", "pitch"}},
+	{TypeEssay, []string{"ensayo", "essay", "informe", "report"}},
+	{TypeReading, []string{"lectura", "leer", "reading", "capítulo", "capitulo", "chapter"}},
+	{TypeGroupWork, []string{"grupal", "en grupo", "group"}},
+}
+
+// InferTaskType deduce el tipo de tarea a partir de un texto libre.
+// Retorna TypeAssignment si ninguna palabra clave coincide.
+func InferTaskType(text string) string {
+	words := strings.Fields(strings.ToLower(text))
+	joined := " " + strings.Join(words, " ") + " "
+	for _, tk := range typeKeywords {
+		for _, kw := range tk.Keywords {
+			if strings.Contains(joined, " "+kw+" ") || hasWordPrefix(words, kw) {
+				return tk.Type
+			}
+		}
+	}
+	return TypeAssignment
+}
+
+// hasWordPrefix detecta variantes como "lab2", "parcial#1" o "quiz:"
+func hasWordPrefix(words []string, kw string) bool {
+	if strings.Contains(kw, " ") {
+		return false
+	}
+	for _, w := range words {
+		if strings.HasPrefix(w, kw) && len(w) > len(kw) && !isLetter(w[len(kw)]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || b >= 0x80
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestInferTaskType(t *testing.T) {
+	tests := map[string]string{
+		"Primer Parcial de Cálculo": TypeExam,
+		"Midterm exam":              TypeExam,
+		"Quiz 3":                    TypeQuiz,
+		"Lab2: osciloscopio":        TypeLab,
+		"Laboratorio de redes":      TypeLab,
+		"Lectura capítulo 4":        TypeReading,
+		"Exposición final grupal":   TypeExam, // "final" es más específico que presentación
+		"Tarea programada 1":        TypeAssignment,
+		"Syllabus review":           TypeAssignment,
+	}
+
+	for text, want := range tests {
+		if got := InferTaskType(text); got != want {
+			t.Errorf("InferTaskType(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
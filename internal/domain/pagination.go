@@ -0,0 +1,395 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Valores por defecto de paginación
+const (
+	DefaultPageLimit = 20
+)
+
+// SortField campo de ordenamiento de un listado
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// SortValue valor de un campo de orden en una tarea: numérico (fechas en
+// milisegundos, score) o texto. Es lo que se guarda en los cursores.
+type SortValue struct {
+	Num float64 `json:"n,omitempty"`
+	Str string  `json:"s,omitempty"`
+}
+
+// Compare compara dos valores del mismo campo
+func (v SortValue) Compare(o SortValue) int {
+	switch {
+	case v.Num < o.Num:
+		return -1
+	case v.Num > o.Num:
+		return 1
+	}
+	return strings.Compare(v.Str, o.Str)
+}
+
+// SortableFields campos aceptados en sortBy / sort
+var SortableFields = []string{"dueDate", "priority", "status", "createdAt", "updatedAt", "completedAt", "title", "score"}
+
+// MaxSortFields máximo de campos en un orden compuesto
+const MaxSortFields = 4
+
+// ParseSort interpreta "priority:desc,dueDate:asc" (la dirección por
+// defecto es asc)
+func ParseSort(spec string) ([]SortField, error) {
+	var fields []SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, dir, _ := strings.Cut(part, ":")
+		if !IsSortableField(name) {
+			return nil, fmt.Errorf("campo de orden inválido: %s", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("campo de orden repetido: %s", name)
+		}
+		seen[name] = true
+		switch strings.ToLower(dir) {
+		case "", "asc":
+			fields = append(fields, SortField{Field: name})
+		case "desc":
+			fields = append(fields, SortField{Field: name, Desc: true})
+		default:
+			return nil, fmt.Errorf("dirección de orden inválida: %s", dir)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("orden vacío")
+	}
+	if len(fields) > MaxSortFields {
+		return nil, fmt.Errorf("máximo %d campos de orden", MaxSortFields)
+	}
+	return fields, nil
+}
+
+// IsSortableField indica si el campo se puede usar para ordenar
+func IsSortableField(field string) bool {
+	for _, f := range SortableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// SortFields orden efectivo del filtro: Sort si viene, si no
+// SortBy/SortOrder (dueDate asc por defecto)
+func (f *TaskFilter) SortFields() []SortField {
+	if len(f.Sort) > 0 {
+		return f.Sort
+	}
+	field := f.SortBy
+	if field == "" {
+		field = "dueDate"
+	}
+	return []SortField{{Field: field, Desc: f.SortOrder == "desc"}}
+}
+
+// SortsByScore indica si el orden incluye el score (calculado en memoria)
+func (f *TaskFilter) SortsByScore() bool {
+	for _, sf := range f.SortFields() {
+		if sf.Field == "score" {
+			return true
+		}
+	}
+	return false
+}
+
+// SortRelevance orden por relevancia; solo aplica a búsquedas de texto
+const SortRelevance = "relevance"
+
+// SortsByRelevance indica si los resultados de una búsqueda se ordenan por
+// relevancia: es el orden por defecto cuando hay Search y no se pidió otro
+func (f *TaskFilter) SortsByRelevance() bool {
+	return f.Search != "" && len(f.Sort) == 0 && (f.SortBy == "" || f.SortBy == SortRelevance)
+}
+
+// PriorityRank orden semántico de la prioridad (low=1 … urgent=4, 0 si es desconocida)
+func PriorityRank(p string) int {
+	return rankOf(ValidPriorities, p)
+}
+
+// StatusRank orden semántico del estado (todo=1 … cancelled=5, 0 si es desconocido)
+func StatusRank(s string) int {
+	return rankOf(ValidStatuses, s)
+}
+
+func rankOf(values []string, v string) int {
+	for i, x := range values {
+		if x == v {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// IsCursorMode indica si el filtro pide paginación por cursor
+func (f *TaskFilter) IsCursorMode() bool {
+	return f.After != "" || f.Before != ""
+}
+
+// SortValueOf valor de la tarea para un campo de orden. Prioridad y estado
+// usan su rango semántico; completedAt sin valor cuenta como época 0 (primero
+// en orden asc), igual que en Mongo.
+func SortValueOf(t *Task, field string, now time.Time) SortValue {
+	switch field {
+	case "priority":
+		return SortValue{Num: float64(PriorityRank(t.Priority))}
+	case "status":
+		return SortValue{Num: float64(StatusRank(t.Status))}
+	case "createdAt":
+		return SortValue{Num: float64(t.CreatedAt.UnixMilli())}
+	case "updatedAt":
+		return SortValue{Num: float64(t.UpdatedAt.UnixMilli())}
+	case "completedAt":
+		if t.CompletedAt == nil {
+			return SortValue{}
+		}
+		return SortValue{Num: float64(t.CompletedAt.UnixMilli())}
+	case "title":
+		return SortValue{Str: t.Title}
+	case "score":
+		return SortValue{Num: ComputeScore(t, now).Score}
+	default:
+		return SortValue{Num: float64(t.DueDate.UnixMilli())}
+	}
+}
+
+// Cursor posición dentro de un listado ordenado: valores de la clave de
+// orden de la última (o primera) tarea vista más su ID como desempate
+type Cursor struct {
+	Sort   string      `json:"o"` // firma del orden, ej. "dueDate:asc"
+	Values []SortValue `json:"v"`
+	ID     string      `json:"id"`
+	At     int64       `json:"t,omitempty"` // instante (ms) del score; solo en órdenes por score
+}
+
+// SortSignature identifica un orden para validar que el cursor le corresponde
+func SortSignature(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		dir := "asc"
+		if f.Desc {
+			dir = "desc"
+		}
+		parts[i] = f.Field + ":" + dir
+	}
+	return strings.Join(parts, ",")
+}
+
+// NewCursor cursor que apunta a la tarea t
+func NewCursor(t *Task, fields []SortField, now time.Time) Cursor {
+	c := Cursor{Sort: SortSignature(fields), ID: t.ID, Values: make([]SortValue, len(fields))}
+	for i, f := range fields {
+		c.Values[i] = SortValueOf(t, f.Field, now)
+		if f.Field == "score" {
+			c.At = now.UnixMilli()
+		}
+	}
+	return c
+}
+
+// ScoreTime instante con el que se calcula el score de una página: el del
+// cursor si lo trae (así las páginas siguientes no se desplazan con el
+// reloj) o now truncado a milisegundos, la precisión que guarda el cursor
+func ScoreTime(cur *Cursor, now time.Time) time.Time {
+	if cur != nil && cur.At != 0 {
+		return time.UnixMilli(cur.At)
+	}
+	return now.Truncate(time.Millisecond)
+}
+
+// Encode serializa el cursor como token opaco (base64url)
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor valida un token para el orden indicado
+func DecodeCursor(token string, fields []SortField) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != SortSignature(fields) || len(c.Values) != len(fields) || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CompareSortKeys compara dos posiciones (valores + ID) según el orden.
+// El ID desempata en la dirección del último campo.
+func CompareSortKeys(a []SortValue, aID string, b []SortValue, bID string, fields []SortField) int {
+	for i, f := range fields {
+		if c := a[i].Compare(b[i]); c != 0 {
+			if f.Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	c := strings.Compare(aID, bID)
+	if len(fields) > 0 && fields[len(fields)-1].Desc {
+		return -c
+	}
+	return c
+}
+
+// PaginateInMemory ordena y pagina tareas ya filtradas (repositorio en
+// memoria, órdenes que incluyen score). Soporta page/limit y cursores after/before.
+func PaginateInMemory(tasks []Task, f TaskFilter, now time.Time) ([]Task, PageInfo, error) {
+	fields := f.SortFields()
+	limit := pageLimit(f.Limit)
+
+	var cur *Cursor
+	before := f.Before != ""
+	if f.IsCursorMode() {
+		token := f.After
+		if before {
+			token = f.Before
+		}
+		var err error
+		if cur, err = DecodeCursor(token, fields); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+	if f.SortsByScore() {
+		now = ScoreTime(cur, now)
+	}
+
+	keys := make([][]SortValue, len(tasks))
+	idx := make([]int, len(tasks))
+	for i := range tasks {
+		idx[i] = i
+		keys[i] = make([]SortValue, len(fields))
+		for j, field := range fields {
+			keys[i][j] = SortValueOf(&tasks[i], field.Field, now)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ia, ib := idx[a], idx[b]
+		return CompareSortKeys(keys[ia], tasks[ia].ID, keys[ib], tasks[ib].ID, fields) < 0
+	})
+
+	var rows []Task
+	switch {
+	case cur != nil:
+		if before {
+			// Las limit+1 anteriores al cursor, en orden inverso (como las leería la BD)
+			for k := len(idx) - 1; k >= 0 && len(rows) <= limit; k-- {
+				i := idx[k]
+				if CompareSortKeys(keys[i], tasks[i].ID, cur.Values, cur.ID, fields) < 0 {
+					rows = append(rows, tasks[i])
+				}
+			}
+		} else {
+			for _, i := range idx {
+				if len(rows) > limit {
+					break
+				}
+				if CompareSortKeys(keys[i], tasks[i].ID, cur.Values, cur.ID, fields) > 0 {
+					rows = append(rows, tasks[i])
+				}
+			}
+		}
+	default:
+		start := (pageNumber(f.Page) - 1) * limit
+		for k := start; k < len(idx) && k <= start+limit; k++ {
+			rows = append(rows, tasks[idx[k]])
+		}
+	}
+
+	total := int64(-1)
+	if !f.SkipCount {
+		total = int64(len(tasks))
+	}
+	page, info := BuildPage(rows, f, total, now)
+	return page, info, nil
+}
+
+// BuildPage arma la página a partir de hasta limit+1 filas leídas en la
+// dirección del listado (en orden inverso si se pidió before).
+// total -1 indica que el conteo se omitió.
+func BuildPage(rows []Task, f TaskFilter, total int64, now time.Time) ([]Task, PageInfo) {
+	fields := f.SortFields()
+	limit := pageLimit(f.Limit)
+	before := f.Before != ""
+
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if before {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if rows == nil {
+		rows = []Task{}
+	}
+
+	info := PageInfo{Total: total, Limit: limit, TotalPages: -1}
+	if total >= 0 {
+		info.TotalPages = (total + int64(limit) - 1) / int64(limit)
+	}
+
+	switch {
+	case before:
+		info.HasNext = true
+		info.HasPrev = more
+	case f.After != "":
+		info.HasNext = more
+		info.HasPrev = true
+	default:
+		info.Page = pageNumber(f.Page)
+		info.HasNext = more
+		info.HasPrev = info.Page > 1
+	}
+
+	if len(rows) > 0 {
+		if info.HasNext {
+			info.NextCursor = NewCursor(&rows[len(rows)-1], fields, now).Encode()
+		}
+		if info.HasPrev {
+			info.PrevCursor = NewCursor(&rows[0], fields, now).Encode()
+		}
+	}
+
+	return rows, info
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	}
+	return limit
+}
+
+func pageNumber(page int) int {
+	if page <= 0 {
+		return 1
+	}
+	return page
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func paginationTasks(n int, base time.Time) []Task {
+	tasks := make([]Task, n)
+	for i := range tasks {
+		// Fechas repetidas de a pares para ejercitar el desempate por ID
+		tasks[i] = Task{
+			ID:        fmt.Sprintf("t-%02d", i),
+			Priority:  []string{PriorityLow, PriorityMedium, PriorityHigh}[i%3],
+			Status:    StatusTodo,
+			DueDate:   base.Add(time.Duration(i/2) * time.Hour),
+			CreatedAt: base,
+		}
+	}
+	return tasks
+}
+
+func TestPaginateInMemoryCursorWalk(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	tasks := paginationTasks(11, now)
+
+	for _, sortBy := range SortableFields {
+		for _, order := range []string{"asc", "desc"} {
+			f := TaskFilter{SortBy: sortBy, SortOrder: order, Limit: 4}
+			var forward []string
+			var last PageInfo
+			for {
+				page, info, err := PaginateInMemory(tasks, f, now)
+				if err != nil {
+					t.Fatalf("%s %s: %v", sortBy, order, err)
+				}
+				for _, task := range page {
+					forward = append(forward, task.ID)
+				}
+				last = info
+				if !info.HasNext {
+					break
+				}
+				f.After, f.Page = info.NextCursor, 0
+			}
+			if len(forward) != len(tasks) {
+				t.Fatalf("%s %s: walked %d tasks, want %d", sortBy, order, len(forward), len(tasks))
+			}
+			seen := map[string]bool{}
+			for _, id := range forward {
+				if seen[id] {
+					t.Fatalf("%s %s: duplicate %s", sortBy, order, id)
+				}
+				seen[id] = true
+			}
+
+			// Retroceder con before desde la última página reproduce el mismo orden
+			var backward []string
+			f = TaskFilter{SortBy: sortBy, SortOrder: order, Limit: 4, Before: last.PrevCursor}
+			backward = append(backward, forward[len(forward)-(len(tasks)%4):]...)
+			for f.Before != "" {
+				page, info, err := PaginateInMemory(tasks, f, now)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids := make([]string, len(page))
+				for i, task := range page {
+					ids[i] = task.ID
+				}
+				backward = append(ids, backward...)
+				f.Before = info.PrevCursor
+			}
+			if fmt.Sprint(backward) != fmt.Sprint(forward) {
+				t.Errorf("%s %s: backward %v != forward %v", sortBy, order, backward, forward)
+			}
+		}
+	}
+}
+
+func TestPaginateInMemoryStableUnderInserts(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	tasks := paginationTasks(6, now)
+
+	page, info, _ := PaginateInMemory(tasks, TaskFilter{Limit: 3}, now)
+	if len(page) != 3 || !info.HasNext || info.Total != 6 {
+		t.Fatalf("unexpected first page %d %+v", len(page), info)
+	}
+
+	// Una tarea nueva antes del cursor no desplaza la página siguiente
+	tasks = append(tasks, Task{ID: "t-new", DueDate: now.Add(-time.Hour)})
+	next, _, _ := PaginateInMemory(tasks, TaskFilter{Limit: 3, After: info.NextCursor, SkipCount: true}, now)
+	if len(next) != 3 || next[0].ID != "t-03" {
+		t.Fatalf("expected page to resume at t-03, got %+v", next)
+	}
+}
+
+// El cursor de un orden por score fija el instante: la página siguiente
+// sigue a la primera aunque el reloj cambie el score de las tareas
+func TestPaginateInMemoryScorePinsNow(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	tasks := paginationTasks(8, now)
+	for i := range tasks {
+		// Las más lejanas valen más por prioridad: al vencer todas, el orden se invierte
+		tasks[i].DueDate = now.Add(time.Duration(i*30) * time.Hour)
+		tasks[i].Priority = ValidPriorities[i/2]
+	}
+	f := TaskFilter{SortBy: "score", SortOrder: "desc", Limit: 3}
+
+	first, info, err := PaginateInMemory(tasks, f, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, _, _ := PaginateInMemory(tasks, TaskFilter{SortBy: "score", SortOrder: "desc", Limit: 8}, now)
+
+	f.After = info.NextCursor
+	next, _, err := PaginateInMemory(tasks, f, now.Add(30*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next) != 3 {
+		t.Fatalf("expected a full second page, got %d tasks", len(next))
+	}
+	for i, task := range append(first, next...) {
+		if task.ID != all[i].ID {
+			t.Fatalf("position %d: got %s, want %s", i, task.ID, all[i].ID)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsOtherSort(t *testing.T) {
+	now := time.Now()
+	task := &Task{ID: "t-1", DueDate: now}
+	token := NewCursor(task, []SortField{{Field: "dueDate"}}, now).Encode()
+
+	if _, err := DecodeCursor(token, []SortField{{Field: "dueDate", Desc: true}}); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor for different order, got %v", err)
+	}
+	if _, err := DecodeCursor("%%%", []SortField{{Field: "dueDate"}}); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor for garbage, got %v", err)
+	}
+	if _, err := DecodeCursor(token, []SortField{{Field: "dueDate"}}); err != nil {
+		t.Errorf("valid cursor rejected: %v", err)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	fields, err := ParseSort("priority:desc, dueDate")
+	if err != nil || len(fields) != 2 || fields[0] != (SortField{Field: "priority", Desc: true}) || fields[1] != (SortField{Field: "dueDate"}) {
+		t.Fatalf("unexpected fields %+v (%v)", fields, err)
+	}
+	for _, bad := range []string{"", "owner:asc", "dueDate:up", "title,title", "dueDate,title,status,priority,createdAt"} {
+		if _, err := ParseSort(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestPaginateInMemoryMultiSortSemanticRanks(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	done := now.Add(-time.Hour)
+	tasks := []Task{
+		{ID: "a", Priority: PriorityLow, Status: StatusDone, DueDate: now, CompletedAt: &done},
+		{ID: "b", Priority: PriorityUrgent, Status: StatusTodo, DueDate: now.Add(2 * time.Hour)},
+		{ID: "c", Priority: PriorityMedium, Status: StatusInProgress, DueDate: now},
+		{ID: "d", Priority: PriorityUrgent, Status: StatusInReview, DueDate: now.Add(time.Hour)},
+		{ID: "e", Priority: PriorityHigh, Status: StatusTodo, DueDate: now},
+	}
+
+	order := func(spec string) string {
+		fields, err := ParseSort(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page, _, err := PaginateInMemory(tasks, TaskFilter{Sort: fields}, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := ""
+		for _, task := range page {
+			ids += task.ID
+		}
+		return ids
+	}
+
+	// Alfabéticamente "high" < "low" < "medium" < "urgent"; el rango no
+	if got := order("priority:desc,dueDate:asc"); got != "dbeca" {
+		t.Errorf("priority desc, dueDate asc: got %s", got)
+	}
+	if got := order("status,dueDate:desc"); got != "becda" {
+		t.Errorf("status asc, dueDate desc: got %s", got)
+	}
+	// Sin completedAt va primero en orden ascendente
+	if got := order("completedAt:desc"); got[0] != 'a' {
+		t.Errorf("completed task should come first in desc order, got %s", got)
+	}
+}
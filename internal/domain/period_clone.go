@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxPeriodCloneTasks tareas que se pueden clonar en una sola operación
+const MaxPeriodCloneTasks = 500
+
+// Acciones de PeriodCloneChange
+const (
+	CloneActionCreate = "create"
+	CloneActionSkip   = "skip"
+)
+
+// PeriodCloneOptions parámetros para clonar las tareas de un período
+type PeriodCloneOptions struct {
+	FromPeriodID string
+	ToPeriodID   string
+	SubjectIDs   []string  // Vacío = todas las materias
+	FromStart    time.Time // Inicio del período origen (cero = semana de la primera tarea)
+	ToStart      time.Time // Inicio del período destino
+	WeekStart    time.Weekday
+	Location     *time.Location
+}
+
+// PeriodClonePlan diferencia entre el período origen y lo que se creará en
+// el destino. Las fechas se mueven ShiftDays días (semanas completas), así
+// cada tarea cae en la misma semana del período y el mismo día y hora local.
+type PeriodClonePlan struct {
+	FromPeriodID string              `json:"fromPeriodId"`
+	ToPeriodID   string              `json:"toPeriodId"`
+	FromStart    time.Time           `json:"fromStart"` // Inicio de la primera semana del origen
+	ToStart      time.Time           `json:"toStart"`   // Inicio de la primera semana del destino
+	ShiftDays    int                 `json:"shiftDays"`
+	CreateCount  int                 `json:"createCount"`
+	SkipCount    int                 `json:"skipCount"`
+	Changes      []PeriodCloneChange `json:"changes"`
+}
+
+// PeriodCloneChange una tarea del período origen y su copia en el destino
+type PeriodCloneChange struct {
+	Action      string    `json:"action"` // create / skip
+	Reason      string    `json:"reason,omitempty"`
+	SourceID    string    `json:"sourceId"`
+	TaskID      string    `json:"taskId,omitempty"` // Tarea creada (solo al confirmar)
+	Title       string    `json:"title"`
+	SubjectID   string    `json:"subjectId"`
+	Week        int       `json:"week"` // Semana del período (1 = la primera)
+	FromDueDate time.Time `json:"fromDueDate"`
+	ToDueDate   time.Time `json:"toDueDate"`
+	FromStatus  string    `json:"fromStatus"`
+	Task        *Task     `json:"-"` // Copia a crear (nil si se omite)
+}
+
+// BuildPeriodClonePlan arma el plan para clonar source en el período
+// destino. Se omiten las tareas canceladas y las que ya existen en el
+// destino (misma materia y título), así repetir la operación no duplica.
+func BuildPeriodClonePlan(source, existing []Task, opts PeriodCloneOptions, userID string, now time.Time) PeriodClonePlan {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	subjects := make(map[string]bool, len(opts.SubjectIDs))
+	for _, s := range opts.SubjectIDs {
+		subjects[s] = true
+	}
+	tasks := make([]Task, 0, len(source))
+	for _, t := range source {
+		if len(subjects) == 0 || subjects[t.SubjectID] {
+			tasks = append(tasks, t)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(tasks[j].DueDate) })
+
+	fromStart := opts.FromStart
+	if fromStart.IsZero() && len(tasks) > 0 {
+		fromStart = tasks[0].DueDate
+	}
+	fromWeek := StartOfWeek(fromStart, loc, opts.WeekStart)
+	toWeek := StartOfWeek(opts.ToStart, loc, opts.WeekStart)
+	shift := calendarDays(fromWeek, toWeek)
+
+	taken := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		taken[cloneKey(&t)] = true
+	}
+
+	plan := PeriodClonePlan{
+		FromPeriodID: opts.FromPeriodID,
+		ToPeriodID:   opts.ToPeriodID,
+		FromStart:    fromWeek,
+		ToStart:      toWeek,
+		ShiftDays:    shift,
+		Changes:      make([]PeriodCloneChange, 0, len(tasks)),
+	}
+	for i := range tasks {
+		src := &tasks[i]
+		due := src.DueDate.In(loc)
+		change := PeriodCloneChange{
+			Action:      CloneActionCreate,
+			SourceID:    src.ID,
+			Title:       src.Title,
+			SubjectID:   src.SubjectID,
+			Week:        calendarDays(fromWeek, StartOfWeek(due, loc, opts.WeekStart))/7 + 1,
+			FromDueDate: src.DueDate,
+			ToDueDate:   due.AddDate(0, 0, shift),
+			FromStatus:  src.Status,
+		}
+
+		switch key := cloneKey(src); {
+		case src.IsCancelled():
+			change.Action, change.Reason = CloneActionSkip, "tarea cancelada"
+		case taken[key]:
+			change.Action, change.Reason = CloneActionSkip, "ya existe en el período destino"
+		default:
+			taken[key] = true
+			change.Task = CloneTaskToPeriod(src, userID, opts.ToPeriodID, change.ToDueDate, now)
+		}
+
+		if change.Action == CloneActionCreate {
+			plan.CreateCount++
+		} else {
+			plan.SkipCount++
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	return plan
+}
+
+// CloneTaskToPeriod copia la estructura de src (título, materia, tipo,
+// prioridad, estimación, peso, etiquetas) como tarea nueva en estado todo,
+// sin tiempo real, fecha de completado, adjuntos, integrantes ni datos de
+// origen o de tarea compartida
+func CloneTaskToPeriod(src *Task, userID, periodID string, due, now time.Time) *Task {
+	return &Task{
+		UserID:             userID,
+		Title:              src.Title,
+		Description:        src.Description,
+		SubjectID:          src.SubjectID,
+		PeriodID:           periodID,
+		DueDate:            due,
+		Status:             StatusTodo,
+		Priority:           src.Priority,
+		Type:               src.Type,
+		EstimatedTimeHours: src.EstimatedTimeHours,
+		GradeWeight:        src.GradeWeight,
+		Tags:               append([]string{}, src.Tags...),
+		IsGroupWork:        src.IsGroupWork,
+		GroupMembers:       []string{},
+		Attachments:        []string{},
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// cloneKey identifica una tarea dentro de un período (materia + título)
+func cloneKey(t *Task) string {
+	return t.SubjectID + "\x00" + strings.ToLower(strings.TrimSpace(t.Title))
+}
+
+// calendarDays días de calendario entre las fechas locales de a y b
+func calendarDays(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildPeriodClonePlan(t *testing.T) {
+	cr, _ := time.LoadLocation("America/Costa_Rica")
+	actual := 5
+	done := time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC)
+	source := []Task{
+		// Jueves de la semana 3 a las 23:59 locales
+		{ID: "t2", Title: "Tarea 2", SubjectID: "calc", PeriodID: "2025-1", DueDate: time.Date(2025, 2, 21, 5, 59, 0, 0, time.UTC),
+			Status: StatusDone, Priority: PriorityHigh, Type: TypeAssignment, ActualTimeHours: &actual, CompletedAt: &done,
+			Attachments: []string{"att-1"}, GroupMembers: []string{"ana"}, ExternalID: "ics-1", Tags: []string{"semanal"}},
+		// Lunes de la semana 1
+		{ID: "t1", Title: "Tarea 1", SubjectID: "calc", PeriodID: "2025-1", DueDate: time.Date(2025, 2, 3, 15, 0, 0, 0, time.UTC),
+			Status: StatusTodo, Priority: PriorityMedium, Type: TypeAssignment},
+		{ID: "t3", Title: "Cancelada", SubjectID: "calc", PeriodID: "2025-1", DueDate: time.Date(2025, 2, 10, 15, 0, 0, 0, time.UTC),
+			Status: StatusCancelled, Priority: PriorityMedium, Type: TypeAssignment},
+		{ID: "t4", Title: "Ensayo", SubjectID: "hist", PeriodID: "2025-1", DueDate: time.Date(2025, 2, 12, 15, 0, 0, 0, time.UTC),
+			Status: StatusTodo, Priority: PriorityMedium, Type: TypeEssay},
+	}
+	existing := []Task{{ID: "x", Title: "tarea 1 ", SubjectID: "calc", PeriodID: "2025-2"}}
+
+	plan := BuildPeriodClonePlan(source, existing, PeriodCloneOptions{
+		FromPeriodID: "2025-1",
+		ToPeriodID:   "2025-2",
+		SubjectIDs:   []string{"calc"},
+		ToStart:      time.Date(2025, 8, 6, 12, 0, 0, 0, cr), // miércoles: la semana empieza el lunes 4
+		WeekStart:    time.Monday,
+		Location:     cr,
+	}, "u1", time.Now())
+
+	if plan.ShiftDays != 182 || plan.CreateCount != 1 || plan.SkipCount != 2 || len(plan.Changes) != 3 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	if first := plan.Changes[0]; first.SourceID != "t1" || first.Action != CloneActionSkip || first.Week != 1 {
+		t.Errorf("expected t1 skipped as existing, got %+v", first)
+	}
+	if cancelled := plan.Changes[1]; cancelled.SourceID != "t3" || cancelled.Action != CloneActionSkip || cancelled.Week != 2 {
+		t.Errorf("expected t3 skipped as cancelled, got %+v", cancelled)
+	}
+
+	change := plan.Changes[2]
+	if change.SourceID != "t2" || change.Action != CloneActionCreate || change.Week != 3 || change.FromStatus != StatusDone {
+		t.Fatalf("unexpected change %+v", change)
+	}
+	local := change.ToDueDate.In(cr)
+	if local.Weekday() != time.Thursday || local.Day() != 21 || local.Month() != time.August || local.Hour() != 23 || local.Minute() != 59 {
+		t.Errorf("expected Thursday 21 August 23:59 local, got %v", local)
+	}
+	task := change.Task
+	if task.Status != StatusTodo || task.ActualTimeHours != nil || task.CompletedAt != nil || len(task.Attachments) != 0 ||
+		len(task.GroupMembers) != 0 || task.ExternalID != "" || task.PeriodID != "2025-2" || task.UserID != "u1" ||
+		task.Priority != PriorityHigh || len(task.Tags) != 1 || !task.DueDate.Equal(change.ToDueDate) {
+		t.Errorf("unexpected cloned task %+v", task)
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Valores por defecto y límites de UserPreferences
+const (
+	DefaultLocale             = "es"
+	DefaultWeekStart          = "monday"
+	DefaultDailyCapacityHours = 4
+	DefaultUpcomingLimit      = 5
+
+	MaxDashboardLimit      = 50
+	MaxReminderOffsets     = 5
+	MaxReminderOffsetHours = 30 * 24
+)
+
+// DefaultReminderOffsetsHours recordatorio por defecto: 3 días antes
+var DefaultReminderOffsetsHours = []int{72}
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// UserPreferences preferencias del usuario. Se crean con los valores por
+// defecto la primera vez que se consultan.
+type UserPreferences struct {
+	UserID               string          `bson:"_id" json:"-"`
+	TimeZone             string          `bson:"timeZone" json:"timeZone"`                         // IANA, ej. America/Costa_Rica; define "hoy", "esta semana" y la hora de los recordatorios
+	Locale               string          `bson:"locale" json:"locale"`                             // BCP 47: es, es-CR, en-US
+	ReminderOffsetsHours []int           `bson:"reminderOffsetsHours" json:"reminderOffsetsHours"` // Horas antes del vencimiento; múltiplos de 24 = días calendario a la misma hora local. [] = sin recordatorios
+	DailyCapacityHours   int             `bson:"dailyCapacityHours" json:"dailyCapacityHours"`     // Horas de estudio por día (agenda)
+	WeekStart            string          `bson:"weekStart" json:"weekStart"`                       // monday..sunday
+	Dashboard            DashboardLimits `bson:"dashboard" json:"dashboard"`
+	CreatedAt            time.Time       `bson:"createdAt" json:"createdAt"`
+	UpdatedAt            time.Time       `bson:"updatedAt" json:"updatedAt"`
+}
+
+// DashboardLimits cantidad de tareas de cada lista del dashboard
+type DashboardLimits struct {
+	Upcoming int `bson:"upcoming" json:"upcoming"` // Próximas (1-50)
+	Today    int `bson:"today" json:"today"`       // De hoy (0 = todas)
+}
+
+// DefaultPreferences preferencias de un usuario nuevo
+func DefaultPreferences(userID, timeZone string) *UserPreferences {
+	p := &UserPreferences{UserID: userID, TimeZone: timeZone}
+	p.ApplyDefaults()
+	return p
+}
+
+// ApplyDefaults completa los campos sin valor (documentos guardados antes
+// de que existieran)
+func (p *UserPreferences) ApplyDefaults() {
+	if p.TimeZone == "" {
+		p.TimeZone = "UTC"
+	}
+	if p.Locale == "" {
+		p.Locale = DefaultLocale
+	}
+	if p.ReminderOffsetsHours == nil {
+		p.ReminderOffsetsHours = append([]int(nil), DefaultReminderOffsetsHours...)
+	}
+	if p.DailyCapacityHours == 0 {
+		p.DailyCapacityHours = DefaultDailyCapacityHours
+	}
+	if p.WeekStart == "" {
+		p.WeekStart = DefaultWeekStart
+	}
+	if p.Dashboard.Upcoming == 0 {
+		p.Dashboard.Upcoming = DefaultUpcomingLimit
+	}
+}
+
+// Validate comprueba rangos y formatos
+func (p *UserPreferences) Validate() error {
+	if _, err := LoadTimeZone(p.TimeZone); err != nil {
+		return err
+	}
+	if !localePattern.MatchString(p.Locale) {
+		return invalidPreferences("locale inválido %q (usar BCP 47, ej. es-CR)", p.Locale)
+	}
+	if len(p.ReminderOffsetsHours) > MaxReminderOffsets {
+		return invalidPreferences("máximo %d recordatorios", MaxReminderOffsets)
+	}
+	seen := make(map[int]bool, len(p.ReminderOffsetsHours))
+	for _, h := range p.ReminderOffsetsHours {
+		if h < 0 || h > MaxReminderOffsetHours {
+			return invalidPreferences("reminderOffsetsHours debe estar entre 0 y %d", MaxReminderOffsetHours)
+		}
+		if seen[h] {
+			return invalidPreferences("reminderOffsetsHours repetido: %d", h)
+		}
+		seen[h] = true
+	}
+	if p.DailyCapacityHours < 1 || p.DailyCapacityHours > 24 {
+		return invalidPreferences("dailyCapacityHours debe estar entre 1 y 24")
+	}
+	if _, ok := ParseWeekday(p.WeekStart); !ok {
+		return invalidPreferences("weekStart inválido %q (monday..sunday)", p.WeekStart)
+	}
+	if p.Dashboard.Upcoming < 1 || p.Dashboard.Upcoming > MaxDashboardLimit {
+		return invalidPreferences("dashboard.upcoming debe estar entre 1 y %d", MaxDashboardLimit)
+	}
+	if p.Dashboard.Today < 0 || p.Dashboard.Today > MaxDashboardLimit {
+		return invalidPreferences("dashboard.today debe estar entre 0 y %d", MaxDashboardLimit)
+	}
+	return nil
+}
+
+func invalidPreferences(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidPreferences, fmt.Sprintf(format, args...))
+}
+
+// DashboardOptions opciones del dashboard en la zona loc
+func (p *UserPreferences) DashboardOptions(loc *time.Location) DashboardOptions {
+	return DashboardOptions{
+		Location:      loc,
+		WeekStart:     p.WeekStart,
+		UpcomingLimit: p.Dashboard.Upcoming,
+		TodayLimit:    p.Dashboard.Today,
+	}
+}
+
+// Reminder recordatorio programado de una tarea
+type Reminder struct {
+	At          time.Time
+	OffsetHours int // Horas antes del vencimiento configuradas
+}
+
+// ReminderSchedule recordatorios de una tarea que vence en due, por fecha.
+// Los offsets en días enteros se cuentan en días calendario de loc, así un
+// cambio de horario no mueve la hora local del aviso. Los que ya pasaron se
+// reemplazan por uno solo inmediato (el más cercano al vencimiento); si la
+// tarea ya venció no hay recordatorios.
+func (p *UserPreferences) ReminderSchedule(now, due time.Time, loc *time.Location) []Reminder {
+	if !due.After(now) {
+		return nil
+	}
+	offsets := append([]int(nil), p.ReminderOffsetsHours...)
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets))) // primero el más anticipado
+
+	var out []Reminder
+	var late *Reminder
+	for _, h := range offsets {
+		at := due.Add(-time.Duration(h) * time.Hour)
+		if h%24 == 0 {
+			at = ReminderAt(due, loc, h/24)
+		}
+		if at.After(now) {
+			out = append(out, Reminder{At: at, OffsetHours: h})
+			continue
+		}
+		late = &Reminder{At: now, OffsetHours: h}
+	}
+	if late != nil {
+		out = append([]Reminder{*late}, out...)
+	}
+	return out
+}
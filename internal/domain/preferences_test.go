@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPreferencesDefaultsAndValidate(t *testing.T) {
+	p := DefaultPreferences("u1", "America/Costa_Rica")
+	if err := p.Validate(); err != nil {
+		t.Fatalf("defaults should be valid: %v", err)
+	}
+	if p.WeekStart != "monday" || p.DailyCapacityHours != 4 || p.Dashboard.Upcoming != 5 || len(p.ReminderOffsetsHours) != 1 {
+		t.Errorf("unexpected defaults %+v", p)
+	}
+
+	// [] explícito = sin recordatorios; ApplyDefaults no lo pisa
+	p.ReminderOffsetsHours = []int{}
+	p.ApplyDefaults()
+	if len(p.ReminderOffsetsHours) != 0 {
+		t.Errorf("empty reminders should be kept, got %v", p.ReminderOffsetsHours)
+	}
+
+	invalid := map[string]func(*UserPreferences){
+		"locale":    func(p *UserPreferences) { p.Locale = "español" },
+		"offset":    func(p *UserPreferences) { p.ReminderOffsetsHours = []int{-1} },
+		"duplicate": func(p *UserPreferences) { p.ReminderOffsetsHours = []int{24, 24} },
+		"too many":  func(p *UserPreferences) { p.ReminderOffsetsHours = []int{1, 2, 3, 4, 5, 6} },
+		"capacity":  func(p *UserPreferences) { p.DailyCapacityHours = 25 },
+		"weekStart": func(p *UserPreferences) { p.WeekStart = "lunes" },
+		"upcoming":  func(p *UserPreferences) { p.Dashboard.Upcoming = 51 },
+		"today":     func(p *UserPreferences) { p.Dashboard.Today = -1 },
+	}
+	for name, apply := range invalid {
+		p := DefaultPreferences("u1", "UTC")
+		apply(p)
+		if err := p.Validate(); !errors.Is(err, ErrInvalidPreferences) {
+			t.Errorf("%s: expected ErrInvalidPreferences, got %v", name, err)
+		}
+	}
+
+	p = DefaultPreferences("u1", "Mars/Olympus")
+	if err := p.Validate(); !errors.Is(err, ErrInvalidTimeZone) {
+		t.Errorf("expected ErrInvalidTimeZone, got %v", err)
+	}
+}
+
+func TestReminderSchedule(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	due := time.Date(2025, 3, 11, 9, 0, 0, 0, ny) // EDT
+	p := DefaultPreferences("u1", ny.String())
+	p.ReminderOffsetsHours = []int{2, 72, 24}
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, ny)
+	got := p.ReminderSchedule(now, due, ny)
+	want := []time.Time{
+		time.Date(2025, 3, 8, 9, 0, 0, 0, ny), // 3 días calendario: misma hora local pese al cambio de horario
+		time.Date(2025, 3, 10, 9, 0, 0, 0, ny),
+		time.Date(2025, 3, 11, 7, 0, 0, 0, ny),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d reminders, got %+v", len(want), got)
+	}
+	for i := range want {
+		if !got[i].At.Equal(want[i]) {
+			t.Errorf("reminder %d at %s, want %s", i, got[i].At, want[i])
+		}
+	}
+
+	// Los que ya pasaron se juntan en uno inmediato
+	now = time.Date(2025, 3, 10, 12, 0, 0, 0, ny)
+	got = p.ReminderSchedule(now, due, ny)
+	if len(got) != 2 || !got[0].At.Equal(now) || got[0].OffsetHours != 24 || got[1].OffsetHours != 2 {
+		t.Errorf("unexpected schedule %+v", got)
+	}
+
+	if got := p.ReminderSchedule(due, due, ny); len(got) != 0 {
+		t.Errorf("due task should have no reminders, got %+v", got)
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	cr := mustLoad(t, "America/Costa_Rica")
+	wed := time.Date(2025, 3, 12, 10, 0, 0, 0, cr)
+
+	if got := StartOfWeek(wed, cr, time.Monday); !got.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, cr)) {
+		t.Errorf("monday week starts %s", got)
+	}
+	if got := StartOfWeek(wed, cr, time.Sunday); !got.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, cr)) {
+		t.Errorf("sunday week starts %s", got)
+	}
+	if got := StartOfWeek(wed, cr, time.Wednesday); !got.Equal(time.Date(2025, 3, 12, 0, 0, 0, 0, cr)) {
+		t.Errorf("wednesday week starts %s", got)
+	}
+
+	w := DashboardOptions{Location: cr, WeekStart: "sunday", UpcomingLimit: 0}.Windows(wed)
+	if w.UpcomingLimit != DefaultUpcomingLimit || !w.StartOfWeek.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, cr)) {
+		t.Errorf("unexpected windows %+v", w)
+	}
+}
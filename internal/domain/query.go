@@ -0,0 +1,695 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Límites de una expresión de filtro
+const (
+	MaxQueryLength = 500
+	MaxQueryTerms  = 32
+	MaxQueryDepth  = 8
+)
+
+// QueryError error de sintaxis o validación en una expresión de filtro;
+// Pos es el offset (en bytes) del token problemático
+type QueryError struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s (posición %d)", e.Message, e.Pos+1)
+	}
+	return fmt.Sprintf("%s: %q (posición %d)", e.Message, e.Token, e.Pos+1)
+}
+
+// QueryKind tipo de nodo de una expresión de filtro
+type QueryKind int
+
+const (
+	QueryAnd      QueryKind = iota
+	QueryOr                 // alguno de los hijos
+	QueryNot                // niega el único hijo
+	QueryIn                 // Field ∈ Values (en arrays: algún elemento ∈ Values)
+	QueryContains           // Field contiene Text, sin distinguir mayúsculas
+	QueryNumber             // Field <Cmp> Num
+	QueryTime               // From <= Field < To (extremo cero = abierto)
+	QueryBool               // Field == Bool
+)
+
+// QueryNode nodo de una expresión ya validada y resuelta (fechas relativas
+// convertidas a rangos concretos), lista para traducir o evaluar
+type QueryNode struct {
+	Kind     QueryKind
+	Children []*QueryNode
+	Field    string // nombre del campo en bson: status, tags, dueDate, ...
+	Values   []string
+	Text     string
+	Cmp      string // eq, gt, gte, lt, lte
+	Num      float64
+	From     time.Time
+	To       time.Time
+	Bool     bool
+}
+
+// QueryOptions contexto para resolver fechas relativas (today, this-week, ...)
+type QueryOptions struct {
+	Now       time.Time
+	Location  *time.Location
+	WeekStart string // Primer día de this-week (vacío = monday)
+}
+
+type queryFieldKind int
+
+const (
+	fieldEnum queryFieldKind = iota
+	fieldString
+	fieldContains
+	fieldNumber
+	fieldDate
+	fieldFlag
+)
+
+type queryField struct {
+	bson   string
+	kind   queryFieldKind
+	values []string // valores válidos (enum); en orden semántico si ranked
+	ranked bool
+}
+
+// queryFields campos permitidos en la expresión
+var queryFields = map[string]queryField{
+	"status":    {bson: "status", kind: fieldEnum, values: ValidStatuses, ranked: true},
+	"priority":  {bson: "priority", kind: fieldEnum, values: ValidPriorities, ranked: true},
+	"type":      {bson: "type", kind: fieldEnum, values: ValidTypes},
+	"tag":       {bson: "tags", kind: fieldString},
+	"tags":      {bson: "tags", kind: fieldString},
+	"subject":   {bson: "subjectId", kind: fieldString},
+	"period":    {bson: "periodId", kind: fieldString},
+	"title":     {bson: "title", kind: fieldContains},
+	"estimated": {bson: "estimatedTimeHours", kind: fieldNumber},
+	"weight":    {bson: "gradeWeight", kind: fieldNumber},
+	"due":       {bson: "dueDate", kind: fieldDate},
+	"created":   {bson: "createdAt", kind: fieldDate},
+	"completed": {bson: "completedAt", kind: fieldDate},
+	"is":        {kind: fieldFlag, values: []string{"overdue", "blocked", "group"}},
+}
+
+// ParseQuery interpreta una expresión como
+// `tag:parcial AND estimated>4 AND -type:reading` o `due:this-week OR is:overdue`.
+// AND es implícito entre términos; NOT o "-" niegan; se admiten paréntesis y
+// valores entre comillas.
+func ParseQuery(input string, opts QueryOptions) (*QueryNode, error) {
+	if len(input) > MaxQueryLength {
+		return nil, &QueryError{Pos: MaxQueryLength, Message: fmt.Sprintf("la expresión supera %d caracteres", MaxQueryLength)}
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens, end: len(input), opts: opts}
+	if len(tokens) == 0 {
+		return nil, &QueryError{Pos: 0, Message: "expresión vacía"}
+	}
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, &QueryError{Pos: tok.pos, Token: tok.text, Message: "token inesperado"}
+	}
+	return node, nil
+}
+
+type queryTokenKind int
+
+const (
+	tokAtom queryTokenKind = iota
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// lexQuery separa paréntesis, operadores lógicos y términos (campo:valor);
+// las comillas pueden contener espacios y paréntesis
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		default:
+			start := i
+			for i < len(input) {
+				c = input[i]
+				if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')' {
+					break
+				}
+				if c == '"' {
+					closing := strings.IndexByte(input[i+1:], '"')
+					if closing < 0 {
+						return nil, &QueryError{Pos: i, Token: input[i:], Message: "comillas sin cerrar"}
+					}
+					i += closing + 2
+					continue
+				}
+				i++
+			}
+			text := input[start:i]
+			tok := queryToken{kind: tokAtom, text: text, pos: start}
+			switch strings.ToUpper(text) {
+			case "AND", "&&":
+				tok.kind = tokAnd
+			case "OR", "||":
+				tok.kind = tokOr
+			case "NOT", "-":
+				tok.kind = tokNot
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	i      int
+	end    int
+	terms  int
+	opts   QueryOptions
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.i >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.i], true
+}
+
+func (p *queryParser) parseOr(depth int) (*QueryNode, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	children := []*QueryNode{left}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokOr {
+			break
+		}
+		p.i++
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &QueryNode{Kind: QueryOr, Children: children}, nil
+}
+
+func (p *queryParser) parseAnd(depth int) (*QueryNode, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	children := []*QueryNode{left}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokOr || tok.kind == tokRParen {
+			break
+		}
+		if tok.kind == tokAnd {
+			p.i++
+		}
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &QueryNode{Kind: QueryAnd, Children: children}, nil
+}
+
+func (p *queryParser) parseUnary(depth int) (*QueryNode, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, &QueryError{Pos: p.end, Message: "se esperaba un filtro al final de la expresión"}
+	}
+	switch tok.kind {
+	case tokNot:
+		p.i++
+		child, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &QueryNode{Kind: QueryNot, Children: []*QueryNode{child}}, nil
+	case tokLParen:
+		if depth >= MaxQueryDepth {
+			return nil, &QueryError{Pos: tok.pos, Token: tok.text, Message: "demasiados paréntesis anidados"}
+		}
+		p.i++
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokRParen {
+			return nil, &QueryError{Pos: tok.pos, Token: tok.text, Message: "paréntesis sin cerrar"}
+		}
+		p.i++
+		return node, nil
+	case tokAtom:
+		p.i++
+		if strings.HasPrefix(tok.text, "-") {
+			node, err := p.parseTerm(tok.text[1:], tok.pos+1)
+			if err != nil {
+				return nil, err
+			}
+			return &QueryNode{Kind: QueryNot, Children: []*QueryNode{node}}, nil
+		}
+		return p.parseTerm(tok.text, tok.pos)
+	default:
+		return nil, &QueryError{Pos: tok.pos, Token: tok.text, Message: "se esperaba un filtro"}
+	}
+}
+
+// queryOperators en orden de búsqueda (los de dos caracteres primero)
+var queryOperators = []string{">=", "<=", "!=", ":", "=", ">", "<"}
+
+// parseTerm valida y resuelve un término campo<op>valor
+func (p *queryParser) parseTerm(text string, pos int) (*QueryNode, error) {
+	p.terms++
+	if p.terms > MaxQueryTerms {
+		return nil, &QueryError{Pos: pos, Token: text, Message: fmt.Sprintf("máximo %d filtros por expresión", MaxQueryTerms)}
+	}
+
+	n := 0
+	for n < len(text) && (text[n] >= 'a' && text[n] <= 'z' || text[n] >= 'A' && text[n] <= 'Z') {
+		n++
+	}
+	name := strings.ToLower(text[:n])
+	op := ""
+	for _, candidate := range queryOperators {
+		if strings.HasPrefix(text[n:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if name == "" || op == "" {
+		return nil, &QueryError{Pos: pos, Token: text, Message: "se esperaba campo:valor"}
+	}
+	field, ok := queryFields[name]
+	if !ok {
+		return nil, &QueryError{Pos: pos, Token: text[:n], Message: "campo desconocido"}
+	}
+
+	valuePos := pos + n + len(op)
+	raw := text[n+len(op):]
+	value := raw
+	if strings.HasPrefix(raw, `"`) {
+		if len(raw) < 2 || !strings.HasSuffix(raw, `"`) {
+			return nil, &QueryError{Pos: valuePos, Token: raw, Message: "comillas mal formadas"}
+		}
+		value = raw[1 : len(raw)-1]
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, &QueryError{Pos: valuePos, Token: text, Message: "valor vacío"}
+	}
+	if op == "=" {
+		op = ":"
+	}
+
+	term := queryTerm{name: name, field: field, op: op, value: value, raw: raw, pos: pos, valuePos: valuePos}
+	switch field.kind {
+	case fieldEnum:
+		return p.enumNode(term)
+	case fieldString:
+		return p.stringNode(term)
+	case fieldContains:
+		return p.containsNode(term)
+	case fieldNumber:
+		return p.numberNode(term)
+	case fieldDate:
+		return p.dateNode(term)
+	default:
+		return p.flagNode(term)
+	}
+}
+
+type queryTerm struct {
+	name     string
+	field    queryField
+	op       string
+	value    string
+	raw      string
+	pos      int
+	valuePos int
+}
+
+func (t queryTerm) errValue(msg string) error {
+	return &QueryError{Pos: t.valuePos, Token: t.raw, Message: msg}
+}
+
+func (t queryTerm) errOp() error {
+	return &QueryError{Pos: t.valuePos - len(t.op), Token: t.op, Message: fmt.Sprintf("operador no soportado para %s", t.name)}
+}
+
+func negateIf(cond bool, n *QueryNode) *QueryNode {
+	if !cond {
+		return n
+	}
+	return &QueryNode{Kind: QueryNot, Children: []*QueryNode{n}}
+}
+
+// splitValues separa valores por coma (type:exam,quiz)
+func splitValues(v string) []string {
+	parts := strings.Split(v, ",")
+	out := make([]string, 0, len(parts))
+	for _, s := range parts {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (p *queryParser) enumNode(t queryTerm) (*QueryNode, error) {
+	values := splitValues(strings.ToLower(t.value))
+	for _, v := range values {
+		if rankOf(t.field.values, v) == 0 {
+			return nil, t.errValue(fmt.Sprintf("valor inválido para %s (válidos: %s)", t.name, strings.Join(t.field.values, ", ")))
+		}
+	}
+
+	switch t.op {
+	case ":", "!=":
+		return negateIf(t.op == "!=", &QueryNode{Kind: QueryIn, Field: t.field.bson, Values: values}), nil
+	}
+
+	// >, >=, <, <= sobre el orden semántico (priority>=high)
+	if !t.field.ranked {
+		return nil, t.errOp()
+	}
+	if len(values) != 1 {
+		return nil, t.errValue("se esperaba un único valor")
+	}
+	pivot := rankOf(t.field.values, values[0])
+	var selected []string
+	for i, v := range t.field.values {
+		rank := i + 1
+		if (t.op == ">" && rank > pivot) || (t.op == ">=" && rank >= pivot) ||
+			(t.op == "<" && rank < pivot) || (t.op == "<=" && rank <= pivot) {
+			selected = append(selected, v)
+		}
+	}
+	return &QueryNode{Kind: QueryIn, Field: t.field.bson, Values: selected}, nil
+}
+
+func (p *queryParser) stringNode(t queryTerm) (*QueryNode, error) {
+	if t.op != ":" && t.op != "!=" {
+		return nil, t.errOp()
+	}
+	return negateIf(t.op == "!=", &QueryNode{Kind: QueryIn, Field: t.field.bson, Values: splitValues(t.value)}), nil
+}
+
+func (p *queryParser) containsNode(t queryTerm) (*QueryNode, error) {
+	if t.op != ":" && t.op != "!=" {
+		return nil, t.errOp()
+	}
+	return negateIf(t.op == "!=", &QueryNode{Kind: QueryContains, Field: t.field.bson, Text: t.value}), nil
+}
+
+func (p *queryParser) numberNode(t queryTerm) (*QueryNode, error) {
+	num, err := strconv.ParseFloat(t.value, 64)
+	if err != nil {
+		return nil, t.errValue("se esperaba un número")
+	}
+	cmp := map[string]string{":": "eq", "!=": "eq", ">": "gt", ">=": "gte", "<": "lt", "<=": "lte"}[t.op]
+	return negateIf(t.op == "!=", &QueryNode{Kind: QueryNumber, Field: t.field.bson, Cmp: cmp, Num: num}), nil
+}
+
+func (p *queryParser) dateNode(t queryTerm) (*QueryNode, error) {
+	from, to, ok := resolveQueryDate(strings.ToLower(t.value), p.opts)
+	if !ok {
+		return nil, t.errValue("fecha inválida (usa YYYY-MM-DD, today, this-week, next-7d, +3d, ...)")
+	}
+	node := &QueryNode{Kind: QueryTime, Field: t.field.bson}
+	switch t.op {
+	case ":", "!=":
+		if !from.Before(to) {
+			return nil, t.errValue("un instante solo admite >, >=, < o <=")
+		}
+		node.From, node.To = from, to
+	case ">":
+		node.From = to
+	case ">=":
+		node.From = from
+	case "<":
+		node.To = from
+	case "<=":
+		node.To = to
+	}
+	return negateIf(t.op == "!=", node), nil
+}
+
+func (p *queryParser) flagNode(t queryTerm) (*QueryNode, error) {
+	if t.op != ":" {
+		return nil, t.errOp()
+	}
+	var node *QueryNode
+	switch strings.ToLower(t.value) {
+	case "overdue":
+		// Vencida: fecha pasada y ni completada ni cancelada
+		node = &QueryNode{Kind: QueryAnd, Children: []*QueryNode{
+			{Kind: QueryTime, Field: "dueDate", To: p.opts.Now},
+			negateIf(true, &QueryNode{Kind: QueryIn, Field: "status", Values: []string{StatusDone, StatusCancelled}}),
+		}}
+	case "blocked":
+		node = &QueryNode{Kind: QueryBool, Field: "isBlocked", Bool: true}
+	case "group":
+		node = &QueryNode{Kind: QueryBool, Field: "isGroupWork", Bool: true}
+	default:
+		return nil, t.errValue(fmt.Sprintf("valor inválido para is (válidos: %s)", strings.Join(t.field.values, ", ")))
+	}
+	return node, nil
+}
+
+// resolveQueryDate convierte un valor de fecha en el intervalo [from, to)
+// que representa (un día, una semana, un mes o un instante)
+func resolveQueryDate(v string, opts QueryOptions) (from, to time.Time, ok bool) {
+	now := opts.Now.In(opts.Location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, opts.Location)
+	first, ok := ParseWeekday(opts.WeekStart)
+	if !ok {
+		first = time.Monday
+	}
+	weekStart := StartOfWeek(today, opts.Location, first)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, opts.Location)
+
+	switch v {
+	case "now", "ahora":
+		return now, now, true
+	case "today", "hoy":
+		return today, today.AddDate(0, 0, 1), true
+	case "tomorrow", "mañana", "manana":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), true
+	case "yesterday", "ayer":
+		return today.AddDate(0, 0, -1), today, true
+	case "this-week", "week", "semana":
+		return weekStart, weekStart.AddDate(0, 0, 7), true
+	case "next-week", "proxima-semana":
+		return weekStart.AddDate(0, 0, 7), weekStart.AddDate(0, 0, 14), true
+	case "last-week":
+		return weekStart.AddDate(0, 0, -7), weekStart, true
+	case "this-month", "month", "mes":
+		return monthStart, monthStart.AddDate(0, 1, 0), true
+	}
+
+	// Rangos móviles desde ahora: next-7d, last-2w, next-12h
+	if rest, ok := strings.CutPrefix(v, "next-"); ok {
+		if d, ok := parseQueryOffset(rest); ok {
+			return now, now.Add(d), true
+		}
+	}
+	if rest, ok := strings.CutPrefix(v, "last-"); ok {
+		if d, ok := parseQueryOffset(rest); ok {
+			return now.Add(-d), now, true
+		}
+	}
+	// Instantes relativos: +3d, -1w
+	if strings.HasPrefix(v, "+") || strings.HasPrefix(v, "-") {
+		if d, ok := parseQueryOffset(v[1:]); ok {
+			if v[0] == '-' {
+				d = -d
+			}
+			return now.Add(d), now.Add(d), true
+		}
+	}
+
+	if d, err := time.ParseInLocation("2006-01-02", v, opts.Location); err == nil {
+		return d, d.AddDate(0, 0, 1), true
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(v)); err == nil {
+		return t, t, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// parseQueryOffset interpreta "7d", "2w" o "12h" (máximo 3 dígitos)
+func parseQueryOffset(v string) (time.Duration, bool) {
+	if len(v) < 2 || len(v) > 4 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	switch v[len(v)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, true
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// Matches evalúa la expresión sobre una tarea (equivalente en memoria de la
+// traducción a Mongo)
+func (n *QueryNode) Matches(t *Task) bool {
+	switch n.Kind {
+	case QueryAnd:
+		for _, c := range n.Children {
+			if !c.Matches(t) {
+				return false
+			}
+		}
+		return true
+	case QueryOr:
+		for _, c := range n.Children {
+			if c.Matches(t) {
+				return true
+			}
+		}
+		return false
+	case QueryNot:
+		return !n.Children[0].Matches(t)
+	case QueryIn:
+		for _, have := range taskStrings(t, n.Field) {
+			for _, want := range n.Values {
+				if have == want {
+					return true
+				}
+			}
+		}
+		return false
+	case QueryContains:
+		return strings.Contains(strings.ToLower(t.Title), strings.ToLower(n.Text))
+	case QueryNumber:
+		v := taskNumber(t, n.Field)
+		switch n.Cmp {
+		case "gt":
+			return v > n.Num
+		case "gte":
+			return v >= n.Num
+		case "lt":
+			return v < n.Num
+		case "lte":
+			return v <= n.Num
+		default:
+			return v == n.Num
+		}
+	case QueryTime:
+		v := taskTime(t, n.Field)
+		if v == nil {
+			return false
+		}
+		if !n.From.IsZero() && v.Before(n.From) {
+			return false
+		}
+		if !n.To.IsZero() && !v.Before(n.To) {
+			return false
+		}
+		return true
+	case QueryBool:
+		switch n.Field {
+		case "isBlocked":
+			return t.IsBlocked == n.Bool
+		case "isGroupWork":
+			return t.IsGroupWork == n.Bool
+		}
+	}
+	return false
+}
+
+func taskStrings(t *Task, field string) []string {
+	switch field {
+	case "status":
+		return []string{t.Status}
+	case "priority":
+		return []string{t.Priority}
+	case "type":
+		return []string{t.Type}
+	case "subjectId":
+		return []string{t.SubjectID}
+	case "periodId":
+		return []string{t.PeriodID}
+	case "tags":
+		return t.Tags
+	}
+	return nil
+}
+
+func taskNumber(t *Task, field string) float64 {
+	if field == "gradeWeight" {
+		return t.GradeWeight
+	}
+	return float64(t.EstimatedTimeHours)
+}
+
+func taskTime(t *Task, field string) *time.Time {
+	switch field {
+	case "createdAt":
+		return &t.CreatedAt
+	case "completedAt":
+		return t.CompletedAt
+	default:
+		return &t.DueDate
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseQueryMatches(t *testing.T) {
+	// Lunes 6 de octubre de 2025
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	opts := QueryOptions{Now: now, Location: time.UTC}
+
+	exam := &Task{ID: "exam", Title: "Parcial de Cálculo", Type: TypeExam, Status: StatusTodo, Priority: PriorityHigh,
+		Tags: []string{"parcial", "calculo"}, EstimatedTimeHours: 6, DueDate: now.Add(48 * time.Hour)}
+	reading := &Task{ID: "reading", Title: "Lectura capítulo 3", Type: TypeReading, Status: StatusInProgress, Priority: PriorityLow,
+		Tags: []string{"parcial"}, EstimatedTimeHours: 5, DueDate: now.Add(24 * time.Hour)}
+	late := &Task{ID: "late", Title: "Informe de laboratorio", Type: TypeLab, Status: StatusTodo, Priority: PriorityUrgent,
+		EstimatedTimeHours: 2, DueDate: now.AddDate(0, 0, -3), IsBlocked: true}
+	doneLate := &Task{ID: "done", Title: "Quiz 1", Type: TypeQuiz, Status: StatusDone, Priority: PriorityMedium,
+		DueDate: now.AddDate(0, 0, -2)}
+	nextWeek := &Task{ID: "next", Title: "Ensayo final", Type: TypeEssay, Status: StatusTodo, Priority: PriorityMedium,
+		DueDate: now.AddDate(0, 0, 8)}
+	all := []*Task{exam, reading, late, doneLate, nextWeek}
+
+	cases := map[string]string{
+		`tag:parcial AND estimated>4 AND -type:reading`: "exam",
+		`tag:parcial estimated>=5`:                      "exam,reading",
+		`due:this-week OR is:overdue`:                   "exam,reading,late",
+		`(due:this-week OR is:overdue) -status:todo`:    "reading",
+		`due:last-week`:                                 "late,done",
+		`is:overdue`:                                    "late",
+		`priority>=high`:                                "exam,late",
+		`priority<medium OR status:in-progress`:         "reading",
+		`NOT type:exam,reading,lab`:                     "done,next",
+		`title:"de cálculo"`:                            "exam",
+		`title:INFORME is:blocked`:                      "late",
+		`due:next-week`:                                 "next",
+		`due>2025-10-07 due<=2025-10-14`:                "exam,next",
+		`due:2025-10-07`:                                "reading",
+		`status!=todo`:                                  "reading,done",
+		`completed:today`:                               "",
+	}
+
+	for q, want := range cases {
+		node, err := ParseQuery(q, opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", q, err)
+			continue
+		}
+		got := ""
+		for _, task := range all {
+			if node.Matches(task) {
+				if got != "" {
+					got += ","
+				}
+				got += task.ID
+			}
+		}
+		if got != want {
+			t.Errorf("%s: got [%s], want [%s]", q, got, want)
+		}
+	}
+}
+
+func TestParseQueryErrorsPointToToken(t *testing.T) {
+	cases := []struct {
+		query string
+		pos   int
+		token string
+	}{
+		{`tag:parcial AND owner:me`, 16, "owner"},
+		{`status:todo priority:extreme`, 21, "extreme"},
+		{`estimated>four`, 10, "four"},
+		{`type>exam`, 4, ">"},
+		{`(tag:parcial OR tag:final`, 0, "("},
+		{`tag:parcial)`, 11, ")"},
+		{`due:someday`, 4, "someday"},
+		{`title:"sin cerrar`, 6, `"sin cerrar`},
+		{`tag:parcial AND`, 15, ""},
+		{`parcial`, 0, "parcial"},
+	}
+	for _, c := range cases {
+		_, err := ParseQuery(c.query, QueryOptions{})
+		var qe *QueryError
+		if !errors.As(err, &qe) {
+			t.Errorf("%s: expected QueryError, got %v", c.query, err)
+			continue
+		}
+		if qe.Pos != c.pos || qe.Token != c.token {
+			t.Errorf("%s: got pos %d token %q, want pos %d token %q", c.query, qe.Pos, qe.Token, c.pos, c.token)
+		}
+	}
+}
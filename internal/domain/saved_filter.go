@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// MaxSavedFiltersPerUser límite de listas guardadas por usuario
+const MaxSavedFiltersPerUser = 50
+
+// SavedFilter lista inteligente: un filtro de tareas con nombre ("Urgentes de
+// esta semana"). Los parámetros se guardan tal como los recibe GET /tasks y se
+// interpretan en cada uso, así los rangos relativos (q=due:next-7d) se
+// recalculan siempre respecto al momento de la consulta.
+type SavedFilter struct {
+	ID        string            `bson:"_id,omitempty" json:"id"`
+	UserID    string            `bson:"userId" json:"-"`
+	Name      string            `bson:"name" json:"name"`
+	Params    SavedFilterParams `bson:"params" json:"params"`
+	CreatedAt time.Time         `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time         `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SavedFilterParams parámetros de filtro de GET /tasks (mismos nombres y formato)
+type SavedFilterParams struct {
+	Status    string `bson:"status,omitempty" json:"status,omitempty"`     // "todo,in-progress"
+	Priority  string `bson:"priority,omitempty" json:"priority,omitempty"` // "high,urgent"
+	Type      string `bson:"type,omitempty" json:"type,omitempty"`
+	SubjectID string `bson:"subjectId,omitempty" json:"subjectId,omitempty"`
+	PeriodID  string `bson:"periodId,omitempty" json:"periodId,omitempty"`
+	IsOverdue *bool  `bson:"isOverdue,omitempty" json:"isOverdue,omitempty"`
+	IsDueSoon *bool  `bson:"isDueSoon,omitempty" json:"isDueSoon,omitempty"`
+	Query     string `bson:"q,omitempty" json:"q,omitempty"`       // Expresión (ParseQuery)
+	Sort      string `bson:"sort,omitempty" json:"sort,omitempty"` // "priority:desc,dueDate:asc"
+}
+
+// SavedFilterCount tareas que hoy cumplen una lista guardada (dashboard)
+type SavedFilterCount struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Pesos de cada factor en el score de urgencia (suman 1.0). Son públicos
+// para que los repositorios que ordenan por score en la base usen los mismos.
+const (
+	ScoreWeightDue      = 0.35
+	ScoreWeightPriority = 0.20
+	ScoreWeightType     = 0.15
+	ScoreWeightEffort   = 0.15
+	ScoreWeightGrade    = 0.15
+
+	// Penalización aplicada cuando la tarea está bloqueada
+	ScoreBlockedFactor = 0.25
+
+	ScoreDueDecayDays = 5  // la cercanía decae como e^(-días/5)
+	ScoreEffortShare  = 4  // se asume ~1/4 del tiempo disponible para la tarea
+	ScoreGradeCap     = 30 // peso en la nota (%) a partir del cual satura
+)
+
+// priorityScores valor relativo de cada prioridad (0-1)
+var priorityScores = map[string]float64{
+	PriorityLow:    0.25,
+	PriorityMedium: 0.5,
+	PriorityHigh:   0.75,
+	PriorityUrgent: 1.0,
+}
+
+// typeScores valor relativo de cada tipo de tarea (exam > reading)
+var typeScores = map[string]float64{
+	TypeExam:         1.0,
+	TypeQuiz:         0.75,
+	TypePresentation: 0.7,
+	TypeLab:          0.6,
+	TypeEssay:        0.6,
+	TypeGroupWork:    0.55,
+	TypeAssignment:   0.5,
+	TypeReading:      0.3,
+}
+
+// PriorityScore valor relativo de una prioridad (0 si es desconocida)
+func PriorityScore(p string) float64 {
+	return priorityScores[p]
+}
+
+// TypeScore valor relativo de un tipo de tarea (0 si es desconocido)
+func TypeScore(t string) float64 {
+	return typeScores[t]
+}
+
+// ScoreComponent representa el aporte de un factor al score final
+type ScoreComponent struct {
+	Factor       string  `json:"factor"`
+	Value        float64 `json:"value"`        // 0-1
+	Weight       float64 `json:"weight"`       // peso del factor
+	Contribution float64 `json:"contribution"` // puntos aportados (0-100)
+	Reason       string  `json:"reason"`
+}
+
+// TaskScore es el score de urgencia calculado para una tarea
+type TaskScore struct {
+	Score      float64          `json:"score"` // 0-100
+	Components []ScoreComponent `json:"components"`
+	Blocked    bool             `json:"blocked"`
+}
+
+// ScoredTask agrupa una tarea con su score de urgencia calculado
+type ScoredTask struct {
+	Task  Task
+	Score TaskScore
+}
+
+// RemainingHours horas estimadas que faltan por invertir en la tarea
+func (t *Task) RemainingHours() int {
+	remaining := t.EstimatedTimeHours
+	if t.ActualTimeHours != nil {
+		remaining -= *t.ActualTimeHours
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// ComputeScore calcula el score de urgencia de una tarea en el instante now.
+// Combina cercanía de la fecha de entrega, prioridad, tipo, horas restantes,
+// peso en la nota y estado bloqueado. Tareas completadas o canceladas valen 0.
+func ComputeScore(t *Task, now time.Time) TaskScore {
+	if t.IsCompleted() || t.IsCancelled() {
+		return TaskScore{Score: 0, Components: []ScoreComponent{}}
+	}
+
+	hoursLeft := t.DueDate.Sub(now).Hours()
+	components := []ScoreComponent{
+		dueComponent(hoursLeft),
+		{
+			Factor: "priority",
+			Value:  priorityScores[t.Priority],
+			Weight: ScoreWeightPriority,
+			Reason: fmt.Sprintf("prioridad %s", t.Priority),
+		},
+		{
+			Factor: "type",
+			Value:  typeScores[t.Type],
+			Weight: ScoreWeightType,
+			Reason: fmt.Sprintf("tipo %s", t.Type),
+		},
+		effortComponent(t.RemainingHours(), hoursLeft),
+		gradeComponent(t.GradeWeight),
+	}
+
+	total := 0.0
+	for i := range components {
+		components[i].Contribution = round2(components[i].Value * components[i].Weight * 100)
+		total += components[i].Value * components[i].Weight * 100
+	}
+
+	if t.IsBlocked {
+		total *= ScoreBlockedFactor
+		components = append(components, ScoreComponent{
+			Factor:       "blocked",
+			Value:        ScoreBlockedFactor,
+			Weight:       0,
+			Contribution: 0,
+			Reason:       fmt.Sprintf("tarea bloqueada: el score se multiplica por %.2f", ScoreBlockedFactor),
+		})
+	}
+
+	return TaskScore{
+		Score:      round2(total),
+		Components: components,
+		Blocked:    t.IsBlocked,
+	}
+}
+
+// SortByScore ordena tareas por score de urgencia (desempata por dueDate e ID)
+func SortByScore(tasks []Task, now time.Time, desc bool) {
+	type scored struct {
+		task  Task
+		score float64
+	}
+	items := make([]scored, len(tasks))
+	for i := range tasks {
+		items[i] = scored{task: tasks[i], score: ComputeScore(&tasks[i], now).Score}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.score != b.score {
+			if desc {
+				return a.score > b.score
+			}
+			return a.score < b.score
+		}
+		if !a.task.DueDate.Equal(b.task.DueDate) {
+			return a.task.DueDate.Before(b.task.DueDate)
+		}
+		return a.task.ID < b.task.ID
+	})
+	for i := range items {
+		tasks[i] = items[i].task
+	}
+}
+
+// dueComponent cercanía de la fecha de entrega (decae con los días restantes)
+func dueComponent(hoursLeft float64) ScoreComponent {
+	c := ScoreComponent{Factor: "dueDate", Weight: ScoreWeightDue}
+	if hoursLeft <= 0 {
+		c.Value = 1
+		c.Reason = fmt.Sprintf("vencida hace %s", humanizeHours(-hoursLeft))
+		return c
+	}
+	days := hoursLeft / 24
+	c.Value = math.Exp(-days / ScoreDueDecayDays)
+	c.Reason = fmt.Sprintf("vence en %s", humanizeHours(hoursLeft))
+	return c
+}
+
+// effortComponent presión por horas restantes frente al tiempo disponible
+func effortComponent(remaining int, hoursLeft float64) ScoreComponent {
+	c := ScoreComponent{Factor: "remainingHours", Weight: ScoreWeightEffort}
+	if remaining == 0 {
+		c.Reason = "sin horas estimadas pendientes"
+		return c
+	}
+	if hoursLeft <= 0 {
+		c.Value = 1
+		c.Reason = fmt.Sprintf("%dh pendientes y ya vencida", remaining)
+		return c
+	}
+	c.Value = math.Min(float64(remaining)/(hoursLeft/ScoreEffortShare), 1)
+	c.Reason = fmt.Sprintf("%dh pendientes para %s disponibles", remaining, humanizeHours(hoursLeft))
+	return c
+}
+
+// gradeComponent peso de la tarea en la nota final (ScoreGradeCap% o más satura)
+func gradeComponent(gradeWeight float64) ScoreComponent {
+	c := ScoreComponent{Factor: "gradeWeight", Weight: ScoreWeightGrade}
+	if gradeWeight <= 0 {
+		c.Reason = "sin peso en la nota registrado"
+		return c
+	}
+	c.Value = math.Min(gradeWeight/ScoreGradeCap, 1)
+	c.Reason = fmt.Sprintf("vale %.0f%% de la nota", gradeWeight)
+	return c
+}
+
+func humanizeHours(h float64) string {
+	if h < 24 {
+		return fmt.Sprintf("%.0fh", math.Ceil(h))
+	}
+	return fmt.Sprintf("%.0f días", math.Floor(h/24))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeScoreOrdering(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+
+	exam := &Task{ID: "exam", Status: StatusTodo, Priority: PriorityHigh, Type: TypeExam,
+		DueDate: now.Add(24 * time.Hour), EstimatedTimeHours: 6, GradeWeight: 25}
+	reading := &Task{ID: "reading", Status: StatusTodo, Priority: PriorityHigh, Type: TypeReading,
+		DueDate: now.Add(24 * time.Hour), EstimatedTimeHours: 6, GradeWeight: 25}
+	farAway := &Task{ID: "far", Status: StatusTodo, Priority: PriorityHigh, Type: TypeExam,
+		DueDate: now.Add(30 * 24 * time.Hour), EstimatedTimeHours: 6, GradeWeight: 25}
+
+	examScore := ComputeScore(exam, now).Score
+	if readingScore := ComputeScore(reading, now).Score; examScore <= readingScore {
+		t.Errorf("exam (%.2f) should outrank reading (%.2f)", examScore, readingScore)
+	}
+	if farScore := ComputeScore(farAway, now).Score; examScore <= farScore {
+		t.Errorf("task due tomorrow (%.2f) should outrank task due next month (%.2f)", examScore, farScore)
+	}
+
+	blocked := *exam
+	blocked.IsBlocked = true
+	if blockedScore := ComputeScore(&blocked, now); blockedScore.Score >= examScore || !blockedScore.Blocked {
+		t.Errorf("blocked task should score lower, got %.2f vs %.2f", blockedScore.Score, examScore)
+	}
+}
+
+func TestComputeScoreCompletedIsZero(t *testing.T) {
+	now := time.Now()
+	done := &Task{Status: StatusDone, Priority: PriorityUrgent, Type: TypeExam, DueDate: now}
+	if s := ComputeScore(done, now); s.Score != 0 {
+		t.Errorf("expected 0 for completed task, got %.2f", s.Score)
+	}
+}
+
+func TestComputeScoreExplainsEveryFactor(t *testing.T) {
+	now := time.Now()
+	task := &Task{Status: StatusTodo, Priority: PriorityMedium, Type: TypeLab, DueDate: now.Add(-2 * time.Hour)}
+
+	score := ComputeScore(task, now)
+	factors := map[string]bool{}
+	for _, c := range score.Components {
+		factors[c.Factor] = true
+		if c.Reason == "" {
+			t.Errorf("factor %s without reason", c.Factor)
+		}
+	}
+	for _, f := range []string{"dueDate", "priority", "type", "remainingHours", "gradeWeight"} {
+		if !factors[f] {
+			t.Errorf("missing factor %s", f)
+		}
+	}
+}
+
+func TestSortByScore(t *testing.T) {
+	now := time.Now()
+	tasks := []Task{
+		{ID: "low", Status: StatusTodo, Priority: PriorityLow, Type: TypeReading, DueDate: now.Add(20 * 24 * time.Hour)},
+		{ID: "high", Status: StatusTodo, Priority: PriorityUrgent, Type: TypeExam, DueDate: now.Add(6 * time.Hour)},
+	}
+
+	SortByScore(tasks, now, true)
+	if tasks[0].ID != "high" {
+		t.Errorf("expected most urgent first, got %s", tasks[0].ID)
+	}
+}
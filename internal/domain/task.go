@@ -19,10 +19,16 @@ type Task struct {
 	Type               string     `bson:"type" json:"type"`
 	EstimatedTimeHours int        `bson:"estimatedTimeHours" json:"estimatedTimeHours"`
 	ActualTimeHours    *int       `bson:"actualTimeHours,omitempty" json:"actualTimeHours,omitempty"`
+	GradeWeight        float64    `bson:"gradeWeight" json:"gradeWeight"` // % de la nota final (0-100)
+	IsBlocked          bool       `bson:"isBlocked" json:"isBlocked"`     // Bloqueada por dependencia externa
 	Tags               []string   `bson:"tags" json:"tags"`
 	IsGroupWork        bool       `bson:"isGroupWork" json:"isGroupWork"`
 	GroupMembers       []string   `bson:"groupMembers" json:"groupMembers"`
 	Attachments        []string   `bson:"attachments" json:"attachments"`
+	ExternalID         string     `bson:"externalId,omitempty" json:"externalId,omitempty"`     // ID en el sistema de origen (ICS UID, CSV)
+	SharedTaskID       string     `bson:"sharedTaskId,omitempty" json:"sharedTaskId,omitempty"` // Tarea del dueño, si es la copia de un colaborador
+	OwnerID            string     `bson:"ownerId,omitempty" json:"ownerId,omitempty"`           // Dueño de la tarea compartida (solo en copias)
+	Role               string     `bson:"role,omitempty" json:"role,omitempty"`                 // editor / viewer (solo en copias)
 	CreatedAt          time.Time  `bson:"createdAt" json:"createdAt"`
 	UpdatedAt          time.Time  `bson:"updatedAt" json:"updatedAt"`
 	CompletedAt        *time.Time `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
@@ -45,6 +51,9 @@ func (t *Task) IsValid() error {
 	if !isValidType(t.Type) {
 		return fmt.Errorf("tipo inválido: %s", t.Type)
 	}
+	if t.GradeWeight < 0 || t.GradeWeight > 100 {
+		return fmt.Errorf("gradeWeight debe estar entre 0 y 100")
+	}
 	return nil
 }
 
@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Límites de plantillas
+const (
+	MaxTemplatesPerUser  = 50
+	MaxTemplateItems     = 20
+	MaxTemplateOffsetDay = 365
+)
+
+// BuiltInTemplatePrefix prefijo de los IDs de las plantillas predefinidas
+const BuiltInTemplatePrefix = "builtin-"
+
+// TaskTemplate estructura reutilizable de tareas ("Reporte de laboratorio:
+// pre-laboratorio, datos, reporte"). Cada ítem de la lista se convierte en
+// una tarea que vence DueOffsetDays días respecto a la fecha objetivo; sin
+// ítems la plantilla crea una sola tarea que vence en la fecha objetivo.
+type TaskTemplate struct {
+	ID                 string         `bson:"_id,omitempty" json:"id"`
+	UserID             string         `bson:"userId" json:"-"`
+	Name               string         `bson:"name" json:"name"`
+	Description        string         `bson:"description" json:"description"`
+	Type               string         `bson:"type" json:"type"`
+	Priority           string         `bson:"priority" json:"priority"`
+	EstimatedTimeHours int            `bson:"estimatedTimeHours" json:"estimatedTimeHours"`
+	Tags               []string       `bson:"tags" json:"tags"`
+	Items              []TemplateItem `bson:"items" json:"items"`
+	BuiltIn            bool           `bson:"-" json:"builtIn"`
+	CreatedAt          time.Time      `bson:"createdAt" json:"createdAt"`
+	UpdatedAt          time.Time      `bson:"updatedAt" json:"updatedAt"`
+}
+
+// TemplateItem paso de la plantilla; Type y EstimatedTimeHours vacíos
+// heredan los valores de la plantilla
+type TemplateItem struct {
+	Title              string `bson:"title" json:"title"`
+	DueOffsetDays      int    `bson:"dueOffsetDays" json:"dueOffsetDays"` // Ej: -7 = una semana antes
+	Type               string `bson:"type,omitempty" json:"type,omitempty"`
+	EstimatedTimeHours int    `bson:"estimatedTimeHours,omitempty" json:"estimatedTimeHours,omitempty"`
+}
+
+// TemplateInstance datos para crear las tareas de una plantilla
+type TemplateInstance struct {
+	UserID    string
+	SubjectID string
+	PeriodID  string
+	Title     string    // Prefijo de los títulos (default: nombre de la plantilla)
+	DueDate   time.Time // Fecha objetivo (entrega final)
+	Location  *time.Location
+	Now       time.Time
+}
+
+// Normalize aplica valores por defecto y limpia espacios
+func (t *TaskTemplate) Normalize() {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	if t.Type == "" {
+		t.Type = TypeAssignment
+	}
+	if t.Priority == "" {
+		t.Priority = PriorityMedium
+	}
+	if t.Tags == nil {
+		t.Tags = []string{}
+	}
+	if t.Items == nil {
+		t.Items = []TemplateItem{}
+	}
+	for i := range t.Items {
+		t.Items[i].Title = strings.TrimSpace(t.Items[i].Title)
+	}
+}
+
+// Validate valida nombre, tipo, prioridad y los ítems
+func (t *TaskTemplate) Validate() error {
+	if t.Name == "" || len(t.Name) > 100 {
+		return invalidTemplate("name es requerido (máximo 100 caracteres)")
+	}
+	if !isValidType(t.Type) {
+		return invalidTemplate("tipo inválido: %s", t.Type)
+	}
+	if !isValidPriority(t.Priority) {
+		return invalidTemplate("prioridad inválida: %s", t.Priority)
+	}
+	if t.EstimatedTimeHours < 0 {
+		return invalidTemplate("estimatedTimeHours no puede ser negativo")
+	}
+	if len(t.Items) > MaxTemplateItems {
+		return invalidTemplate("máximo %d ítems por plantilla", MaxTemplateItems)
+	}
+	for i, item := range t.Items {
+		if item.Title == "" || len(item.Title) > 200 {
+			return invalidTemplate("items[%d].title es requerido (máximo 200 caracteres)", i)
+		}
+		if item.DueOffsetDays < -MaxTemplateOffsetDay || item.DueOffsetDays > MaxTemplateOffsetDay {
+			return invalidTemplate("items[%d].dueOffsetDays debe estar entre -%d y %d", i, MaxTemplateOffsetDay, MaxTemplateOffsetDay)
+		}
+		if item.Type != "" && !isValidType(item.Type) {
+			return invalidTemplate("items[%d].type inválido: %s", i, item.Type)
+		}
+		if item.EstimatedTimeHours < 0 {
+			return invalidTemplate("items[%d].estimatedTimeHours no puede ser negativo", i)
+		}
+	}
+	return nil
+}
+
+func invalidTemplate(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTemplate, fmt.Sprintf(format, args...))
+}
+
+// Instantiate tareas de la plantilla. Los desplazamientos se aplican por día
+// de calendario en la zona del usuario, así la hora local de la fecha
+// objetivo se conserva aunque haya un cambio de horario en medio.
+func (t *TaskTemplate) Instantiate(in TemplateInstance) []*Task {
+	loc := in.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	title := strings.TrimSpace(in.Title)
+	if title == "" {
+		title = t.Name
+	}
+	target := in.DueDate.In(loc)
+
+	items := t.Items
+	if len(items) == 0 {
+		items = []TemplateItem{{Title: title}}
+	}
+
+	tasks := make([]*Task, 0, len(items))
+	for _, item := range items {
+		task := &Task{
+			UserID:             in.UserID,
+			Title:              item.Title,
+			Description:        t.Description,
+			SubjectID:          in.SubjectID,
+			PeriodID:           in.PeriodID,
+			DueDate:            target.AddDate(0, 0, item.DueOffsetDays),
+			Status:             StatusTodo,
+			Priority:           t.Priority,
+			Type:               t.Type,
+			EstimatedTimeHours: t.EstimatedTimeHours,
+			Tags:               append([]string{}, t.Tags...),
+			GroupMembers:       []string{},
+			Attachments:        []string{},
+			CreatedAt:          in.Now,
+			UpdatedAt:          in.Now,
+		}
+		if len(t.Items) > 0 {
+			task.Title = title + ": " + item.Title
+		}
+		if item.Type != "" {
+			task.Type = item.Type
+		}
+		if item.EstimatedTimeHours > 0 {
+			task.EstimatedTimeHours = item.EstimatedTimeHours
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// TemplateFromTask plantilla de una sola tarea con los valores de task
+func TemplateFromTask(task *Task, userID, name string) *TaskTemplate {
+	if strings.TrimSpace(name) == "" {
+		name = task.Title
+	}
+	return &TaskTemplate{
+		UserID:             userID,
+		Name:               name,
+		Description:        task.Description,
+		Type:               task.Type,
+		Priority:           task.Priority,
+		EstimatedTimeHours: task.EstimatedTimeHours,
+		Tags:               append([]string{}, task.Tags...),
+		Items:              []TemplateItem{},
+	}
+}
+
+// IsBuiltInTemplate indica si el ID corresponde a una plantilla predefinida
+func IsBuiltInTemplate(id string) bool {
+	return strings.HasPrefix(id, BuiltInTemplatePrefix)
+}
+
+// BuiltInTemplates plantillas predefinidas para flujos académicos comunes.
+// Retorna copias nuevas en cada llamada.
+func BuiltInTemplates() []TaskTemplate {
+	templates := []TaskTemplate{
+		{
+			ID:                 BuiltInTemplatePrefix + "lab-report",
+			Name:               "Reporte de laboratorio",
+			Type:               TypeLab,
+			Priority:           PriorityMedium,
+			EstimatedTimeHours: 2,
+			Tags:               []string{"laboratorio"},
+			Items: []TemplateItem{
+				{Title: "Pre-laboratorio", DueOffsetDays: -7, EstimatedTimeHours: 1},
+				{Title: "Datos", DueOffsetDays: -4},
+				{Title: "Reporte", DueOffsetDays: 0, EstimatedTimeHours: 4},
+			},
+		},
+		{
+			ID:                 BuiltInTemplatePrefix + "exam",
+			Name:               "Examen",
+			Type:               TypeExam,
+			Priority:           PriorityHigh,
+			EstimatedTimeHours: 3,
+			Tags:               []string{"examen"},
+			Items: []TemplateItem{
+				{Title: "Repasar capítulos", DueOffsetDays: -7, Type: TypeReading, EstimatedTimeHours: 4},
+				{Title: "Examen de práctica", DueOffsetDays: -2, Type: TypeQuiz, EstimatedTimeHours: 2},
+				{Title: "Examen", DueOffsetDays: 0},
+			},
+		},
+		{
+			ID:                 BuiltInTemplatePrefix + "essay",
+			Name:               "Ensayo",
+			Type:               TypeEssay,
+			Priority:           PriorityMedium,
+			EstimatedTimeHours: 2,
+			Tags:               []string{"ensayo"},
+			Items: []TemplateItem{
+				{Title: "Investigación", DueOffsetDays: -10, Type: TypeReading, EstimatedTimeHours: 3},
+				{Title: "Esquema", DueOffsetDays: -7, EstimatedTimeHours: 1},
+				{Title: "Borrador", DueOffsetDays: -3, EstimatedTimeHours: 4},
+				{Title: "Revisión final", DueOffsetDays: 0},
+			},
+		},
+		{
+			ID:                 BuiltInTemplatePrefix + "presentation",
+			Name:               "Presentación",
+			Type:               TypePresentation,
+			Priority:           PriorityMedium,
+			EstimatedTimeHours: 2,
+			Tags:               []string{"presentación"},
+			Items: []TemplateItem{
+				{Title: "Contenido", DueOffsetDays: -5, EstimatedTimeHours: 3},
+				{Title: "Diapositivas", DueOffsetDays: -3},
+				{Title: "Ensayo de la presentación", DueOffsetDays: -1, EstimatedTimeHours: 1},
+				{Title: "Presentación", DueOffsetDays: 0, EstimatedTimeHours: 1},
+			},
+		},
+	}
+	for i := range templates {
+		templates[i].BuiltIn = true
+	}
+	return templates
+}
+
+// BuiltInTemplate plantilla predefinida por ID
+func BuiltInTemplate(id string) (*TaskTemplate, bool) {
+	for _, t := range BuiltInTemplates() {
+		if t.ID == id {
+			return &t, true
+		}
+	}
+	return nil, false
+}
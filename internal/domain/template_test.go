@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuiltInTemplatesAreValid(t *testing.T) {
+	for _, tpl := range BuiltInTemplates() {
+		if err := tpl.Validate(); err != nil {
+			t.Errorf("%s: %v", tpl.ID, err)
+		}
+		if !tpl.BuiltIn || !IsBuiltInTemplate(tpl.ID) {
+			t.Errorf("%s should be marked as built-in", tpl.ID)
+		}
+	}
+	if _, ok := BuiltInTemplate("builtin-nope"); ok {
+		t.Error("unknown built-in template should not be found")
+	}
+}
+
+func TestTemplateInstantiateOffsets(t *testing.T) {
+	tpl, _ := BuiltInTemplate("builtin-exam")
+	cr, _ := time.LoadLocation("America/Costa_Rica")
+	// 20 de marzo 08:00 en Costa Rica = 14:00Z
+	due := time.Date(2025, 3, 20, 14, 0, 0, 0, time.UTC)
+
+	tasks := tpl.Instantiate(TemplateInstance{UserID: "u1", SubjectID: "calc", Title: "Parcial 1", DueDate: due, Location: cr})
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	first, last := tasks[0], tasks[2]
+	if first.Title != "Parcial 1: Repasar capítulos" || first.Type != TypeReading || first.EstimatedTimeHours != 4 {
+		t.Errorf("unexpected first task %+v", first)
+	}
+	if got := first.DueDate.In(cr); got.Day() != 13 || got.Hour() != 8 {
+		t.Errorf("expected 13 March 08:00 local, got %v", got)
+	}
+	if !last.DueDate.Equal(due) || last.Type != TypeExam || last.EstimatedTimeHours != 3 || last.Status != StatusTodo || last.SubjectID != "calc" {
+		t.Errorf("unexpected last task %+v", last)
+	}
+	for _, task := range tasks {
+		if err := task.IsValid(); err != nil {
+			t.Errorf("%s: %v", task.Title, err)
+		}
+	}
+
+	// Sin ítems: una sola tarea con el nombre de la plantilla
+	single := TemplateFromTask(&Task{Title: "Lectura semanal", Type: TypeReading, Priority: PriorityLow, Tags: []string{"lectura"}}, "u1", "")
+	tasks = single.Instantiate(TemplateInstance{UserID: "u1", SubjectID: "hist", DueDate: due})
+	if len(tasks) != 1 || tasks[0].Title != "Lectura semanal" || !tasks[0].DueDate.Equal(due) || tasks[0].Priority != PriorityLow {
+		t.Errorf("unexpected single task %+v", tasks)
+	}
+}
+
+func TestTemplateValidate(t *testing.T) {
+	invalid := map[string]TaskTemplate{
+		"name":     {Name: " "},
+		"type":     {Name: "x", Type: "tesis"},
+		"offset":   {Name: "x", Items: []TemplateItem{{Title: "a", DueOffsetDays: -400}}},
+		"item":     {Name: "x", Items: []TemplateItem{{Title: ""}}},
+		"itemType": {Name: "x", Items: []TemplateItem{{Title: "a", Type: "tesis"}}},
+	}
+	for name, tpl := range invalid {
+		tpl.Normalize()
+		if err := tpl.Validate(); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("%s: expected ErrInvalidTemplate, got %v", name, err)
+		}
+	}
+}
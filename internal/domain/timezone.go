@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LoadTimeZone valida un nombre de zona IANA ("America/Costa_Rica"). Vacío
+// es UTC; "Local" se rechaza porque depende del servidor.
+func LoadTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "UTC" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+	return loc, nil
+}
+
+// StartOfDay inicio del día calendario de t en loc. Si la medianoche no
+// existe (cambio de horario a las 00:00) es el primer instante del día.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// DayRange día calendario de t en loc como [start, end). Dura 23 o 25
+// horas los días de cambio de horario.
+func DayRange(t time.Time, loc *time.Location) (start, end time.Time) {
+	start = StartOfDay(t, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// ParseLocalDate interpreta "2006-01-02" como el inicio de ese día en loc
+func ParseLocalDate(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	d, err := time.ParseInLocation("2006-01-02", s, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return StartOfDay(d, loc), nil
+}
+
+// weekdays nombres aceptados para el primer día de la semana
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// ParseWeekday "monday".."sunday"; vacío es lunes
+func ParseWeekday(name string) (time.Weekday, bool) {
+	if name == "" {
+		return time.Monday, true
+	}
+	d, ok := weekdays[strings.ToLower(name)]
+	return d, ok
+}
+
+// StartOfWeek medianoche local del primer día (first) de la semana de t
+func StartOfWeek(t time.Time, loc *time.Location, first time.Weekday) time.Time {
+	day := StartOfDay(t, loc)
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(first) + 7) % 7))
+}
+
+// DashboardOptions parámetros del dashboard que dependen del usuario
+type DashboardOptions struct {
+	Location      *time.Location // "hoy" y "esta semana" (nil = UTC)
+	WeekStart     string         // Primer día de la semana (vacío = monday)
+	UpcomingLimit int            // Próximas a mostrar (0 = DefaultUpcomingLimit)
+	TodayLimit    int            // Tareas de hoy a mostrar (0 = todas)
+}
+
+// DashboardWindows instantes y límites que usan los backends para el dashboard
+type DashboardWindows struct {
+	Now           time.Time
+	StartOfDay    time.Time // "hoy" = [StartOfDay, EndOfDay) en la zona del usuario
+	EndOfDay      time.Time
+	StartOfWeek   time.Time // completadas "esta semana" = desde el inicio de la semana local
+	UpcomingLimit int
+	TodayLimit    int // 0 = sin límite
+}
+
+// Windows ventanas del dashboard para now
+func (o DashboardOptions) Windows(now time.Time) DashboardWindows {
+	loc := o.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	first, ok := ParseWeekday(o.WeekStart)
+	if !ok {
+		first = time.Monday
+	}
+	upcoming := o.UpcomingLimit
+	if upcoming <= 0 {
+		upcoming = DefaultUpcomingLimit
+	}
+	today := o.TodayLimit
+	if today < 0 {
+		today = 0
+	}
+
+	now = now.In(loc)
+	start, end := DayRange(now, loc)
+	return DashboardWindows{
+		Now:           now,
+		StartOfDay:    start,
+		EndOfDay:      end,
+		StartOfWeek:   StartOfWeek(now, loc, first),
+		UpcomingLimit: upcoming,
+		TodayLimit:    today,
+	}
+}
+
+// ReminderAt momento del recordatorio: daysBefore días calendario antes del
+// vencimiento, a la misma hora local (respeta los cambios de horario)
+func ReminderAt(due time.Time, loc *time.Location, daysBefore int) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return due.In(loc).AddDate(0, 0, -daysBefore)
+}
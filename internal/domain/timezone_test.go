@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	for _, name := range []string{"", "UTC", " America/Costa_Rica "} {
+		if _, err := LoadTimeZone(name); err != nil {
+			t.Errorf("%q: unexpected error %v", name, err)
+		}
+	}
+	for _, name := range []string{"Local", "Mars/Olympus", "../etc/passwd"} {
+		if _, err := LoadTimeZone(name); !errors.Is(err, ErrInvalidTimeZone) {
+			t.Errorf("%q: expected ErrInvalidTimeZone, got %v", name, err)
+		}
+	}
+}
+
+func TestDayRangeAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	cases := []struct {
+		day  time.Time
+		want time.Duration
+	}{
+		{time.Date(2025, 3, 9, 12, 0, 0, 0, ny), 23 * time.Hour},  // inicio del horario de verano
+		{time.Date(2025, 11, 2, 12, 0, 0, 0, ny), 25 * time.Hour}, // fin del horario de verano
+		{time.Date(2025, 6, 1, 12, 0, 0, 0, ny), 24 * time.Hour},
+	}
+	for _, c := range cases {
+		start, end := DayRange(c.day, ny)
+		if got := end.Sub(start); got != c.want {
+			t.Errorf("%s: day lasts %s, want %s", c.day.Format("2006-01-02"), got, c.want)
+		}
+		if start.Hour() != 0 || end.In(ny).Hour() != 0 {
+			t.Errorf("%s: range %s - %s is not midnight to midnight", c.day.Format("2006-01-02"), start, end)
+		}
+	}
+}
+
+func TestDayBoundaryInUserZone(t *testing.T) {
+	cr := mustLoad(t, "America/Costa_Rica") // UTC-6, sin horario de verano
+
+	// 23:30 del 9 en Costa Rica ya es el 10 en UTC
+	now := time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC)
+	w := DashboardOptions{Location: cr}.Windows(now)
+	if want := time.Date(2025, 3, 9, 6, 0, 0, 0, time.UTC); !w.StartOfDay.Equal(want) {
+		t.Errorf("start of day %s, want %s", w.StartOfDay.UTC(), want)
+	}
+	if want := time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC); !w.EndOfDay.Equal(want) {
+		t.Errorf("end of day %s, want %s", w.EndOfDay.UTC(), want)
+	}
+
+	// En UTC el mismo instante es otro día
+	if utc := (DashboardOptions{}).Windows(now); utc.StartOfDay.Equal(w.StartOfDay) {
+		t.Error("UTC and Costa Rica should disagree on today")
+	}
+
+	d, err := ParseLocalDate("2025-03-09", cr)
+	if err != nil || !d.Equal(w.StartOfDay) {
+		t.Errorf("ParseLocalDate = %s, %v", d, err)
+	}
+	if _, err := ParseLocalDate("09/03/2025", cr); err == nil {
+		t.Error("expected error for non ISO date")
+	}
+}
+
+func TestReminderAtKeepsLocalTimeAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	due := time.Date(2025, 3, 11, 9, 0, 0, 0, ny) // EDT
+
+	got := ReminderAt(due, ny, 3)
+	if want := time.Date(2025, 3, 8, 9, 0, 0, 0, ny); !got.Equal(want) { // EST
+		t.Errorf("reminder at %s, want %s", got, want)
+	}
+	// Solo 71 horas reales: el 9 de marzo duró 23
+	if d := due.Sub(got); d != 71*time.Hour {
+		t.Errorf("reminder %s before due, want 71h", d)
+	}
+}
@@ -51,32 +51,39 @@ type Stats struct {
 
 // TaskFilter estructura para filtrar tareas en consultas
 type TaskFilter struct {
-	UserID      string    `form:"userId"`   // Obligatorio (viene del JWT)
-	Status      []string  `form:"status"`   // Ej: "todo,in-progress"
-	Priority    []string  `form:"priority"` // Ej: "high,urgent"
-	Type        []string  `form:"type"`     // Ej: "exam,quiz"
-	SubjectID   string    `form:"subjectId"`
-	PeriodID    string    `form:"periodId"`
-	DueDateFrom time.Time `form:"dueDateFrom"` // ISO 8601
-	DueDateTo   time.Time `form:"dueDateTo"`
-	IsOverdue   *bool     `form:"isOverdue"`
-	IsDueSoon   *bool     `form:"isDueSoon"` // Próximas 24h
-	Search      string    `form:"search"`    // Búsqueda texto
-	SortBy      string    `form:"sortBy"`    // dueDate, priority, status, createdAt
-	SortOrder   string    `form:"sortOrder"` // asc, desc
-	Page        int       `form:"page"`
-	Limit       int       `form:"limit"`
-	TimeZone    string    `form:"tz"` // Ej: America/Costa_Rica
+	UserID      string      `form:"userId"`   // Obligatorio (viene del JWT)
+	Status      []string    `form:"status"`   // Ej: "todo,in-progress"
+	Priority    []string    `form:"priority"` // Ej: "high,urgent"
+	Type        []string    `form:"type"`     // Ej: "exam,quiz"
+	SubjectID   string      `form:"subjectId"`
+	PeriodID    string      `form:"periodId"`
+	DueDateFrom time.Time   `form:"dueDateFrom"` // ISO 8601
+	DueDateTo   time.Time   `form:"dueDateTo"`
+	IsOverdue   *bool       `form:"isOverdue"`
+	IsDueSoon   *bool       `form:"isDueSoon"` // Próximas 24h
+	Search      string      `form:"search"`    // Búsqueda texto
+	Query       *QueryNode  `form:"-"`         // Expresión de filtro (ParseQuery)
+	SortBy      string      `form:"sortBy"`    // Ver SortableFields
+	SortOrder   string      `form:"sortOrder"` // asc, desc
+	Sort        []SortField `form:"-"`         // Orden compuesto; tiene prioridad sobre SortBy/SortOrder
+	Page        int         `form:"page"`
+	Limit       int         `form:"limit"`
+	After       string      `form:"after"`  // Cursor: página siguiente a esta posición
+	Before      string      `form:"before"` // Cursor: página anterior a esta posición
+	SkipCount   bool        `form:"-"`      // Omitir el conteo total (más rápido)
+	TimeZone    string      `form:"tz"`     // Ej: America/Costa_Rica
 }
 
 // PageInfo metadatos de paginación
 type PageInfo struct {
-	Total      int64
-	Page       int
+	Total      int64 // -1 si se omitió el conteo
+	Page       int   // 0 en paginación por cursor
 	Limit      int
-	TotalPages int64
+	TotalPages int64 // -1 si se omitió el conteo
 	HasNext    bool
 	HasPrev    bool
+	NextCursor string // Token para "after" (vacío si no hay más)
+	PrevCursor string // Token para "before" (vacío si no hay anteriores)
 }
 
 // DashboardTask es una representación simplificada de Task para el dashboard
@@ -94,11 +101,12 @@ type DashboardTask struct {
 
 // DashboardData contiene toda la información del dashboard
 type DashboardData struct {
-	UpcomingTasks     []DashboardTask `json:"upcomingTasks"`
-	TodayTasks        []DashboardTask `json:"todayTasks"`
-	OverdueCount      int             `json:"overdueCount"`
-	TotalPending      int             `json:"totalPending"`
-	CompletedThisWeek int             `json:"completedThisWeek"`
-	InProgressCount   int             `json:"inProgressCount"`
-	TodoCount         int             `json:"todoCount"`
+	UpcomingTasks     []DashboardTask    `json:"upcomingTasks"`
+	TodayTasks        []DashboardTask    `json:"todayTasks"`
+	OverdueCount      int                `json:"overdueCount"`
+	TotalPending      int                `json:"totalPending"`
+	CompletedThisWeek int                `json:"completedThisWeek"`
+	InProgressCount   int                `json:"inProgressCount"`
+	TodoCount         int                `json:"todoCount"`
+	SavedFilters      []SavedFilterCount `json:"savedFilters,omitempty"` // Conteo de cada lista guardada
 }
@@ -38,7 +38,7 @@ func (u *UserContext) IsValid() error {
 // Solo X-User-ID es obligatorio
 func FromHeaders(c *gin.Context) (*UserContext, error) {
 	userID := c.GetHeader("X-User-ID")
-	
+
 	if userID == "" {
 		return nil, errors.New("X-User-ID header is required")
 	}
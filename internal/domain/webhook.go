@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook suscripción a eventos de tareas.
+// UserID vacío indica un webhook de sistema: recibe los eventos de todos los usuarios.
+type Webhook struct {
+	ID             string     `bson:"_id,omitempty" json:"id"`
+	UserID         string     `bson:"userId" json:"-"`
+	URL            string     `bson:"url" json:"url"`
+	Secret         string     `bson:"secret" json:"-"`      // clave HMAC, solo se muestra al crear
+	Events         []string   `bson:"events" json:"events"` // vacío = todos
+	Description    string     `bson:"description" json:"description"`
+	Active         bool       `bson:"active" json:"active"`
+	FailureCount   int        `bson:"failureCount" json:"failureCount"` // eventos fallidos consecutivos
+	DisabledAt     *time.Time `bson:"disabledAt,omitempty" json:"disabledAt,omitempty"`
+	DisabledReason string     `bson:"disabledReason,omitempty" json:"disabledReason,omitempty"`
+	CreatedAt      time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// IsSystem indica si el webhook es de sistema
+func (w *Webhook) IsSystem() bool {
+	return w.UserID == ""
+}
+
+// Subscribes indica si el webhook debe recibir el tipo de evento
+func (w *Webhook) Subscribes(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid valida URL y eventos del webhook
+func (w *Webhook) IsValid() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url inválida: debe ser http(s)://host/...")
+	}
+	for _, e := range w.Events {
+		if !IsValidEventType(e) {
+			return fmt.Errorf("evento inválido: %s", e)
+		}
+	}
+	return nil
+}
+
+// WebhookDelivery intento de entrega de un evento a un webhook
+type WebhookDelivery struct {
+	ID           string    `bson:"_id,omitempty" json:"id"`
+	WebhookID    string    `bson:"webhookId" json:"webhookId"`
+	EventID      string    `bson:"eventId" json:"eventId"`
+	EventType    string    `bson:"eventType" json:"eventType"`
+	Attempt      int       `bson:"attempt" json:"attempt"`
+	StatusCode   int       `bson:"statusCode,omitempty" json:"statusCode,omitempty"`
+	Success      bool      `bson:"success" json:"success"`
+	Error        string    `bson:"error,omitempty" json:"error,omitempty"`
+	ResponseBody string    `bson:"responseBody,omitempty" json:"responseBody,omitempty"` // truncada; solo webhooks de sistema
+	DurationMs   int64     `bson:"durationMs" json:"durationMs"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+}
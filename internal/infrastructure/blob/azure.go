@@ -0,0 +1,73 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"uniflow-api/internal/domain"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+)
+
+// AzureStore guarda los blobs en un contenedor de Azure Blob Storage. Con
+// la cadena de conexión de Azurite funciona contra el emulador local.
+type AzureStore struct {
+	container *container.Client
+}
+
+// NewAzureStore conecta con el contenedor y lo crea si no existe
+func NewAzureStore(ctx context.Context, connectionString, containerName string) (*AzureStore, error) {
+	client, err := container.NewClientFromConnectionString(connectionString, containerName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("conectar con Azure Blob Storage: %w", err)
+	}
+	if _, err := client.Create(ctx, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return nil, fmt.Errorf("crear contenedor %s: %w", containerName, err)
+	}
+	return &AzureStore{container: client}, nil
+}
+
+func (s *AzureStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	opts := &blockblob.UploadStreamOptions{}
+	if contentType != "" {
+		opts.HTTPHeaders = &blob.HTTPHeaders{BlobContentType: &contentType}
+	}
+	if _, err := s.container.NewBlockBlobClient(key).UploadStream(ctx, r, opts); err != nil {
+		return fmt.Errorf("subir blob: %w", err)
+	}
+	return nil
+}
+
+func (s *AzureStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.container.NewBlobClient(key).DownloadStream(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("descargar blob: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (s *AzureStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.container.NewBlobClient(key).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("consultar blob: %w", err)
+	}
+	return true, nil
+}
+
+func (s *AzureStore) Delete(ctx context.Context, key string) error {
+	_, err := s.container.NewBlobClient(key).Delete(ctx, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("eliminar blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package blob implementa ports.BlobStore sobre el sistema de archivos local
+// y sobre Azure Blob Storage (o el emulador Azurite).
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"uniflow-api/internal/domain"
+)
+
+// FSStore guarda cada blob como un archivo bajo un directorio raíz. Sirve
+// para desarrollo y despliegues de un solo nodo.
+type FSStore struct {
+	root string
+}
+
+// NewFSStore crea el directorio raíz si no existe
+func NewFSStore(root string) (*FSStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("crear directorio de adjuntos: %w", err)
+	}
+	return &FSStore{root: root}, nil
+}
+
+// path ruta del archivo de key; rechaza claves que salgan de la raíz
+func (s *FSStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == "." || strings.HasPrefix(clean, "..") {
+		return "", fmt.Errorf("clave de blob inválida: %q", key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
+
+// Put escribe en un archivo temporal y lo renombra, así una lectura
+// concurrente nunca ve un archivo a medias
+func (s *FSStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return fmt.Errorf("crear directorio de blob: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("crear blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("escribir blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("escribir blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("guardar blob: %w", err)
+	}
+	return nil
+}
+
+func (s *FSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("abrir blob: %w", err)
+	}
+	return f, nil
+}
+
+func (s *FSStore) Exists(ctx context.Context, key string) (bool, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("consultar blob: %w", err)
+	}
+	return true, nil
+}
+
+func (s *FSStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("eliminar blob: %w", err)
+	}
+	return nil
+}
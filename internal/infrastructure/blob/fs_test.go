@@ -0,0 +1,55 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestFSStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := store.Exists(ctx, "sha256/ab/abc"); err != nil || ok {
+		t.Fatalf("expected missing blob, got %v %v", ok, err)
+	}
+	if err := store.Put(ctx, "sha256/ab/abc", strings.NewReader("hola"), 4, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := store.Exists(ctx, "sha256/ab/abc"); !ok {
+		t.Fatalf("expected stored blob")
+	}
+
+	r, err := store.Get(ctx, "sha256/ab/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "hola" {
+		t.Errorf("unexpected content %q", data)
+	}
+
+	if err := store.Delete(ctx, "sha256/ab/abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(ctx, "sha256/ab/abc"); err != nil {
+		t.Errorf("deleting a missing blob should not fail: %v", err)
+	}
+	if _, err := store.Get(ctx, "sha256/ab/abc"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"", "../fuera", "/etc/passwd"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("expected invalid key error for %q", key)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+
+	"uniflow-api/internal/domain"
+)
+
+// localBufferSize eventos pendientes antes de descartar
+const localBufferSize = 1024
+
+// ErrBufferFull el receptor no consume los eventos a tiempo
+var ErrBufferFull = errors.New("broadcaster en proceso: buffer lleno, evento descartado")
+
+// Local implementa ports.EventBroadcaster dentro del proceso.
+// Solo sirve con una réplica: los eventos no salen del nodo.
+type Local struct {
+	events chan domain.TaskEvent
+}
+
+// NewLocal crea un broadcaster en proceso
+func NewLocal() *Local {
+	return &Local{events: make(chan domain.TaskEvent, localBufferSize)}
+}
+
+// Broadcast encola el evento sin bloquear al caso de uso
+func (l *Local) Broadcast(ctx context.Context, event domain.TaskEvent) error {
+	select {
+	case l.events <- event:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// Run entrega los eventos encolados hasta que ctx se cancele
+func (l *Local) Run(ctx context.Context, deliver func(domain.TaskEvent)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-l.events:
+			deliver(event)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+// countingRepo cuenta las lecturas que llegan al repositorio real; si gate
+// no es nil, el dashboard espera a que se cierre
+type countingRepo struct {
+	ports.TaskRepository
+	dashboards, finds atomic.Int64
+	gate              chan struct{}
+}
+
+func (c *countingRepo) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
+	c.dashboards.Add(1)
+	if c.gate != nil {
+		<-c.gate
+	}
+	if err := ctx.Err(); err != nil {
+		return domain.DashboardData{}, err
+	}
+	return c.TaskRepository.GetDashboardStats(ctx, userID, opts)
+}
+
+func (c *countingRepo) FindByFilter(ctx context.Context, f ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	c.finds.Add(1)
+	return c.TaskRepository.FindByFilter(ctx, f)
+}
+
+func newCached() (*TaskRepository, *countingRepo) {
+	inner := &countingRepo{TaskRepository: memory.NewRepo()}
+	return NewTaskRepository(inner, NewLRU(100), Config{}), inner
+}
+
+func newTask(userID, title string) *domain.Task {
+	return &domain.Task{UserID: userID, Title: title, DueDate: time.Now().Add(24 * time.Hour),
+		Status: domain.StatusTodo, Priority: domain.PriorityMedium, Type: domain.TypeAssignment, Tags: []string{}}
+}
+
+func TestReadThroughAndInvalidation(t *testing.T) {
+	ctx := context.Background()
+	repo, inner := newCached()
+	_ = repo.Create(ctx, newTask("u1", "A"))
+	_ = repo.Create(ctx, newTask("u2", "B"))
+
+	filter := domain.TaskFilter{UserID: "u1", Limit: 10}
+	for i := 0; i < 3; i++ {
+		tasks, _, err := repo.FindByFilter(ctx, filter)
+		if err != nil || len(tasks) != 1 || tasks[0].UserID != "u1" || tasks[0].Tags == nil {
+			t.Fatalf("unexpected result: %+v %v", tasks, err)
+		}
+	}
+	if inner.finds.Load() != 1 {
+		t.Errorf("expected 1 query, got %d", inner.finds.Load())
+	}
+
+	// Otro filtro es otra entrada
+	_, _, _ = repo.FindByFilter(ctx, domain.TaskFilter{UserID: "u1", Limit: 5})
+	if inner.finds.Load() != 2 {
+		t.Errorf("expected a miss for a different filter, got %d queries", inner.finds.Load())
+	}
+
+	// Una mutación de otro usuario no invalida u1
+	_ = repo.Create(ctx, newTask("u2", "C"))
+	_, _, _ = repo.FindByFilter(ctx, filter)
+	if inner.finds.Load() != 2 {
+		t.Errorf("u2 mutation invalidated u1")
+	}
+
+	// Una de u1 sí
+	_ = repo.Create(ctx, newTask("u1", "D"))
+	tasks, _, _ := repo.FindByFilter(ctx, filter)
+	if inner.finds.Load() != 3 || len(tasks) != 2 {
+		t.Errorf("expected fresh result after create: %d queries, %d tasks", inner.finds.Load(), len(tasks))
+	}
+
+	s := repo.Stats()
+	if s.Hits != 3 || s.Misses != 3 || s.Invalidations != 4 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestDashboardIsCoalesced(t *testing.T) {
+	repo, inner := newCached()
+	inner.gate = make(chan struct{})
+	_ = repo.Create(context.Background(), newTask("u1", "A"))
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make(chan int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d, err := repo.GetDashboardStats(context.Background(), "u1", domain.DashboardOptions{})
+			if err != nil {
+				t.Error(err)
+			}
+			results <- d.TodoCount
+		}()
+	}
+	// Esperar a que todos estén en vuelo antes de liberar la consulta
+	deadline := time.Now().Add(2 * time.Second)
+	for repo.Stats().Misses < callers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(inner.gate)
+	wg.Wait()
+	close(results)
+
+	for todo := range results {
+		if todo != 1 {
+			t.Errorf("unexpected dashboard todo count %d", todo)
+		}
+	}
+	if inner.dashboards.Load() != 1 {
+		t.Errorf("expected one repository call, got %d", inner.dashboards.Load())
+	}
+	if s := repo.Stats(); s.Coalesced != callers {
+		t.Errorf("expected %d coalesced calls, got %+v", callers, s)
+	}
+}
+
+func TestCoalescedReadSurvivesFirstCallerCancel(t *testing.T) {
+	repo, inner := newCached()
+	inner.gate = make(chan struct{})
+	_ = repo.Create(context.Background(), newTask("u1", "A"))
+
+	// El primero inicia la consulta compartida y se desconecta
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.GetDashboardStats(first, "u1", domain.DashboardOptions{})
+		firstErr <- err
+	}()
+	for repo.Stats().Misses < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan error, 1)
+	go func() {
+		d, err := repo.GetDashboardStats(context.Background(), "u1", domain.DashboardOptions{})
+		if err == nil && d.TodoCount != 1 {
+			t.Errorf("unexpected dashboard %+v", d)
+		}
+		second <- err
+	}()
+	for repo.Stats().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("cancelled caller got %v", err)
+	}
+	close(inner.gate)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller failed after the first one cancelled: %v", err)
+	}
+	if inner.dashboards.Load() != 1 {
+		t.Errorf("expected one repository call, got %d", inner.dashboards.Load())
+	}
+}
+
+func TestLRUEvictionAndTTL(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(2)
+	_ = l.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = l.Set(ctx, "b", []byte("2"), time.Minute)
+	_, _, _ = l.Get(ctx, "a") // b queda como la menos usada
+	_ = l.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := l.Get(ctx, "b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if v, ok, _ := l.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Error("a should survive")
+	}
+
+	_ = l.Set(ctx, "short", []byte("x"), -time.Second)
+	if _, ok, _ := l.Get(ctx, "short"); ok {
+		t.Error("expired entry returned")
+	}
+	// short desplazó a c y al vencer se descartó
+	if l.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", l.Len())
+	}
+}
@@ -0,0 +1,242 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Valores por defecto de Redis
+const (
+	redisDialTimeout = 5 * time.Second
+	redisOpTimeout   = 2 * time.Second // Por operación si el ctx no trae deadline
+	redisPoolSize    = 16
+)
+
+// Redis backend en un servidor Redis (o compatible: Valkey, Azure Cache for
+// Redis) compartido por todas las réplicas. La generación de cada usuario
+// también vive ahí, así una mutación atendida por un nodo invalida la caché
+// de todos. Habla RESP directamente (solo GET y SET con PX) y reutiliza las
+// conexiones con un pool simple.
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	tls      *tls.Config
+	pool     chan *redisConn
+}
+
+var _ Backend = (*Redis)(nil)
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// NewRedis crea el backend a partir de una URL redis://[:password@]host:port[/db]
+// (rediss:// para TLS, como en Azure Cache for Redis en el puerto 6380).
+// No abre conexiones hasta la primera operación.
+func NewRedis(rawURL string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("URL de Redis inválida: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("URL de Redis inválida: esquema %q (redis:// o rediss://)", u.Scheme)
+	}
+
+	r := &Redis{addr: u.Host, pool: make(chan *redisConn, redisPoolSize)}
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.Scheme == "rediss" {
+		r.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.User != nil {
+		r.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if r.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("URL de Redis inválida: base %q", db)
+		}
+	}
+	return r, nil
+}
+
+// Get devuelve el valor si existe
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var (
+		value []byte
+		isNil bool
+	)
+	err := r.do(ctx, func(c *redisConn) (err error) {
+		value, isNil, err = c.call("GET", key)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return value, !isNil, nil
+}
+
+// Set guarda el valor con vencimiento ttl (SET ... PX)
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ms := ttl.Milliseconds()
+	if ms <= 0 {
+		ms = 1
+	}
+	return r.do(ctx, func(c *redisConn) error {
+		_, _, err := c.call("SET", key, string(value), "PX", strconv.FormatInt(ms, 10))
+		return err
+	})
+}
+
+// Close cierra las conexiones del pool
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.pool:
+			_ = c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do ejecuta fn sobre una conexión del pool. Una conexión que falló (red,
+// protocolo o timeout) se descarta: puede tener respuestas a medio leer.
+func (r *Redis) do(ctx context.Context, fn func(*redisConn) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c, err := r.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisOpTimeout)
+	}
+	_ = c.conn.SetDeadline(deadline)
+
+	if err := fn(c); err != nil {
+		var replyErr redisError
+		if errors.As(err, &replyErr) {
+			r.release(c) // Error del servidor: la conexión sigue sincronizada
+		} else {
+			_ = c.conn.Close()
+		}
+		return err
+	}
+	r.release(c)
+	return nil
+}
+
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.pool:
+		return c, nil
+	default:
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, redisDialTimeout)
+	defer cancel()
+	var (
+		conn net.Conn
+		err  error
+	)
+	if r.tls != nil {
+		conn, err = (&tls.Dialer{Config: r.tls}).DialContext(dialCtx, "tcp", r.addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(dialCtx, "tcp", r.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	if deadline, ok := dialCtx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if r.password != "" {
+		if _, _, err := c.call("AUTH", r.password); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, _, err := c.call("SELECT", strconv.Itoa(r.db)); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (r *Redis) release(c *redisConn) {
+	select {
+	case r.pool <- c:
+	default:
+		_ = c.conn.Close()
+	}
+}
+
+// redisError respuesta de error del servidor (-ERR ...)
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// call envía un comando como arreglo de bulk strings y lee la respuesta.
+// isNil indica un bulk string nulo (GET de una clave inexistente).
+func (c *redisConn) call(args ...string) (value []byte, isNil bool, err error) {
+	fmt.Fprintf(c.w, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(c.w, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, false, fmt.Errorf("redis: %w", err)
+	}
+	return c.reply()
+}
+
+func (c *redisConn) reply() ([]byte, bool, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, false, fmt.Errorf("redis: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, false, fmt.Errorf("redis: respuesta vacía")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), false, nil
+	case '-':
+		return nil, false, redisError(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, false, fmt.Errorf("redis: longitud inválida %q", line)
+		}
+		if n < 0 {
+			return nil, true, nil
+		}
+		buf := make([]byte, n+2) // + \r\n
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, false, fmt.Errorf("redis: %w", err)
+		}
+		return buf[:n], false, nil
+	}
+	return nil, false, fmt.Errorf("redis: respuesta no soportada %q", line)
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+// fakeRedis servidor RESP mínimo (AUTH, SELECT, GET, SET ... PX) para
+// probar el backend sin un Redis real. Las bases (SELECT) son independientes.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu   sync.Mutex
+	data map[string]fakeEntry
+}
+
+type fakeEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeRedis{ln: ln, password: password, data: make(map[string]fakeEntry)}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedis) url(password string, db int) string {
+	if password != "" {
+		return fmt.Sprintf("redis://:%s@%s/%d", password, s.ln.Addr(), db)
+	}
+	return fmt.Sprintf("redis://%s/%d", s.ln.Addr(), db)
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed, db := s.password == "", "0"
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		var reply string
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			authed = args[1] == s.password
+			reply = "+OK\r\n"
+			if !authed {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case cmd == "SELECT":
+			db, reply = args[1], "+OK\r\n"
+		case cmd == "GET":
+			s.mu.Lock()
+			e, ok := s.data[db+":"+args[1]]
+			s.mu.Unlock()
+			if !ok || time.Now().After(e.expiresAt) {
+				reply = "$-1\r\n"
+			} else {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(e.value), e.value)
+			}
+		case cmd == "SET" && len(args) == 5 && strings.ToUpper(args[3]) == "PX":
+			ms, _ := strconv.Atoi(args[4])
+			s.mu.Lock()
+			s.data[db+":"+args[1]] = fakeEntry{args[2], time.Now().Add(time.Duration(ms) * time.Millisecond)}
+			s.mu.Unlock()
+			reply = "+OK\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func TestRedisBackend(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeRedis(t, "secret")
+	r, err := NewRedis(srv.url("secret", 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, ok, err := r.Get(ctx, "missing"); ok || err != nil {
+		t.Fatalf("expected a miss, got ok=%v err=%v", ok, err)
+	}
+	value := []byte("{\"a\":1}\r\nbinario\x00")
+	if err := r.Set(ctx, "k", value, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := r.Get(ctx, "k"); !ok || err != nil || string(got) != string(value) {
+		t.Fatalf("Get = %q %v %v", got, ok, err)
+	}
+
+	// Otra base no ve la clave
+	other, _ := NewRedis(srv.url("secret", 3))
+	defer other.Close()
+	if _, ok, _ := other.Get(ctx, "k"); ok {
+		t.Error("SELECT ignored")
+	}
+
+	_ = r.Set(ctx, "short", []byte("x"), 20*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	if _, ok, _ := r.Get(ctx, "short"); ok {
+		t.Error("expected expiry")
+	}
+
+	wrong, _ := NewRedis(srv.url("nope", 0))
+	defer wrong.Close()
+	if _, _, err := wrong.Get(ctx, "k"); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("expected auth error, got %v", err)
+	}
+
+	if _, err := NewRedis("http://localhost:6379"); err == nil {
+		t.Error("expected scheme error")
+	}
+}
+
+// Dos réplicas con el mismo Redis: una mutación en una invalida la otra
+func TestRedisSharedInvalidation(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeRedis(t, "")
+	backend := func() Backend {
+		r, err := NewRedis(srv.url("", 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.Close() })
+		return r
+	}
+
+	inner := &countingRepo{TaskRepository: memory.NewRepo()}
+	a := NewTaskRepository(inner, backend(), Config{})
+	b := NewTaskRepository(inner, backend(), Config{})
+	_ = a.Create(ctx, newTask("u1", "A"))
+
+	if d, err := a.GetDashboardStats(ctx, "u1", domain.DashboardOptions{}); err != nil || d.TodoCount != 1 {
+		t.Fatalf("unexpected dashboard %+v %v", d, err)
+	}
+	if d, _ := b.GetDashboardStats(ctx, "u1", domain.DashboardOptions{}); d.TodoCount != 1 || inner.dashboards.Load() != 1 {
+		t.Fatalf("replica b should hit the shared entry: %d queries", inner.dashboards.Load())
+	}
+
+	_ = b.Create(ctx, newTask("u1", "B"))
+	if d, _ := a.GetDashboardStats(ctx, "u1", domain.DashboardOptions{}); d.TodoCount != 2 {
+		t.Errorf("replica a served stale data after b's mutation: %+v", d)
+	}
+}
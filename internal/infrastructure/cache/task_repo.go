@@ -0,0 +1,330 @@
+// Package cache implementa un decorador read-through de
+// ports.TaskRepository para las lecturas más frecuentes (dashboard y
+// listados), con claves por usuario que se invalidan en cada mutación.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Valores por defecto de Config
+const (
+	DefaultTTL      = 30 * time.Second
+	DefaultCapacity = 10000
+)
+
+// sharedLoadTimeout límite de una consulta compartida entre lecturas
+// concurrentes; no depende del contexto del llamador que la inició
+const sharedLoadTimeout = 30 * time.Second
+
+// generationTTL vida de la generación de un usuario; mayor que cualquier
+// TTL de datos para que no se pierda mientras quedan entradas suyas
+const generationTTL = 24 * time.Hour
+
+// Backend almacenamiento de las entradas. NewLRU es la implementación en
+// proceso; uno externo (Redis, Memcached) permite compartir la caché, y las
+// invalidaciones, entre réplicas.
+type Backend interface {
+	// Get devuelve el valor y si existía (un error se trata como miss)
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set guarda el valor durante ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Config parámetros del decorador
+type Config struct {
+	TTL time.Duration // Vida de cada entrada; acota lo que depende de la hora (vencidas, hoy)
+}
+
+// Stats métricas acumuladas desde el arranque
+type Stats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Coalesced     int64 `json:"coalesced"`     // Lecturas resueltas por una consulta compartida con otras concurrentes
+	Invalidations int64 `json:"invalidations"` // Mutaciones que invalidaron la caché de un usuario
+	Errors        int64 `json:"errors"`        // Fallos del backend (la lectura va al repositorio)
+}
+
+// TaskRepository decora un ports.TaskRepository. Las claves incluyen una
+// generación por usuario: cualquier Create/Update/Delete la cambia y las
+// entradas anteriores dejan de usarse (vencen solas por TTL). Las consultas
+// idénticas concurrentes se resuelven con una sola llamada al repositorio.
+type TaskRepository struct {
+	ports.TaskRepository
+	backend Backend
+	ttl     time.Duration
+	group   singleflight.Group
+
+	hits, misses, coalesced, invalidations, errors atomic.Int64
+}
+
+var _ ports.TaskRepository = (*TaskRepository)(nil)
+
+// NewTaskRepository envuelve repo con la caché
+func NewTaskRepository(repo ports.TaskRepository, backend Backend, cfg Config) *TaskRepository {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	return &TaskRepository{TaskRepository: repo, backend: backend, ttl: cfg.TTL}
+}
+
+// Stats métricas de aciertos y fallos
+func (r *TaskRepository) Stats() Stats {
+	return Stats{
+		Hits:          r.hits.Load(),
+		Misses:        r.misses.Load(),
+		Coalesced:     r.coalesced.Load(),
+		Invalidations: r.invalidations.Load(),
+		Errors:        r.errors.Load(),
+	}
+}
+
+func generationKey(userID string) string {
+	return "tasks:" + userID + ":gen"
+}
+
+// generation generación actual del usuario. Si no existe (primera lectura o
+// descartada por el LRU) se crea una nueva, así nunca se reutilizan
+// entradas de una generación anterior.
+func (r *TaskRepository) generation(ctx context.Context, userID string) (string, error) {
+	gen, ok, err := r.backend.Get(ctx, generationKey(userID))
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return string(gen), nil
+	}
+	return r.newGeneration(ctx, userID)
+}
+
+func (r *TaskRepository) newGeneration(ctx context.Context, userID string) (string, error) {
+	gen := strconv.FormatInt(time.Now().UnixNano(), 36)
+	return gen, r.backend.Set(ctx, generationKey(userID), []byte(gen), generationTTL)
+}
+
+// invalidate descarta la caché del usuario tras una mutación
+func (r *TaskRepository) invalidate(ctx context.Context, userID string) {
+	r.invalidations.Add(1)
+	if _, err := r.newGeneration(ctx, userID); err != nil {
+		r.errors.Add(1)
+		log.Printf("⚠️ Caché: no se pudo invalidar al usuario %s: %v", userID, err)
+	}
+}
+
+// key clave de una lectura: usuario, generación, operación y hash de los parámetros
+func key(userID, gen, op string, params any) string {
+	raw, _ := json.Marshal(params)
+	sum := sha256.Sum256(raw)
+	return "tasks:" + userID + ":" + gen + ":" + op + ":" + hex.EncodeToString(sum[:16])
+}
+
+// cached lectura read-through: backend, o load (una sola vez por clave
+// entre llamadas concurrentes) y guardado del resultado en out. La consulta
+// compartida corre sobre un contexto propio: si el llamador que la inició
+// cancela, los demás siguen esperando el resultado; cada uno deja de
+// esperar cuando se cancela su propio ctx.
+func (r *TaskRepository) cached(ctx context.Context, userID, op string, params any, out any, load func(context.Context) (any, error)) error {
+	gen, err := r.generation(ctx, userID)
+	if err != nil {
+		r.errors.Add(1)
+		v, err := load(ctx)
+		if err != nil {
+			return err
+		}
+		return remarshal(v, out)
+	}
+
+	k := key(userID, gen, op, params)
+	if raw, ok, err := r.backend.Get(ctx, k); err != nil {
+		r.errors.Add(1)
+	} else if ok && json.Unmarshal(raw, out) == nil {
+		r.hits.Add(1)
+		return nil
+	}
+	r.misses.Add(1)
+
+	ch := r.group.DoChan(k, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedLoadTimeout)
+		defer cancel()
+
+		v, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.backend.Set(loadCtx, k, raw, r.ttl); err != nil {
+			r.errors.Add(1)
+		}
+		return raw, nil
+	})
+
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res = <-ch:
+	}
+	if res.Shared {
+		r.coalesced.Add(1)
+	}
+	if res.Err != nil {
+		return res.Err
+	}
+	// Cada llamador decodifica su copia: los resultados no se comparten
+	return json.Unmarshal(res.Val.([]byte), out)
+}
+
+func remarshal(v, out any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// page resultado cacheado de un listado. UserID no se serializa en JSON
+// (json:"-") y se restaura con el del filtro.
+type page struct {
+	Tasks []domain.Task
+	Info  domain.PageInfo
+}
+
+func withUser(tasks []domain.Task, userID string) []domain.Task {
+	for i := range tasks {
+		tasks[i].UserID = userID
+	}
+	return tasks
+}
+
+func (r *TaskRepository) cachedPage(ctx context.Context, op string, f domain.TaskFilter, load func(context.Context) ([]domain.Task, domain.PageInfo, error)) ([]domain.Task, domain.PageInfo, error) {
+	var p page
+	err := r.cached(ctx, f.UserID, op, f, &p, func(ctx context.Context) (any, error) {
+		tasks, info, err := load(ctx)
+		return page{Tasks: tasks, Info: info}, err
+	})
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	return withUser(p.Tasks, f.UserID), p.Info, nil
+}
+
+// GetDashboardStats dashboard cacheado por usuario y opciones
+func (r *TaskRepository) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
+	var d domain.DashboardData
+	err := r.cached(ctx, userID, "dashboard", dashboardParams(opts), &d, func(ctx context.Context) (any, error) {
+		return r.TaskRepository.GetDashboardStats(ctx, userID, opts)
+	})
+	return d, err
+}
+
+// dashboardParams opciones del dashboard como parte de la clave
+// (*time.Location no se serializa en JSON)
+func dashboardParams(opts domain.DashboardOptions) any {
+	loc := "UTC"
+	if opts.Location != nil {
+		loc = opts.Location.String()
+	}
+	return struct {
+		Location, WeekStart string
+		Upcoming, Today     int
+	}{loc, opts.WeekStart, opts.UpcomingLimit, opts.TodayLimit}
+}
+
+// GetAll tareas del usuario, cacheadas
+func (r *TaskRepository) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
+	var tasks []domain.Task
+	err := r.cached(ctx, userID, "all", nil, &tasks, func(ctx context.Context) (any, error) {
+		return r.TaskRepository.GetAll(ctx, userID)
+	})
+	return withUser(tasks, userID), err
+}
+
+// FindByFilter listado cacheado por filtro
+func (r *TaskRepository) FindByFilter(ctx context.Context, f ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.cachedPage(ctx, "findByFilter", f, func(ctx context.Context) ([]domain.Task, domain.PageInfo, error) {
+		return r.TaskRepository.FindByFilter(ctx, f)
+	})
+}
+
+// Find listado cacheado por filtro
+func (r *TaskRepository) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.cachedPage(ctx, "find", f, func(ctx context.Context) ([]domain.Task, domain.PageInfo, error) {
+		return r.TaskRepository.Find(ctx, f)
+	})
+}
+
+// Search búsqueda cacheada por filtro
+func (r *TaskRepository) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.cachedPage(ctx, "search", f, func(ctx context.Context) ([]domain.Task, domain.PageInfo, error) {
+		return r.TaskRepository.Search(ctx, f)
+	})
+}
+
+// Create inserta e invalida la caché del usuario
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	err := r.TaskRepository.Create(ctx, task)
+	if err == nil {
+		r.invalidate(ctx, task.UserID)
+	}
+	return err
+}
+
+// Update actualiza e invalida la caché del usuario
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	err := r.TaskRepository.Update(ctx, task)
+	if err == nil {
+		r.invalidate(ctx, task.UserID)
+	}
+	return err
+}
+
+// UpdateStatus actualiza e invalida la caché del usuario
+func (r *TaskRepository) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	err := r.TaskRepository.UpdateStatus(ctx, task)
+	if err == nil {
+		r.invalidate(ctx, task.UserID)
+	}
+	return err
+}
+
+// UpdateShared actualiza e invalida la caché del usuario
+func (r *TaskRepository) UpdateShared(ctx context.Context, task *domain.Task) error {
+	err := r.TaskRepository.UpdateShared(ctx, task)
+	if err == nil {
+		r.invalidate(ctx, task.UserID)
+	}
+	return err
+}
+
+// DeleteShared elimina e invalida la caché del usuario
+func (r *TaskRepository) DeleteShared(ctx context.Context, taskID, userID string) error {
+	err := r.TaskRepository.DeleteShared(ctx, taskID, userID)
+	if err == nil {
+		r.invalidate(ctx, userID)
+	}
+	return err
+}
+
+// Delete elimina e invalida la caché del usuario
+func (r *TaskRepository) Delete(ctx context.Context, taskID, userID string) error {
+	err := r.TaskRepository.Delete(ctx, taskID, userID)
+	if err == nil {
+		r.invalidate(ctx, userID)
+	}
+	return err
+}
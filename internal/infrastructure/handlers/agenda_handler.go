@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgendaDTO respuesta de GET /tasks/agenda
+type AgendaDTO struct {
+	TimeZone      string           `json:"timezone"`
+	From          string           `json:"from"` // Primer día (hoy), 2006-01-02
+	To            string           `json:"to"`   // Último día incluido
+	CapacityHours int              `json:"capacityHours"`
+	Overdue       AgendaSectionDTO `json:"overdue"`
+	Days          []AgendaDayDTO   `json:"days"`
+}
+
+// AgendaSectionDTO tareas de una sección de la agenda
+type AgendaSectionDTO struct {
+	Tasks          []TaskDTO `json:"tasks"`
+	Count          int       `json:"count"`
+	EstimatedHours int       `json:"estimatedHours"`
+}
+
+// AgendaDayDTO un día de la agenda (incluidos los vacíos)
+type AgendaDayDTO struct {
+	Date    string `json:"date"`    // 2006-01-02 en la zona del usuario
+	Weekday string `json:"weekday"` // monday..sunday
+	IsToday bool   `json:"isToday"`
+	// OverCapacity true si estimatedHours supera dailyCapacityHours
+	OverCapacity bool `json:"overCapacity"`
+	AgendaSectionDTO
+}
+
+func agendaSection(tasks []domain.Task, hours int) AgendaSectionDTO {
+	dtos := make([]TaskDTO, len(tasks))
+	for i := range tasks {
+		dtos[i] = TaskFromDomain(&tasks[i])
+	}
+	return AgendaSectionDTO{Tasks: dtos, Count: len(tasks), EstimatedHours: hours}
+}
+
+// AgendaFromDomain convierte domain.Agenda a AgendaDTO
+func AgendaFromDomain(a *domain.Agenda) AgendaDTO {
+	dto := AgendaDTO{
+		TimeZone:      a.Location.String(),
+		CapacityHours: a.CapacityHours,
+		Overdue:       agendaSection(a.Overdue, a.OverdueEstimatedHours),
+		Days:          make([]AgendaDayDTO, len(a.Days)),
+	}
+	for i, day := range a.Days {
+		dto.Days[i] = AgendaDayDTO{
+			Date:             day.Date.Format("2006-01-02"),
+			Weekday:          strings.ToLower(day.Date.Weekday().String()),
+			IsToday:          i == 0,
+			OverCapacity:     a.OverCapacity(day),
+			AgendaSectionDTO: agendaSection(day.Tasks, day.EstimatedHours),
+		}
+	}
+	if len(dto.Days) > 0 {
+		dto.From, dto.To = dto.Days[0].Date, dto.Days[len(dto.Days)-1].Date
+	}
+	return dto
+}
+
+// GetAgenda maneja GET /tasks/agenda?days=N&tz=
+// Tareas de los próximos N días (hoy incluido, 7 por defecto) agrupadas por
+// día local, con las vencidas pendientes primero y cada día marcado si
+// supera la capacidad diaria del usuario
+func (th *TaskHandler) GetAgenda(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	days := domain.DefaultAgendaDays
+	if d := c.Query("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > domain.MaxAgendaDays {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_DAYS", "days debe ser un número entre 1 y "+strconv.Itoa(domain.MaxAgendaDays)))
+			return
+		}
+		days = parsed
+	}
+
+	prefs, loc, ok := th.userPreferences(ctx, c, userID, c.Query("tz"))
+	if !ok {
+		return
+	}
+
+	agenda, err := th.taskService.GetAgenda(ctx, userID, loc, days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+	agenda.CapacityHours = prefs.DailyCapacityHours
+
+	c.JSON(http.StatusOK, AgendaFromDomain(agenda))
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestGetAgenda(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks/agenda", handler.GetAgenda)
+
+	cr, _ := time.LoadLocation("America/Costa_Rica")
+	today := domain.StartOfDay(time.Now(), cr)
+	for _, task := range []*domain.Task{
+		{Title: "Vencida", DueDate: today.Add(-20 * time.Hour), Status: domain.StatusTodo, EstimatedTimeHours: 2},
+		{Title: "Entregada", DueDate: today.Add(-20 * time.Hour), Status: domain.StatusDone},
+		{Title: "Hoy", DueDate: today.Add(23 * time.Hour), Status: domain.StatusInProgress, EstimatedTimeHours: 3},
+		{Title: "Pasado mañana", DueDate: today.AddDate(0, 0, 2).Add(8 * time.Hour), Status: domain.StatusTodo, EstimatedTimeHours: 1},
+		{Title: "Pasado mañana 2", DueDate: today.AddDate(0, 0, 2).Add(9 * time.Hour), Status: domain.StatusTodo, EstimatedTimeHours: 4},
+		{Title: "Fuera de rango", DueDate: today.AddDate(0, 0, 5), Status: domain.StatusTodo},
+	} {
+		task.UserID, task.SubjectID, task.Priority, task.Type = "user-test", "subject-1", domain.PriorityMedium, domain.TypeAssignment
+		if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := doSaved(r, "GET", "/tasks/agenda?days=3&tz=America/Costa_Rica", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var agenda AgendaDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &agenda)
+
+	if agenda.TimeZone != "America/Costa_Rica" || agenda.From != today.Format("2006-01-02") || len(agenda.Days) != 3 {
+		t.Fatalf("unexpected agenda header %+v", agenda)
+	}
+	if agenda.Overdue.Count != 1 || agenda.Overdue.Tasks[0].Title != "Vencida" || agenda.Overdue.EstimatedHours != 2 {
+		t.Errorf("unexpected overdue section %+v", agenda.Overdue)
+	}
+	wantCounts := []int{1, 0, 2}
+	wantHours := []int{3, 0, 5}
+	for i, day := range agenda.Days {
+		if day.Count != wantCounts[i] || day.EstimatedHours != wantHours[i] || day.Tasks == nil {
+			t.Errorf("day %s: %d tasks / %dh, want %d / %dh", day.Date, day.Count, day.EstimatedHours, wantCounts[i], wantHours[i])
+		}
+		if day.IsToday != (i == 0) {
+			t.Errorf("day %s: isToday=%v", day.Date, day.IsToday)
+		}
+	}
+	if agenda.Days[2].Tasks[0].Title != "Pasado mañana" {
+		t.Errorf("day tasks should be sorted by due date: %+v", agenda.Days[2].Tasks)
+	}
+
+	for _, q := range []string{"days=0", "days=32", "days=abc"} {
+		if w := doSaved(r, "GET", "/tasks/agenda?"+q, "", ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+	if w := doSaved(r, "GET", "/tasks/agenda?tz=Nowhere/City", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid tz, got %d", w.Code)
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentDTO adjunto con un enlace de descarga firmado y temporal
+type AttachmentDTO struct {
+	domain.Attachment
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// attachmentDTO firma el enlace de descarga (ruta pública
+// /attachments/:id/download, relativa a la URL base de la API)
+func (th *TaskHandler) attachmentDTO(a *domain.Attachment, now time.Time) AttachmentDTO {
+	expires, sig := th.taskService.SignAttachment(a.ID, now)
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	q.Set("sig", sig)
+	return AttachmentDTO{
+		Attachment: *a,
+		URL:        "/attachments/" + url.PathEscape(a.ID) + "/download?" + q.Encode(),
+		ExpiresAt:  expires,
+	}
+}
+
+// attachmentError responde según el tipo de error de los adjuntos
+func attachmentError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		switch de {
+		case domain.ErrTaskNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Tarea no encontrada"))
+			return
+		case domain.ErrAttachmentNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrForbidden:
+			c.JSON(http.StatusForbidden, NewErrorResponse(de.Code, "los colaboradores con rol viewer no pueden modificar los adjuntos"))
+			return
+		case domain.ErrAttachmentTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrAttachmentType:
+			c.JSON(http.StatusUnsupportedMediaType, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrAttachmentLimit:
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrAttachmentLinkInvalid:
+			c.JSON(http.StatusForbidden, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrAttachmentLinkExpired:
+			c.JSON(http.StatusGone, NewErrorResponse(de.Code, de.Message))
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// ListAttachments maneja GET /tasks/:id/attachments
+func (th *TaskHandler) ListAttachments(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	list, err := th.taskService.ListAttachments(ctx, c.Param("id"), userID)
+	if err != nil {
+		attachmentError(c, err)
+		return
+	}
+
+	now := time.Now()
+	dtos := make([]AttachmentDTO, 0, len(list))
+	for i := range list {
+		dtos = append(dtos, th.attachmentDTO(&list[i], now))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dtos, "count": len(dtos)})
+}
+
+// UploadAttachment maneja POST /tasks/:id/attachments (multipart, campo "file")
+// Responde 201 con el adjunto nuevo o 200 si la tarea ya tenía ese contenido
+func (th *TaskHandler) UploadAttachment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	// Margen para los encabezados del multipart
+	maxBytes := th.taskService.MaxAttachmentBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+64<<10)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			attachmentError(c, domain.ErrAttachmentTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", "se esperaba un archivo multipart en el campo 'file'"))
+		return
+	}
+	defer file.Close()
+
+	a, created, err := th.taskService.AddAttachment(ctx, c.Param("id"), userID, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		attachmentError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, th.attachmentDTO(a, time.Now()))
+}
+
+// GetAttachment maneja GET /tasks/:id/attachments/:attachmentId
+// Metadatos con un enlace de descarga nuevo
+func (th *TaskHandler) GetAttachment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	a, err := th.taskService.GetAttachment(ctx, c.Param("id"), c.Param("attachmentId"), userID)
+	if err != nil {
+		attachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, th.attachmentDTO(a, time.Now()))
+}
+
+// DeleteAttachment maneja DELETE /tasks/:id/attachments/:attachmentId
+func (th *TaskHandler) DeleteAttachment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.taskService.DeleteAttachment(ctx, c.Param("id"), c.Param("attachmentId"), userID); err != nil {
+		attachmentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DownloadAttachment maneja GET /attachments/:id/download?expires=&sig= (público,
+// autenticado por la firma del enlace)
+func (th *TaskHandler) DownloadAttachment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		attachmentError(c, domain.ErrAttachmentLinkInvalid)
+		return
+	}
+
+	a, body, err := th.taskService.OpenSignedAttachment(ctx, c.Param("id"), expires, c.Query("sig"), time.Now())
+	if err != nil {
+		attachmentError(c, err)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Type", a.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(a.Size, 10))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age="+strconv.Itoa(int(time.Until(time.Unix(expires, 0)).Seconds())))
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		log.Printf("⚠️ Descarga del adjunto %s interrumpida: %v", a.ID, err)
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/blob"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupAttachmentRouter(t *testing.T) (*gin.Engine, string) {
+	r, service := setupSavedFilterRouter(t)
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.WithInvitations(memory.NewInvitationRepo()).
+		WithAttachments(memory.NewAttachmentRepo(), store, application.AttachmentConfig{SigningKey: []byte("test-key"), MaxBytes: 1024})
+	h := NewTaskHandler(service)
+
+	r.GET("/tasks/:id", h.GetTaskByID)
+	r.DELETE("/tasks/:id", h.DeleteTask)
+	r.POST("/tasks/:id/collaborators", h.AddCollaborator)
+	r.GET("/tasks/:id/attachments", h.ListAttachments)
+	r.POST("/tasks/:id/attachments", h.UploadAttachment)
+	r.GET("/tasks/:id/attachments/:attachmentId", h.GetAttachment)
+	r.DELETE("/tasks/:id/attachments/:attachmentId", h.DeleteAttachment)
+	r.GET("/attachments/:id/download", h.DownloadAttachment)
+
+	task := &domain.Task{UserID: "user-test", Title: "Laboratorio", SubjectID: "subject-1", DueDate: time.Now().Add(72 * time.Hour),
+		Status: domain.StatusTodo, Priority: domain.PriorityHigh, Type: domain.TypeLab}
+	if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	return r, task.ID
+}
+
+// upload envía content como archivo multipart en el campo "file"
+func upload(r http.Handler, path, userID, fileName, contentType string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+fileName+`"`)
+	h.Set("Content-Type", contentType)
+	part, _ := mw.CreatePart(h)
+	_, _ = part.Write(content)
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n%%EOF\n")
+
+func TestAttachmentUploadAndSignedDownload(t *testing.T) {
+	r, taskID := setupAttachmentRouter(t)
+
+	w := upload(r, "/tasks/"+taskID+"/attachments", "", "../enunciado.pdf", "application/pdf", pdfContent)
+	var att AttachmentDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &att)
+	if w.Code != http.StatusCreated || att.FileName != "enunciado.pdf" || att.Size != int64(len(pdfContent)) || len(att.Checksum) != 64 {
+		t.Fatalf("unexpected upload %d %s", w.Code, w.Body.String())
+	}
+
+	// Mismo contenido: se reutiliza el adjunto
+	w = upload(r, "/tasks/"+taskID+"/attachments", "", "copia.pdf", "application/pdf", pdfContent)
+	var again AttachmentDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &again)
+	if w.Code != http.StatusOK || again.ID != att.ID {
+		t.Errorf("expected the existing attachment, got %d %s", w.Code, w.Body.String())
+	}
+
+	var task TaskDTO
+	w = doSaved(r, "GET", "/tasks/"+taskID, "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &task)
+	if len(task.Attachments) != 1 || task.Attachments[0] != att.ID {
+		t.Errorf("expected the attachment ID on the task, got %v", task.Attachments)
+	}
+
+	w = doSaved(r, "GET", att.URL, "", "")
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), pdfContent) || w.Header().Get("Content-Type") != "application/pdf" ||
+		!strings.Contains(w.Header().Get("Content-Disposition"), "enunciado.pdf") {
+		t.Fatalf("unexpected download %d %v", w.Code, w.Header())
+	}
+
+	// Firma alterada y enlace vencido
+	u, _ := url.Parse(att.URL)
+	q := u.Query()
+	q.Set("sig", strings.Repeat("0", 64))
+	w = doSaved(r, "GET", u.Path+"?"+q.Encode(), "", "")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a tampered link, got %d", w.Code)
+	}
+	q = u.Query()
+	q.Set("expires", "1")
+	w = doSaved(r, "GET", u.Path+"?"+q.Encode(), "", "")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 when expires is changed, got %d", w.Code)
+	}
+}
+
+func TestAttachmentLimits(t *testing.T) {
+	r, taskID := setupAttachmentRouter(t)
+
+	w := upload(r, "/tasks/"+taskID+"/attachments", "", "grande.txt", "text/plain", bytes.Repeat([]byte("a"), 2048))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d %s", w.Code, w.Body.String())
+	}
+	w = upload(r, "/tasks/"+taskID+"/attachments", "", "pagina.html", "text/html", []byte("<html></html>"))
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for html, got %d", w.Code)
+	}
+	// Declarado como PNG pero el contenido no lo es
+	w = upload(r, "/tasks/"+taskID+"/attachments", "", "foto.png", "image/png", []byte("no soy una imagen"))
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for a fake png, got %d", w.Code)
+	}
+	// Sin tipo declarado se deduce por la extensión
+	w = upload(r, "/tasks/"+taskID+"/attachments", "", "notas.txt", "application/octet-stream", []byte("apuntes"))
+	var att AttachmentDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &att)
+	if w.Code != http.StatusCreated || att.ContentType != "text/plain" {
+		t.Errorf("expected text/plain, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Un viewer no sube archivos
+	_ = doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "role": "viewer"}`)
+	var page struct {
+		Data []TaskDTO `json:"data"`
+	}
+	w = doSaved(r, "GET", "/tasks", "user-ana", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 1 {
+		t.Fatalf("expected the shared task, got %s", w.Body.String())
+	}
+	w = upload(r, "/tasks/"+page.Data[0].ID+"/attachments", "user-ana", "otro.txt", "text/plain", []byte("x"))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a viewer, got %d", w.Code)
+	}
+	// pero sí ve y descarga los de la tarea
+	w = doSaved(r, "GET", "/tasks/"+page.Data[0].ID+"/attachments/"+att.ID, "user-ana", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &att)
+	if w.Code != http.StatusOK || doSaved(r, "GET", att.URL, "", "").Code != http.StatusOK {
+		t.Errorf("expected the member to download, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAttachmentsDeletedWithTask(t *testing.T) {
+	r, taskID := setupAttachmentRouter(t)
+
+	w := upload(r, "/tasks/"+taskID+"/attachments", "", "enunciado.pdf", "application/pdf", pdfContent)
+	var att AttachmentDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &att)
+
+	w = doSaved(r, "DELETE", "/tasks/"+taskID+"/attachments/"+att.ID, "", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d %s", w.Code, w.Body.String())
+	}
+	if w = doSaved(r, "GET", att.URL, "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after deleting the attachment, got %d", w.Code)
+	}
+
+	w = upload(r, "/tasks/"+taskID+"/attachments", "", "enunciado.pdf", "application/pdf", pdfContent)
+	_ = json.Unmarshal(w.Body.Bytes(), &att)
+	if w = doSaved(r, "DELETE", "/tasks/"+taskID, "", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w = doSaved(r, "GET", att.URL, "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected the attachment purged with the task, got %d", w.Code)
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"uniflow-api/internal/infrastructure/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheHandler expone las métricas de la caché de tareas
+type CacheHandler struct {
+	cache *cache.TaskRepository
+}
+
+// NewCacheHandler crea un nuevo CacheHandler
+func NewCacheHandler(c *cache.TaskRepository) *CacheHandler {
+	return &CacheHandler{cache: c}
+}
+
+// GetStats maneja GET /admin/cache/stats
+func (h *CacheHandler) GetStats(c *gin.Context) {
+	stats := h.cache.Stats()
+	hitRate := 0.0
+	if total := stats.Hits + stats.Misses; total > 0 {
+		hitRate = float64(stats.Hits) / float64(total)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"stats":   stats,
+		"hitRate": hitRate,
+	})
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/infrastructure/cache"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+)
+
+func TestCacheStatsAfterDashboardRequests(t *testing.T) {
+	r, _, _ := setupTestRouter()
+	cached := cache.NewTaskRepository(memory.NewRepo(), cache.NewLRU(100), cache.Config{})
+	handler := NewTaskHandler(application.NewTaskService(cached, nil))
+	r.GET("/tasks/dashboard", handler.GetDashboard)
+	r.GET("/admin/cache/stats", NewCacheHandler(cached).GetStats)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/dashboard", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("dashboard: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/cache/stats", nil))
+	var body struct {
+		Stats   cache.Stats `json:"stats"`
+		HitRate float64     `json:"hitRate"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Stats.Hits != 1 || body.Stats.Misses != 1 || body.HitRate != 0.5 {
+		t.Errorf("unexpected stats %+v", body)
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/ical"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler maneja el feed ICS y sus tokens
+type CalendarHandler struct {
+	calendarService *application.CalendarService
+}
+
+// NewCalendarHandler crea un nuevo CalendarHandler
+func NewCalendarHandler(cs *application.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: cs,
+	}
+}
+
+// CreateCalendarTokenRequest estructura para POST /calendar/tokens
+type CreateCalendarTokenRequest struct {
+	Name string `json:"name"`
+}
+
+// CalendarTokenDTO representación de un token (sin el secreto)
+type CalendarTokenDTO struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	CreatedAt  string  `json:"createdAt"`
+	LastUsedAt *string `json:"lastUsedAt,omitempty"`
+	RevokedAt  *string `json:"revokedAt,omitempty"`
+}
+
+func calendarTokenFromDomain(t *domain.CalendarToken) CalendarTokenDTO {
+	dto := CalendarTokenDTO{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if t.LastUsedAt != nil {
+		s := t.LastUsedAt.Format("2006-01-02T15:04:05Z07:00")
+		dto.LastUsedAt = &s
+	}
+	if t.RevokedAt != nil {
+		s := t.RevokedAt.Format("2006-01-02T15:04:05Z07:00")
+		dto.RevokedAt = &s
+	}
+	return dto
+}
+
+// GetFeed maneja GET /calendar/:token.ics (público, autenticado por el token)
+// Query params opcionales: periodId, subjectId, type (comma-separated), component (event|todo)
+func (ch *CalendarHandler) GetFeed(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	param := c.Param("token")
+	if !strings.HasSuffix(param, ".ics") {
+		c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "feed no encontrado"))
+		return
+	}
+	token := strings.TrimSuffix(param, ".ics")
+
+	filter := domain.CalendarFeedFilter{
+		PeriodID:  c.Query("periodId"),
+		SubjectID: c.Query("subjectId"),
+	}
+	if types := c.Query("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			filter.Type = append(filter.Type, strings.TrimSpace(t))
+		}
+	}
+
+	component := ical.ComponentEvent
+	switch c.DefaultQuery("component", "event") {
+	case "event":
+	case "todo":
+		component = ical.ComponentTodo
+	default:
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_COMPONENT", "component debe ser 'event' o 'todo'"))
+		return
+	}
+
+	tasks, err := ch.calendarService.FeedTasks(ctx, token, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrCalendarTokenNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "feed no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="uniflow.ics"`)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+
+	opts := ical.EncodeOptions{
+		Component:    component,
+		CalendarName: "UniFlow",
+		Now:          time.Now(),
+	}
+	if err := ical.Encode(c.Writer, tasks, opts); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// CreateToken maneja POST /calendar/tokens
+// El token en claro solo se retorna en esta respuesta
+func (ch *CalendarHandler) CreateToken(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req CreateCalendarTokenRequest
+	// El body es opcional: un POST vacío crea un token con nombre por defecto
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	plain, token, err := ch.calendarService.CreateToken(ctx, userID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token":   plain,
+		"feedUrl": "/calendar/" + plain + ".ics",
+		"details": calendarTokenFromDomain(token),
+	})
+}
+
+// ListTokens maneja GET /calendar/tokens
+func (ch *CalendarHandler) ListTokens(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	tokens, err := ch.calendarService.ListTokens(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	dtos := make([]CalendarTokenDTO, len(tokens))
+	for i := range tokens {
+		dtos[i] = calendarTokenFromDomain(&tokens[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": dtos,
+		"count":  len(dtos),
+	})
+}
+
+// RevokeToken maneja DELETE /calendar/tokens/:id
+func (ch *CalendarHandler) RevokeToken(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := ch.calendarService.RevokeToken(ctx, c.Param("id"), userID); err != nil {
+		if errors.Is(err, domain.ErrCalendarTokenNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "token no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
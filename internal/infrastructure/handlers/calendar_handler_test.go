@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCalendarFeedLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	repo := memory.NewRepo()
+	calendarService := application.NewCalendarService(repo, memory.NewCalendarTokenRepo())
+	handler := NewCalendarHandler(calendarService)
+
+	_ = repo.Create(context.Background(), &domain.Task{
+		ID: "task-1", UserID: "user-test", Title: "Parcial", SubjectID: "calc",
+		Status: domain.StatusTodo, Priority: domain.PriorityHigh, Type: domain.TypeExam,
+		DueDate: time.Now().Add(48 * time.Hour),
+	})
+
+	// Feed público, registrado antes del middleware de auth
+	r.GET("/calendar/:token", handler.GetFeed)
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-test")
+		c.Next()
+	})
+	r.GET("/calendar/tokens", handler.ListTokens)
+	r.POST("/calendar/tokens", handler.CreateToken)
+	r.DELETE("/calendar/tokens/:id", handler.RevokeToken)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/calendar/tokens", strings.NewReader(`{"name":"Google"}`))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", w.Code)
+	}
+
+	var created struct {
+		FeedURL string           `json:"feedUrl"`
+		Details CalendarTokenDTO `json:"details"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", created.FeedURL+"?type=exam", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "UID:task-1@uniflow") {
+		t.Errorf("feed missing task: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/calendar/tokens/"+created.Details.ID, nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", created.FeedURL, nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after revoke, got %d", w.Code)
+	}
+}
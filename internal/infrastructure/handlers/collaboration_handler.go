@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// collaborationError responde según el tipo de error de las tareas compartidas
+func collaborationError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		switch de {
+		case domain.ErrTaskNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Tarea no encontrada"))
+			return
+		case domain.ErrCollaboratorNotFound, domain.ErrInvitationNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrForbidden:
+			c.JSON(http.StatusForbidden, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrInvalidRole:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrAlreadyCollaborator:
+			c.JSON(http.StatusConflict, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrCollaboratorLimit:
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(de.Code, de.Message))
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// ListCollaborators maneja GET /tasks/:id/collaborators
+// Dueño y colaboradores con su rol y progreso personal; el dueño ve además
+// las invitaciones pendientes
+func (th *TaskHandler) ListCollaborators(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	members, err := th.taskService.ListCollaborators(ctx, c.Param("id"), userID)
+	if err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": members, "count": len(members)})
+}
+
+// AddCollaborator maneja POST /tasks/:id/collaborators
+// Con userId el colaborador ve la tarea de inmediato; con email queda una
+// invitación pendiente hasta que la acepta
+func (th *TaskHandler) AddCollaborator(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.AddCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+	req.UserID = strings.TrimSpace(req.UserID)
+	if (req.UserID == "") == (req.Email == "") {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", "indicar userId o email (solo uno)"))
+		return
+	}
+
+	invitedBy := c.GetHeader("X-User-Name")
+	if invitedBy == "" {
+		invitedBy = c.GetHeader("X-User-Email")
+	}
+
+	member, err := th.taskService.AddCollaborator(ctx, c.Param("id"), userID, req.UserID, req.Email, req.Role, invitedBy)
+	if err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateCollaborator maneja PATCH /tasks/:id/collaborators/:member
+func (th *TaskHandler) UpdateCollaborator(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.UpdateCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	member, err := th.taskService.UpdateCollaboratorRole(ctx, c.Param("id"), userID, c.Param("member"), req.Role)
+	if err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveCollaborator maneja DELETE /tasks/:id/collaborators/:member
+// member es el userId del colaborador o el email de una invitación pendiente
+func (th *TaskHandler) RemoveCollaborator(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.taskService.RemoveCollaborator(ctx, c.Param("id"), userID, c.Param("member")); err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListInvitations maneja GET /me/invitations
+// Invitaciones pendientes para el email del usuario (X-User-Email)
+func (th *TaskHandler) ListInvitations(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, ok := getUserID(c); !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	invitations, err := th.taskService.ListInvitations(ctx, c.GetHeader("X-User-Email"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": invitations, "count": len(invitations)})
+}
+
+// AcceptInvitation maneja POST /me/invitations/:id/accept
+// Crea la copia del usuario: desde ahí aparece en su GET /tasks y su dashboard
+func (th *TaskHandler) AcceptInvitation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	task, err := th.taskService.AcceptInvitation(ctx, c.Param("id"), userID, c.GetHeader("X-User-Email"))
+	if err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, TaskFromDomain(task))
+}
+
+// DeclineInvitation maneja DELETE /me/invitations/:id
+func (th *TaskHandler) DeclineInvitation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, ok := getUserID(c); !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.taskService.DeclineInvitation(ctx, c.Param("id"), c.GetHeader("X-User-Email")); err != nil {
+		collaborationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
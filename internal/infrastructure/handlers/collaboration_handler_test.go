@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupCollaborationRouter(t *testing.T) (*gin.Engine, string) {
+	r, service := setupSavedFilterRouter(t)
+	service.WithInvitations(memory.NewInvitationRepo()).WithActivity(memory.NewActivityRepo())
+	h := NewTaskHandler(service)
+
+	r.GET("/tasks/:id", h.GetTaskByID)
+	r.PUT("/tasks/:id", h.UpdateTask)
+	r.PATCH("/tasks/:id/status", h.UpdateTaskStatus)
+	r.DELETE("/tasks/:id", h.DeleteTask)
+	r.GET("/tasks/:id/collaborators", h.ListCollaborators)
+	r.POST("/tasks/:id/collaborators", h.AddCollaborator)
+	r.PATCH("/tasks/:id/collaborators/:member", h.UpdateCollaborator)
+	r.DELETE("/tasks/:id/collaborators/:member", h.RemoveCollaborator)
+	r.GET("/me/invitations", h.ListInvitations)
+	r.POST("/me/invitations/:id/accept", h.AcceptInvitation)
+	r.DELETE("/me/invitations/:id", h.DeclineInvitation)
+	r.GET("/tasks/:id/comments", h.ListComments)
+	r.POST("/tasks/:id/comments", h.AddComment)
+	r.PATCH("/tasks/:id/comments/:commentId", h.UpdateComment)
+	r.DELETE("/tasks/:id/comments/:commentId", h.DeleteComment)
+	r.GET("/tasks/:id/activity", h.GetActivity)
+
+	task := &domain.Task{UserID: "user-test", Title: "Informe grupal", SubjectID: "subject-1", DueDate: time.Now().Add(72 * time.Hour),
+		Status: domain.StatusTodo, Priority: domain.PriorityHigh, Type: domain.TypeAssignment}
+	if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	return r, task.ID
+}
+
+// doAs como doSaved, con el email del usuario autenticado
+func doAs(r http.Handler, method, path, userID, email, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID)
+	req.Header.Set("X-User-Email", email)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// sharedCopy tarea compartida taskID en el listado de userID
+func sharedCopy(t *testing.T, r http.Handler, userID, taskID string) *TaskDTO {
+	t.Helper()
+	var page struct {
+		Data []TaskDTO `json:"data"`
+	}
+	w := doSaved(r, "GET", "/tasks", userID, "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	for i := range page.Data {
+		if page.Data[i].SharedTaskID == taskID {
+			return &page.Data[i]
+		}
+	}
+	return nil
+}
+
+const updateBody = `{"title": "%s", "subjectId": "subject-1", "dueDate": "2030-01-10T00:00:00Z", "priority": "high", "type": "assignment"}`
+
+func TestShareTaskByUserID(t *testing.T) {
+	r, taskID := setupCollaborationRouter(t)
+
+	w := doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "role": "editor"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "role": "viewer"}`)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a duplicate collaborator, got %d", w.Code)
+	}
+
+	shared := sharedCopy(t, r, "user-ana", taskID)
+	if shared == nil || shared.Title != "Informe grupal" || shared.Role != domain.RoleEditor {
+		t.Fatalf("expected the shared task in the member's list, got %+v", shared)
+	}
+	var dashboard domain.DashboardData
+	w = doSaved(r, "GET", "/tasks/dashboard", "user-ana", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &dashboard)
+	if dashboard.TotalPending != 1 {
+		t.Errorf("expected the shared task in the member's dashboard, got %s", w.Body.String())
+	}
+
+	// El estado es personal
+	w = doSaved(r, "PATCH", "/tasks/"+shared.ID+"/status", "user-ana", `{"status": "in-progress"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	var owned TaskDTO
+	w = doSaved(r, "GET", "/tasks/"+taskID, "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &owned)
+	if owned.Status != domain.StatusTodo || owned.Role != domain.RoleOwner || !owned.IsGroupWork {
+		t.Errorf("unexpected owner task %+v", owned)
+	}
+
+	// Lo que edita un editor llega al dueño y al resto
+	w = doSaved(r, "PUT", "/tasks/"+shared.ID, "user-ana", fmt.Sprintf(updateBody, "Informe final"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "GET", "/tasks/"+taskID, "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &owned)
+	if owned.Title != "Informe final" {
+		t.Errorf("expected the editor's change on the owner task, got %q", owned.Title)
+	}
+	if got := sharedCopy(t, r, "user-ana", taskID); got.Status != domain.StatusInProgress {
+		t.Errorf("expected the member's status to survive the sync, got %s", got.Status)
+	}
+
+	// Un viewer no puede editar
+	w = doSaved(r, "PATCH", "/tasks/"+taskID+"/collaborators/user-ana", "", `{"role": "viewer"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "PUT", "/tasks/"+shared.ID, "user-ana", fmt.Sprintf(updateBody, "Otro"))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a viewer, got %d %s", w.Code, w.Body.String())
+	}
+	// ni administrar colaboradores
+	w = doSaved(r, "POST", "/tasks/"+shared.ID+"/collaborators", "user-ana", `{"userId": "user-luis", "role": "viewer"}`)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 when a member shares, got %d", w.Code)
+	}
+
+	// Al eliminar la tarea del dueño desaparece para todos
+	w = doSaved(r, "DELETE", "/tasks/"+taskID, "", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d %s", w.Code, w.Body.String())
+	}
+	if sharedCopy(t, r, "user-ana", taskID) != nil {
+		t.Errorf("expected the member's copy to be deleted")
+	}
+}
+
+func TestShareTaskByEmail(t *testing.T) {
+	r, taskID := setupCollaborationRouter(t)
+
+	w := doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"email": "Ana@Uniflow.edu", "role": "viewer"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "email": "ana@uniflow.edu", "role": "viewer"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 with both userId and email, got %d", w.Code)
+	}
+
+	var members struct {
+		Data []domain.Collaborator `json:"data"`
+	}
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/collaborators", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &members)
+	if len(members.Data) != 2 || members.Data[0].Role != domain.RoleOwner || !members.Data[1].Pending {
+		t.Fatalf("expected owner and a pending invitation, got %s", w.Body.String())
+	}
+
+	var invitations struct {
+		Data []domain.Invitation `json:"data"`
+	}
+	w = doAs(r, "GET", "/me/invitations", "user-ana", "ana@uniflow.edu", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &invitations)
+	if len(invitations.Data) != 1 || invitations.Data[0].TaskTitle != "Informe grupal" {
+		t.Fatalf("expected one invitation, got %s", w.Body.String())
+	}
+	invID := invitations.Data[0].ID
+
+	w = doAs(r, "POST", "/me/invitations/"+invID+"/accept", "user-otro", "otro@uniflow.edu", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for someone else's invitation, got %d", w.Code)
+	}
+	w = doAs(r, "POST", "/me/invitations/"+invID+"/accept", "user-ana", "ana@uniflow.edu", "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", w.Code, w.Body.String())
+	}
+	if shared := sharedCopy(t, r, "user-ana", taskID); shared == nil || shared.Role != domain.RoleViewer {
+		t.Fatalf("expected the accepted task in the member's list, got %+v", shared)
+	}
+
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/collaborators", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &members)
+	if len(members.Data) != 2 || members.Data[1].UserID != "user-ana" || members.Data[1].Pending {
+		t.Errorf("expected the invitation replaced by the member, got %s", w.Body.String())
+	}
+
+	w = doSaved(r, "DELETE", "/tasks/"+taskID+"/collaborators/user-ana", "", "")
+	if w.Code != http.StatusNoContent || sharedCopy(t, r, "user-ana", taskID) != nil {
+		t.Errorf("expected the member removed, got %d", w.Code)
+	}
+}
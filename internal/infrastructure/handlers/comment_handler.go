@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// activityError responde según el tipo de error del hilo de la tarea
+func activityError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		switch de {
+		case domain.ErrTaskNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Tarea no encontrada"))
+			return
+		case domain.ErrCommentNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrForbidden:
+			c.JSON(http.StatusForbidden, NewErrorResponse(de.Code, "solo el autor (o el dueño de la tarea, al eliminar) puede modificar el comentario"))
+			return
+		case domain.ErrInvalidComment:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(de.Code, de.Message))
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// currentUser usuario autenticado (UserContext del middleware) para firmar comentarios
+func currentUser(c *gin.Context, userID string) *domain.UserContext {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.UserContext); ok && u.ID == userID {
+			return u
+		}
+	}
+	return domain.NewUserContext(userID, c.GetHeader("X-User-Email"), c.GetHeader("X-User-Name"), "")
+}
+
+// listActivity responde una página del hilo filtrada por kind
+func (th *TaskHandler) listActivity(c *gin.Context, kind string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	entries, pageInfo, err := th.taskService.ListActivity(ctx, c.Param("id"), userID, kind, page, limit)
+	if err != nil {
+		activityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"pagination": gin.H{
+			"page":       pageInfo.Page,
+			"limit":      pageInfo.Limit,
+			"total":      pageInfo.Total,
+			"totalPages": pageInfo.TotalPages,
+			"hasNext":    pageInfo.HasNext,
+			"hasPrev":    pageInfo.HasPrev,
+		},
+	})
+}
+
+// ListComments maneja GET /tasks/:id/comments?page=&limit=
+// Comentarios de la tarea, más recientes primero
+func (th *TaskHandler) ListComments(c *gin.Context) {
+	th.listActivity(c, domain.ActivityComment)
+}
+
+// GetActivity maneja GET /tasks/:id/activity?page=&limit=
+// Comentarios y cambios de estado de todos los miembros, más recientes primero
+func (th *TaskHandler) GetActivity(c *gin.Context) {
+	kind := c.Query("kind")
+	if kind != "" && kind != domain.ActivityComment && kind != domain.ActivityStatus {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", "kind debe ser comment o status"))
+		return
+	}
+	th.listActivity(c, kind)
+}
+
+// AddComment maneja POST /tasks/:id/comments
+func (th *TaskHandler) AddComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	comment, err := th.taskService.AddComment(ctx, c.Param("id"), currentUser(c, userID), req.Body)
+	if err != nil {
+		activityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment maneja PATCH /tasks/:id/comments/:commentId
+func (th *TaskHandler) UpdateComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	comment, err := th.taskService.UpdateComment(ctx, c.Param("id"), c.Param("commentId"), userID, req.Body)
+	if err != nil {
+		activityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment maneja DELETE /tasks/:id/comments/:commentId
+func (th *TaskHandler) DeleteComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.taskService.DeleteComment(ctx, c.Param("id"), c.Param("commentId"), userID); err != nil {
+		activityError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
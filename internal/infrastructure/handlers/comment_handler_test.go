@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"uniflow-api/internal/domain"
+)
+
+type activityPage struct {
+	Data       []domain.Activity `json:"data"`
+	Pagination struct {
+		Total   int  `json:"total"`
+		HasNext bool `json:"hasNext"`
+	} `json:"pagination"`
+}
+
+func TestTaskComments(t *testing.T) {
+	r, taskID := setupCollaborationRouter(t)
+	_ = doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "role": "viewer"}`)
+	shared := sharedCopy(t, r, "user-ana", taskID)
+
+	w := doSaved(r, "POST", "/tasks/"+taskID+"/comments", "", `{"body": "   "}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty comment, got %d", w.Code)
+	}
+
+	// Un viewer también comenta, desde su copia
+	w = doAs(r, "POST", "/tasks/"+shared.ID+"/comments", "user-ana", "ana@uniflow.edu", `{"body": "El profe lo movió al viernes"}`)
+	var comment domain.Activity
+	_ = json.Unmarshal(w.Body.Bytes(), &comment)
+	if w.Code != http.StatusCreated || comment.AuthorID != "user-ana" || comment.AuthorName != "ana@uniflow.edu" || comment.TaskID != taskID {
+		t.Fatalf("unexpected comment %d %s", w.Code, w.Body.String())
+	}
+
+	// El dueño ve el mismo hilo
+	var page activityPage
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/comments", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if w.Code != http.StatusOK || len(page.Data) != 1 || page.Data[0].ID != comment.ID {
+		t.Fatalf("expected the member's comment in the owner's thread, got %s", w.Body.String())
+	}
+
+	// Solo el autor edita
+	w = doSaved(r, "PATCH", "/tasks/"+taskID+"/comments/"+comment.ID, "", `{"body": "otro texto"}`)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 when editing someone else's comment, got %d", w.Code)
+	}
+	w = doSaved(r, "PATCH", "/tasks/"+shared.ID+"/comments/"+comment.ID, "user-ana", `{"body": "El profe lo movió al lunes"}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &comment)
+	if w.Code != http.StatusOK || !comment.Edited || comment.Body != "El profe lo movió al lunes" {
+		t.Errorf("unexpected edited comment %d %s", w.Code, w.Body.String())
+	}
+
+	// El dueño puede eliminar cualquier comentario
+	w = doSaved(r, "DELETE", "/tasks/"+taskID+"/comments/"+comment.ID, "", "")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "DELETE", "/tasks/"+taskID+"/comments/"+comment.ID, "", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a deleted comment, got %d", w.Code)
+	}
+
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/comments", "user-otro", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a non-member, got %d", w.Code)
+	}
+}
+
+func TestTaskCommentMentionsAndPagination(t *testing.T) {
+	r, taskID := setupCollaborationRouter(t)
+	_ = doSaved(r, "PUT", "/tasks/"+taskID, "", `{"title": "Informe grupal", "subjectId": "subject-1", "dueDate": "2030-01-10T00:00:00Z",
+		"priority": "high", "type": "group-work", "isGroupWork": true, "groupMembers": ["Ana", "Ana María", "Luis"]}`)
+
+	w := doSaved(r, "POST", "/tasks/"+taskID+"/comments", "", `{"body": "@ana maría revisa la intro y @Luis, las fuentes. @Pedro no está"}`)
+	var comment domain.Activity
+	_ = json.Unmarshal(w.Body.Bytes(), &comment)
+	if w.Code != http.StatusCreated || fmt.Sprint(comment.Mentions) != "[Ana María Luis]" {
+		t.Errorf("unexpected mentions %d %s", w.Code, w.Body.String())
+	}
+
+	for i := 0; i < 4; i++ {
+		_ = doSaved(r, "POST", "/tasks/"+taskID+"/comments", "", fmt.Sprintf(`{"body": "nota %d"}`, i))
+	}
+	var page activityPage
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/comments?limit=2&page=1", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 2 || page.Pagination.Total != 5 || !page.Pagination.HasNext || page.Data[0].Body != "nota 3" {
+		t.Errorf("unexpected first page %s", w.Body.String())
+	}
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/comments?limit=2&page=3", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 1 || page.Pagination.HasNext {
+		t.Errorf("unexpected last page %s", w.Body.String())
+	}
+}
+
+func TestTaskActivityFeed(t *testing.T) {
+	r, taskID := setupCollaborationRouter(t)
+	_ = doSaved(r, "POST", "/tasks/"+taskID+"/collaborators", "", `{"userId": "user-ana", "role": "editor"}`)
+	shared := sharedCopy(t, r, "user-ana", taskID)
+
+	_ = doSaved(r, "PATCH", "/tasks/"+taskID+"/status", "", `{"status": "in-progress"}`)
+	_ = doSaved(r, "POST", "/tasks/"+taskID+"/comments", "", `{"body": "Empecé la intro"}`)
+	_ = doSaved(r, "PATCH", "/tasks/"+shared.ID+"/status", "user-ana", `{"status": "done"}`)
+	// Sin cambio real no se registra nada
+	_ = doSaved(r, "PATCH", "/tasks/"+shared.ID+"/status", "user-ana", `{"status": "done"}`)
+
+	var page activityPage
+	w := doSaved(r, "GET", "/tasks/"+shared.ID+"/activity", "user-ana", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if w.Code != http.StatusOK || len(page.Data) != 3 {
+		t.Fatalf("expected 3 entries, got %d %s", w.Code, w.Body.String())
+	}
+	last, first := page.Data[0], page.Data[2]
+	if last.Kind != domain.ActivityStatus || last.AuthorID != "user-ana" || last.FromStatus != domain.StatusTodo || last.ToStatus != domain.StatusDone {
+		t.Errorf("unexpected latest entry %+v", last)
+	}
+	if first.Kind != domain.ActivityStatus || first.AuthorID != "user-test" || first.ToStatus != domain.StatusInProgress {
+		t.Errorf("unexpected oldest entry %+v", first)
+	}
+	if page.Data[1].Kind != domain.ActivityComment {
+		t.Errorf("expected the comment between status changes, got %+v", page.Data[1])
+	}
+
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/activity?kind=status", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 2 {
+		t.Errorf("expected only status changes, got %s", w.Body.String())
+	}
+	w = doSaved(r, "GET", "/tasks/"+taskID+"/activity?kind=other", "", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid kind, got %d", w.Code)
+	}
+
+	// Los cambios de estado no se editan como comentarios
+	w = doSaved(r, "PATCH", "/tasks/"+taskID+"/comments/"+first.ID, "", `{"body": "x"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 when editing a status entry, got %d", w.Code)
+	}
+}
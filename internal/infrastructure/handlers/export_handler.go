@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+	"uniflow-api/internal/infrastructure/taskio"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportPageSize tamaño de página al recorrer las tareas a exportar
+const exportPageSize = 100
+
+// exportContentTypes content-type por formato de exportación
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"json":   "application/json; charset=utf-8",
+	"ndjson": "application/x-ndjson; charset=utf-8",
+}
+
+// ExportTasks maneja GET /tasks/export?format=csv|json|ndjson
+// Acepta todos los filtros de GET /tasks. Si no se indica page/limit,
+// exporta todas las páginas en streaming.
+func (th *TaskHandler) ExportTasks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FORMAT", "format debe ser csv, json o ndjson"))
+		return
+	}
+
+	var filterReq requests.TaskFilterRequest
+	if err := c.ShouldBindQuery(&filterReq); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILTER", err.Error()))
+		return
+	}
+	if !th.applyView(ctx, c, userID, &filterReq) {
+		return
+	}
+	prefs, loc, ok := th.userPreferences(ctx, c, userID, filterReq.TimeZone)
+	if !ok {
+		return
+	}
+	filterReq.TimeZone, filterReq.WeekStart = loc.String(), prefs.WeekStart
+	filter, err := filterReq.ToTaskFilter(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewFilterErrorResponse(err))
+		return
+	}
+
+	allPages := c.Query("page") == "" && c.Query("limit") == ""
+	if allPages {
+		filter.Page = 1
+		filter.Limit = exportPageSize
+	}
+
+	// Primera página antes de escribir: los errores aún pueden responderse como JSON
+	tasks, pageInfo, err := th.taskService.GetTasksFiltered(ctx, *filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="uniflow-tasks.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	var csvWriter *csv.Writer
+	switch format {
+	case "csv":
+		csvWriter = csv.NewWriter(c.Writer)
+		_ = csvWriter.Write(taskio.Columns)
+	case "json":
+		_, _ = io.WriteString(c.Writer, "[")
+	}
+
+	first := true
+	for {
+		for i := range tasks {
+			switch format {
+			case "csv":
+				_ = csvWriter.Write(taskio.Record(&tasks[i]))
+			case "json":
+				if !first {
+					_, _ = io.WriteString(c.Writer, ",")
+				}
+				writeJSONLine(c.Writer, TaskFromDomain(&tasks[i]), false)
+			case "ndjson":
+				writeJSONLine(c.Writer, TaskFromDomain(&tasks[i]), true)
+			}
+			first = false
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+		}
+		c.Writer.Flush()
+
+		if !allPages || !pageInfo.HasNext {
+			break
+		}
+
+		// Por cursor: las tareas que cambian durante la exportación no se
+		// duplican ni se saltan (con orden por relevancia no hay cursor)
+		if pageInfo.NextCursor != "" {
+			filter.After = pageInfo.NextCursor
+		} else {
+			filter.Page++
+		}
+		filter.SkipCount = true
+		tasks, pageInfo, err = th.taskService.GetTasksFiltered(ctx, *filter)
+		if err != nil {
+			// El status ya se envió: se corta el stream y se registra el error
+			log.Printf("⚠️ Error al exportar tareas: %v", err)
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	if format == "json" {
+		_, _ = io.WriteString(c.Writer, "]")
+	}
+}
+
+func writeJSONLine(w io.Writer, v interface{}, newline bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if newline {
+		data = append(data, '\n')
+	}
+	_, _ = w.Write(data)
+}
+
+// ImportTasks maneja POST /tasks/import
+// Parámetros (query o form): format (csv|json|ndjson), mapping (JSON),
+// mode (create|upsert, upsert usa externalId o el id de una exportación), dryRun, tz
+func (th *TaskHandler) ImportTasks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	body, err := importBody(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILE", err.Error()))
+		return
+	}
+	defer body.Close()
+
+	format := importParam(c, "format", formatFromContentType(c.ContentType()))
+	mode := importParam(c, "mode", "create")
+	if mode != "create" && mode != "upsert" {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_MODE", "mode debe ser create o upsert"))
+		return
+	}
+
+	loc, ok := th.location(ctx, c, userID, importParam(c, "tz", ""))
+	if !ok {
+		return
+	}
+
+	mapping := taskio.Mapping{}
+	if raw := importParam(c, "mapping", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_MAPPING", "mapping debe ser un objeto JSON {\"columna\": \"campo\"}"))
+			return
+		}
+	}
+	if err := mapping.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_MAPPING", err.Error()))
+		return
+	}
+
+	var rows []taskio.Row
+	switch format {
+	case "csv":
+		rows, err = taskio.ReadCSV(body, mapping)
+	case "json", "ndjson":
+		var data []byte
+		if data, err = io.ReadAll(body); err == nil {
+			rows, err = taskio.ReadJSONAuto(data, mapping)
+		}
+	default:
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FORMAT", "format debe ser csv, json o ndjson"))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILE", err.Error()))
+		return
+	}
+
+	drafts := taskio.ToDrafts(rows, userID, loc, time.Now())
+	opts := application.ImportOptions{
+		DryRun: importParam(c, "dryRun", "false") == "true",
+		Upsert: mode == "upsert",
+	}
+
+	result, err := th.taskService.ImportTasks(ctx, userID, drafts, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.JSON(importStatus(result), importResultFromDomain(result))
+}
+
+// formatFromContentType deduce el formato de importación del Content-Type
+func formatFromContentType(ct string) string {
+	switch {
+	case strings.Contains(ct, "ndjson"):
+		return "ndjson"
+	case strings.Contains(ct, "json"):
+		return "json"
+	default:
+		return "csv"
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestExportTasksAllPages(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks/export", handler.ExportTasks)
+
+	for i := 0; i < 120; i++ {
+		_ = service.CreateTask(context.Background(), &domain.Task{
+			ID: fmt.Sprintf("task-%03d", i), UserID: "user-test", Title: "Tarea", SubjectID: "calc",
+			Status: domain.StatusTodo, Priority: domain.PriorityLow, Type: domain.TypeAssignment,
+			DueDate: time.Now().Add(time.Duration(i) * time.Hour),
+		}, "user-test", "", "")
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/export?format=ndjson&subjectId=calc", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 120 {
+		t.Errorf("Expected 120 ndjson lines, got %d", len(lines))
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/export?format=json&limit=10", nil)
+	r.ServeHTTP(w, req)
+
+	var page []TaskDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || len(page) != 10 {
+		t.Errorf("Expected a JSON array with 10 tasks, got %d (err %v)", len(page), err)
+	}
+}
+
+func TestImportTasksCSVUpsert(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.POST("/tasks/import", handler.ImportTasks)
+
+	post := func(body, query string) ImportResultDTO {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/import?format=csv"+query, strings.NewReader(body))
+		r.ServeHTTP(w, req)
+		var res ImportResultDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return res
+	}
+
+	csvBody := "externalId,title,subjectId,dueDate,priority\n" +
+		"row-1,Quiz 1,fis,2025-10-10,high\n" +
+		"row-2,Sin materia,,2025-10-11,low\n"
+
+	res := post(csvBody, "&mode=upsert")
+	if res.Summary.Created != 1 || res.Summary.Failed != 1 || res.Errors[0].Line != 3 {
+		t.Fatalf("unexpected first import %+v %+v", res.Summary, res.Errors)
+	}
+
+	// Mismo externalId con cambios: actualiza
+	res = post(strings.Replace(csvBody, "Quiz 1", "Quiz 1 (reprogramado)", 1), "&mode=upsert")
+	if res.Summary.Updated != 1 || res.Summary.Created != 0 {
+		t.Fatalf("expected one update, got %+v", res.Summary)
+	}
+
+	// En modo create, el externalId existente es un error por fila
+	res = post(csvBody, "")
+	if res.Summary.Created != 0 || res.Summary.Failed != 2 {
+		t.Errorf("expected duplicate externalId error, got %+v", res.Summary)
+	}
+
+	tasks, _ := service.GetAllTasks(context.Background(), "user-test")
+	if len(tasks) != 1 || tasks[0].Title != "Quiz 1 (reprogramado)" {
+		t.Errorf("unexpected stored tasks %+v", tasks)
+	}
+}
+
+func TestExportImportCSVRoundTrip(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks/export", handler.ExportTasks)
+	r.POST("/tasks/import", handler.ImportTasks)
+
+	for _, title := range []string{"Quiz 1", "Informe"} {
+		_ = service.CreateTask(context.Background(), &domain.Task{
+			UserID: "user-test", Title: title, SubjectID: "fis",
+			Status: domain.StatusTodo, Priority: domain.PriorityLow, Type: domain.TypeAssignment,
+			DueDate: time.Now().Add(24 * time.Hour).Truncate(time.Second),
+		}, "user-test", "", "")
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/export?format=csv", nil)
+	r.ServeHTTP(w, req)
+	exported := w.Body.String()
+
+	// Reimportar la exportación sin externalId actualiza por id: no duplica
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/tasks/import?format=csv&mode=upsert", strings.NewReader(strings.Replace(exported, "Quiz 1", "Quiz 1 (v2)", 1)))
+	r.ServeHTTP(w, req)
+	var res ImportResultDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	if res.Summary.Created != 0 || res.Summary.Updated != 1 || res.Summary.Unchanged != 1 {
+		t.Fatalf("expected the export to update by id, got %+v %+v", res.Summary, res.Errors)
+	}
+
+	tasks, _ := service.GetAllTasks(context.Background(), "user-test")
+	if len(tasks) != 2 {
+		t.Errorf("expected 2 tasks after re-import, got %d", len(tasks))
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/ical"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBytes tamaño máximo de un archivo a importar
+const maxImportBytes = 5 << 20 // 5 MB
+
+// ImportResultDTO respuesta de una importación
+type ImportResultDTO struct {
+	DryRun  bool                    `json:"dryRun"`
+	Created []TaskDTO               `json:"created"`
+	Updated []TaskDTO               `json:"updated"`
+	Errors  []domain.ImportRowError `json:"errors"`
+	Summary ImportSummaryDTO        `json:"summary"`
+}
+
+// ImportSummaryDTO conteos de una importación
+type ImportSummaryDTO struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+func importResultFromDomain(r *domain.ImportResult) ImportResultDTO {
+	dto := ImportResultDTO{
+		DryRun:  r.DryRun,
+		Created: make([]TaskDTO, len(r.Created)),
+		Updated: make([]TaskDTO, len(r.Updated)),
+		Errors:  r.Errors,
+		Summary: ImportSummaryDTO{
+			Created:   len(r.Created),
+			Updated:   len(r.Updated),
+			Unchanged: r.Unchanged,
+			Failed:    len(r.Errors),
+		},
+	}
+	for i := range r.Created {
+		dto.Created[i] = TaskFromDomain(&r.Created[i])
+	}
+	for i := range r.Updated {
+		dto.Updated[i] = TaskFromDomain(&r.Updated[i])
+	}
+	return dto
+}
+
+// ImportICS maneja POST /tasks/import/ics
+// Acepta el calendario como multipart (campo "file") o como body text/calendar.
+// Parámetros (query o form): subjectId, periodId, subjectMap (JSON), tz, dryRun
+func (th *TaskHandler) ImportICS(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	body, err := importBody(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILE", err.Error()))
+		return
+	}
+	defer body.Close()
+
+	loc, ok := th.location(ctx, c, userID, importParam(c, "tz", ""))
+	if !ok {
+		return
+	}
+
+	mapping := ical.ImportMapping{
+		SubjectID: importParam(c, "subjectId", ""),
+		PeriodID:  importParam(c, "periodId", ""),
+	}
+	if raw := importParam(c, "subjectMap", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping.SubjectMap); err != nil {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_MAPPING", "subjectMap debe ser un objeto JSON {\"palabra\": \"subjectId\"}"))
+			return
+		}
+	}
+
+	events, parseErrs, err := ical.Parse(body, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILE", err.Error()))
+		return
+	}
+
+	drafts := ical.ToDrafts(events, userID, mapping, time.Now())
+	opts := application.ImportOptions{
+		DryRun:           importParam(c, "dryRun", "false") == "true",
+		Upsert:           true, // el UID identifica el evento entre reimportaciones
+		PreserveProgress: true,
+	}
+
+	result, err := th.taskService.ImportTasks(ctx, userID, drafts, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	for _, pe := range parseErrs {
+		externalID := ""
+		if pe.UID != "" {
+			externalID = ical.ExternalIDPrefix + pe.UID
+		}
+		result.Errors = append(result.Errors, domain.ImportRowError{
+			Line:       pe.Line,
+			ExternalID: externalID,
+			Message:    pe.Message,
+		})
+	}
+
+	c.JSON(importStatus(result), importResultFromDomain(result))
+}
+
+// importBody obtiene el archivo desde multipart (campo "file") o desde el body
+func importBody(c *gin.Context) (io.ReadCloser, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("falta el archivo en el campo 'file': %w", err)
+		}
+		return fh.Open()
+	}
+	if c.Request.Body == nil {
+		return nil, fmt.Errorf("body vacío")
+	}
+	return c.Request.Body, nil
+}
+
+// importParam lee un parámetro desde el form multipart o, si no, desde la query
+func importParam(c *gin.Context, key, def string) string {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		if v := c.PostForm(key); v != "" {
+			return v
+		}
+	}
+	return c.DefaultQuery(key, def)
+}
+
+// importStatus 201 si se creó algo, 200 en dry-run o si solo hubo actualizaciones/errores
+func importStatus(r *domain.ImportResult) int {
+	if !r.DryRun && len(r.Created) > 0 {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const importCalendar = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:quiz-1@curso\r\n" +
+	"SUMMARY:Quiz 1\r\n" +
+	"DTSTART:20251010T150000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Sin UID\r\n" +
+	"DTSTART:20251011T150000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func postICS(r http.Handler, query string) (int, ImportResultDTO) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/import/ics?subjectId=fis-1"+query, strings.NewReader(importCalendar))
+	req.Header.Set("Content-Type", "text/calendar")
+	r.ServeHTTP(w, req)
+
+	var res ImportResultDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func TestImportICS(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.POST("/tasks/import/ics", handler.ImportICS)
+
+	// Dry-run: no persiste
+	code, res := postICS(r, "&dryRun=true")
+	if code != http.StatusOK || !res.DryRun || res.Summary.Created != 1 || res.Summary.Failed != 1 {
+		t.Fatalf("unexpected dry-run result %d %+v", code, res.Summary)
+	}
+	if res.Errors[0].Line != 7 {
+		t.Errorf("expected error at line 7, got %d", res.Errors[0].Line)
+	}
+	if tasks, _ := service.GetAllTasks(context.Background(), "user-test"); len(tasks) != 0 {
+		t.Fatalf("dry-run should not persist, found %d tasks", len(tasks))
+	}
+
+	code, res = postICS(r, "")
+	if code != http.StatusCreated || res.Summary.Created != 1 || res.Created[0].Type != "quiz" {
+		t.Fatalf("unexpected import result %d %+v", code, res.Summary)
+	}
+
+	// Reimportar el mismo archivo no duplica
+	_, res = postICS(r, "")
+	if res.Summary.Created != 0 || res.Summary.Updated != 0 || res.Summary.Unchanged != 1 {
+		t.Errorf("expected no changes on re-import, got %+v", res.Summary)
+	}
+	if tasks, _ := service.GetAllTasks(context.Background(), "user-test"); len(tasks) != 1 {
+		t.Errorf("expected 1 task after re-import, got %d", len(tasks))
+	}
+}
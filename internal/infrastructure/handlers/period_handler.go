@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// periodCloneError responde según el tipo de error de la clonación
+func periodCloneError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if !errors.As(err, &de) {
+		// Validación de una tarea copiada (Task.IsValid)
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_TASK", err.Error()))
+		return
+	}
+	switch de {
+	case domain.ErrInvalidPeriodClone:
+		c.JSON(http.StatusBadRequest, NewErrorResponse(de.Code, err.Error()))
+	case domain.ErrPeriodEmpty:
+		c.JSON(http.StatusNotFound, NewErrorResponse(de.Code, de.Message))
+	case domain.ErrPeriodCloneLimit:
+		c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(de.Code, de.Message))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+	}
+}
+
+// ClonePeriod maneja POST /periods/:from/clone: copia las tareas del período
+// en toPeriodId moviendo las fechas por semanas. Con "preview": true solo
+// responde el plan (200); si no, crea las tareas y responde el plan con los
+// IDs creados (201).
+func (th *TaskHandler) ClonePeriod(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.ClonePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	prefs, loc, ok := th.userPreferences(ctx, c, userID, req.TimeZone)
+	if !ok {
+		return
+	}
+	weekStart, _ := domain.ParseWeekday(prefs.WeekStart)
+
+	opts := domain.PeriodCloneOptions{
+		FromPeriodID: c.Param("from"),
+		ToPeriodID:   req.ToPeriodID,
+		SubjectIDs:   req.SubjectIDs,
+		WeekStart:    weekStart,
+		Location:     loc,
+	}
+	var err error
+	if opts.ToStart, err = domain.ParseLocalDate(req.ToStart, loc); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", "toStart debe tener formato YYYY-MM-DD"))
+		return
+	}
+	if req.FromStart != "" {
+		if opts.FromStart, err = domain.ParseLocalDate(req.FromStart, loc); err != nil {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", "fromStart debe tener formato YYYY-MM-DD"))
+			return
+		}
+	}
+
+	if req.Preview {
+		plan, err := th.taskService.PlanPeriodClone(ctx, userID, opts)
+		if err != nil {
+			periodCloneError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, plan)
+		return
+	}
+
+	plan, err := th.taskService.ClonePeriod(ctx, userID, c.GetHeader("X-User-Name"), c.GetHeader("X-User-Email"), opts)
+	if err != nil {
+		periodCloneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupPeriodRouter(t *testing.T) (*gin.Engine, *application.TaskService) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-test")
+		c.Next()
+	})
+
+	service := application.NewTaskService(memory.NewRepo(), nil)
+	h := NewTaskHandler(service)
+
+	r.GET("/tasks/by-period/:periodId", h.GetByPeriod)
+	r.POST("/periods/:from/clone", h.ClonePeriod)
+
+	for _, task := range []*domain.Task{
+		{Title: "Parcial", SubjectID: "calc", Type: domain.TypeExam, DueDate: time.Date(2025, 3, 12, 14, 0, 0, 0, time.UTC), Status: domain.StatusDone},
+		{Title: "Tarea 1", SubjectID: "calc", Type: domain.TypeAssignment, DueDate: time.Date(2025, 2, 5, 14, 0, 0, 0, time.UTC), Status: domain.StatusInProgress},
+		{Title: "Lectura", SubjectID: "hist", Type: domain.TypeReading, DueDate: time.Date(2025, 2, 7, 14, 0, 0, 0, time.UTC), Status: domain.StatusTodo},
+	} {
+		task.UserID, task.PeriodID, task.Priority = "user-test", "2025-1", domain.PriorityMedium
+		if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r, service
+}
+
+func TestClonePeriodPreviewAndCommit(t *testing.T) {
+	r, _ := setupPeriodRouter(t)
+
+	body := `{"toPeriodId": "2025-2", "fromStart": "2025-02-03", "toStart": "2025-08-04", "subjectIds": ["calc"], "preview": true}`
+	w := doSaved(r, "POST", "/periods/2025-1/clone", "", body)
+	var plan domain.PeriodClonePlan
+	_ = json.Unmarshal(w.Body.Bytes(), &plan)
+	if w.Code != http.StatusOK || plan.CreateCount != 2 || plan.ShiftDays != 182 || plan.Changes[0].Title != "Tarea 1" || plan.Changes[1].Week != 6 {
+		t.Fatalf("unexpected preview %d %s", w.Code, w.Body.String())
+	}
+
+	// La vista previa no crea nada
+	var byPeriod struct {
+		Tasks []TaskDTO `json:"tasks"`
+	}
+	w = doSaved(r, "GET", "/tasks/by-period/2025-2", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &byPeriod)
+	if len(byPeriod.Tasks) != 0 {
+		t.Fatalf("preview should not create tasks, got %s", w.Body.String())
+	}
+
+	w = doSaved(r, "POST", "/periods/2025-1/clone", "", `{"toPeriodId": "2025-2", "fromStart": "2025-02-03", "toStart": "2025-08-04", "subjectIds": ["calc"]}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &plan)
+	if w.Code != http.StatusCreated || plan.CreateCount != 2 || plan.Changes[0].TaskID == "" {
+		t.Fatalf("unexpected clone %d %s", w.Code, w.Body.String())
+	}
+
+	w = doSaved(r, "GET", "/tasks/by-period/2025-2", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &byPeriod)
+	if len(byPeriod.Tasks) != 2 {
+		t.Fatalf("expected 2 cloned tasks, got %s", w.Body.String())
+	}
+	for _, task := range byPeriod.Tasks {
+		if task.Status != domain.StatusTodo || task.SubjectID != "calc" {
+			t.Errorf("unexpected cloned task %+v", task)
+		}
+	}
+	if byPeriod.Tasks[0].DueDate != "2025-08-06T14:00:00Z" {
+		t.Errorf("expected Tarea 1 on Wednesday of week 1, got %s", byPeriod.Tasks[0].DueDate)
+	}
+
+	// Repetir no duplica: todo se omite
+	w = doSaved(r, "POST", "/periods/2025-1/clone", "", `{"toPeriodId": "2025-2", "toStart": "2025-08-04"}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &plan)
+	if w.Code != http.StatusCreated || plan.CreateCount != 1 || plan.SkipCount != 2 {
+		t.Errorf("expected only the history task to be created, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestClonePeriodErrors(t *testing.T) {
+	r, _ := setupPeriodRouter(t)
+
+	for body, code := range map[string]int{
+		`{"toPeriodId": "2025-1", "toStart": "2025-08-04"}`:                            http.StatusBadRequest,
+		`{"toPeriodId": "2025-2", "toStart": "04/08/2025"}`:                            http.StatusBadRequest,
+		`{"toPeriodId": "2025-2"}`:                                                     http.StatusBadRequest,
+		`{"toPeriodId": "2025-2", "toStart": "2025-08-04", "timeZone": "Local"}`:       http.StatusBadRequest,
+		`{"toPeriodId": "2025-2", "toStart": "2025-08-04", "subjectIds": ["quimica"]}`: http.StatusNotFound,
+	} {
+		w := doSaved(r, "POST", "/periods/2025-1/clone", "", body)
+		if w.Code != code {
+			t.Errorf("%s: expected %d, got %d %s", body, code, w.Code, w.Body.String())
+		}
+	}
+
+	w := doSaved(r, "POST", "/periods/2024-2/clone", "", `{"toPeriodId": "2025-2", "toStart": "2025-08-04", "preview": true}`)
+	var errResp ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if w.Code != http.StatusNotFound || errResp.Code != "PERIOD_EMPTY" {
+		t.Errorf("expected 404 PERIOD_EMPTY, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreferencesHandler maneja las preferencias del usuario (/me/preferences)
+type PreferencesHandler struct {
+	preferences *application.PreferencesService
+}
+
+// NewPreferencesHandler crea un nuevo PreferencesHandler
+func NewPreferencesHandler(ps *application.PreferencesService) *PreferencesHandler {
+	return &PreferencesHandler{preferences: ps}
+}
+
+// WithPreferences usa la zona horaria guardada por cada usuario cuando el
+// request no trae ?tz=
+func (th *TaskHandler) WithPreferences(ps *application.PreferencesService) *TaskHandler {
+	th.preferences = ps
+	return th
+}
+
+// userPreferences preferencias del usuario y zona horaria de la consulta:
+// explicit (?tz=) si viene, si no la del usuario. Sin servicio de
+// preferencias se usan los valores por defecto. Retorna false si ya
+// respondió con error.
+func (th *TaskHandler) userPreferences(ctx context.Context, c *gin.Context, userID, explicit string) (*domain.UserPreferences, *time.Location, bool) {
+	var prefs *domain.UserPreferences
+	var loc *time.Location
+	var err error
+	if th.preferences != nil {
+		prefs, loc, err = th.preferences.Resolve(ctx, userID, explicit)
+	} else {
+		prefs = domain.DefaultPreferences(userID, "UTC")
+		loc, err = domain.LoadTimeZone(explicit)
+	}
+	if err != nil {
+		timeZoneError(c, err)
+		return nil, nil, false
+	}
+	return prefs, loc, true
+}
+
+// location zona horaria de la consulta (ver userPreferences)
+func (th *TaskHandler) location(ctx context.Context, c *gin.Context, userID, explicit string) (*time.Location, bool) {
+	_, loc, ok := th.userPreferences(ctx, c, userID, explicit)
+	return loc, ok
+}
+
+// timeZoneError 400 si la zona o las preferencias son inválidas, 500 en otro caso
+func timeZoneError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrInvalidTimeZone) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(domain.ErrInvalidTimeZone.Code, err.Error()))
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidPreferences) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(domain.ErrInvalidPreferences.Code, err.Error()))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// GetPreferences maneja GET /me/preferences
+func (h *PreferencesHandler) GetPreferences(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	prefs, err := h.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences maneja PUT /me/preferences
+func (h *PreferencesHandler) UpdatePreferences(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	prefs, err := h.preferences.UpdatePreferences(ctx, userID, req.ApplyTo)
+	if err != nil {
+		timeZoneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// ProfileDTO respuesta de GET /me
+type ProfileDTO struct {
+	ID          string                  `json:"id"`
+	Email       string                  `json:"email,omitempty"`
+	Name        string                  `json:"name,omitempty"`
+	Picture     string                  `json:"picture,omitempty"`
+	Preferences *domain.UserPreferences `json:"preferences"`
+}
+
+// GetProfile maneja GET /me
+// Datos del usuario autenticado (headers de API Management) y sus preferencias
+func (h *PreferencesHandler) GetProfile(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	prefs, err := h.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	profile := ProfileDTO{ID: userID, Preferences: prefs}
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.UserContext); ok {
+			profile.Email, profile.Name, profile.Picture = u.Email, u.Name, u.Picture
+		}
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
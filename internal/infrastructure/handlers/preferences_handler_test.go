@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupPreferencesRouter(t *testing.T) (*gin.Engine, *application.TaskService) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-test")
+		c.Next()
+	})
+
+	prefs := application.NewPreferencesService(memory.NewPreferencesRepo(), nil)
+	service := application.NewTaskService(memory.NewRepo(), nil).WithPreferences(prefs)
+	h := NewTaskHandler(service).WithPreferences(prefs)
+	ph := NewPreferencesHandler(prefs)
+
+	r.GET("/tasks", h.GetTasks)
+	r.GET("/tasks/dashboard", h.GetDashboard)
+	r.GET("/tasks/agenda", h.GetAgenda)
+	r.GET("/me", ph.GetProfile)
+	r.GET("/me/preferences", ph.GetPreferences)
+	r.PUT("/me/preferences", ph.UpdatePreferences)
+	return r, service
+}
+
+func TestPreferencesTimeZone(t *testing.T) {
+	r, _ := setupPreferencesRouter(t)
+
+	var prefs domain.UserPreferences
+	w := doSaved(r, "GET", "/me/preferences", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &prefs)
+	if w.Code != http.StatusOK || prefs.TimeZone != "UTC" {
+		t.Fatalf("expected default UTC, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = doSaved(r, "PUT", "/me/preferences", "", `{"timeZone": "Mars/Olympus"}`)
+	var errResp ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if w.Code != http.StatusBadRequest || errResp.Code != "INVALID_TIMEZONE" {
+		t.Errorf("expected 400 INVALID_TIMEZONE, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = doSaved(r, "PUT", "/me/preferences", "", `{"timeZone": "America/Costa_Rica"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	w = doSaved(r, "GET", "/me/preferences", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &prefs)
+	if prefs.TimeZone != "America/Costa_Rica" || prefs.UpdatedAt.IsZero() {
+		t.Errorf("unexpected preferences %+v", prefs)
+	}
+}
+
+func TestDashboardTodayUsesUserTimeZone(t *testing.T) {
+	r, service := setupPreferencesRouter(t)
+	cr, _ := time.LoadLocation("America/Costa_Rica")
+
+	w := domain.DashboardOptions{Location: cr}.Windows(time.Now())
+	for title, due := range map[string]time.Time{
+		"ayer":   w.StartOfDay.Add(-time.Minute),
+		"hoy":    w.StartOfDay.Add(time.Minute),
+		"mañana": w.EndOfDay.Add(time.Minute),
+	} {
+		task := &domain.Task{UserID: "user-test", Title: title, SubjectID: "subject-1", DueDate: due,
+			Status: domain.StatusTodo, Priority: domain.PriorityMedium, Type: domain.TypeAssignment}
+		if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = doSaved(r, "PUT", "/me/preferences", "", `{"timeZone": "America/Costa_Rica"}`)
+
+	var dashboard domain.DashboardData
+	rec := doSaved(r, "GET", "/tasks/dashboard", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &dashboard)
+	if rec.Code != http.StatusOK || len(dashboard.TodayTasks) != 1 || dashboard.TodayTasks[0].Title != "hoy" {
+		t.Errorf("expected only today's task in Costa Rica, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// ?tz= explícito tiene prioridad y se valida
+	rec = doSaved(r, "GET", "/tasks/dashboard?tz=Mars/Olympus", "", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid tz, got %d", rec.Code)
+	}
+}
+
+func TestDueDateRangeIsLocalDay(t *testing.T) {
+	r, service := setupPreferencesRouter(t)
+
+	// 9 de marzo en Costa Rica (UTC-6) = [06:00Z del 9, 06:00Z del 10)
+	for title, due := range map[string]time.Time{
+		"antes":   time.Date(2025, 3, 9, 5, 59, 0, 0, time.UTC),
+		"inicio":  time.Date(2025, 3, 9, 6, 0, 0, 0, time.UTC),
+		"noche":   time.Date(2025, 3, 10, 5, 59, 0, 0, time.UTC),
+		"despues": time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC),
+	} {
+		task := &domain.Task{UserID: "user-test", Title: title, SubjectID: "subject-1", DueDate: due,
+			Status: domain.StatusTodo, Priority: domain.PriorityMedium, Type: domain.TypeAssignment}
+		if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var page struct {
+		Data []TaskDTO `json:"data"`
+	}
+	rec := doSaved(r, "GET", "/tasks?dueDateFrom=2025-03-09&dueDateTo=2025-03-09&tz=America/Costa_Rica", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &page)
+	if rec.Code != http.StatusOK || len(page.Data) != 2 || page.Data[0].Title != "inicio" || page.Data[1].Title != "noche" {
+		t.Errorf("expected [inicio noche], got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Sin ?tz= se usa la preferencia guardada
+	_ = doSaved(r, "PUT", "/me/preferences", "", `{"timeZone": "America/Costa_Rica"}`)
+	rec = doSaved(r, "GET", "/tasks?dueDateFrom=2025-03-09&dueDateTo=2025-03-09", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &page)
+	if len(page.Data) != 2 {
+		t.Errorf("expected the stored time zone to apply, got %s", rec.Body.String())
+	}
+
+	rec = doSaved(r, "GET", "/tasks?tz=Local", "", "")
+	var errResp ErrorResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &errResp)
+	if rec.Code != http.StatusBadRequest || errResp.Code != "INVALID_TIMEZONE" {
+		t.Errorf("expected 400 INVALID_TIMEZONE, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdatePreferencesPartial(t *testing.T) {
+	r, _ := setupPreferencesRouter(t)
+
+	w := doSaved(r, "PUT", "/me/preferences", "", `{"locale": "es-CR", "reminderOffsetsHours": [24, 2], "weekStart": "Sunday", "dashboard": {"today": 3}}`)
+	var prefs domain.UserPreferences
+	_ = json.Unmarshal(w.Body.Bytes(), &prefs)
+	if w.Code != http.StatusOK || prefs.Locale != "es-CR" || prefs.WeekStart != "sunday" || len(prefs.ReminderOffsetsHours) != 2 ||
+		prefs.Dashboard.Today != 3 || prefs.Dashboard.Upcoming != domain.DefaultUpcomingLimit || prefs.TimeZone != "UTC" {
+		t.Fatalf("unexpected preferences %d %s", w.Code, w.Body.String())
+	}
+
+	for _, body := range []string{
+		`{"dailyCapacityHours": 0}`,
+		`{"weekStart": "lunes"}`,
+		`{"reminderOffsetsHours": [24, 24]}`,
+		`{"dashboard": {"upcoming": 500}}`,
+	} {
+		w = doSaved(r, "PUT", "/me/preferences", "", body)
+		var errResp ErrorResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+		if w.Code != http.StatusBadRequest || errResp.Code != "INVALID_PREFERENCES" {
+			t.Errorf("%s: expected 400 INVALID_PREFERENCES, got %d %s", body, w.Code, w.Body.String())
+		}
+	}
+
+	// Los cambios rechazados no se guardan
+	w = doSaved(r, "GET", "/me", "", "")
+	var profile ProfileDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if w.Code != http.StatusOK || profile.ID != "user-test" || profile.Preferences == nil ||
+		profile.Preferences.DailyCapacityHours != domain.DefaultDailyCapacityHours || profile.Preferences.Locale != "es-CR" {
+		t.Errorf("unexpected profile %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDashboardAndAgendaUsePreferences(t *testing.T) {
+	r, service := setupPreferencesRouter(t)
+
+	now := time.Now()
+	for i := 1; i <= 4; i++ {
+		task := &domain.Task{UserID: "user-test", Title: "próxima", SubjectID: "subject-1", DueDate: now.Add(time.Duration(i) * time.Hour),
+			Status: domain.StatusTodo, Priority: domain.PriorityMedium, Type: domain.TypeAssignment, EstimatedTimeHours: 1}
+		if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = doSaved(r, "PUT", "/me/preferences", "", `{"dailyCapacityHours": 1, "dashboard": {"upcoming": 2}}`)
+
+	var dashboard domain.DashboardData
+	rec := doSaved(r, "GET", "/tasks/dashboard", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &dashboard)
+	if rec.Code != http.StatusOK || len(dashboard.UpcomingTasks) != 2 {
+		t.Errorf("expected 2 upcoming tasks, got %d %s", rec.Code, rec.Body.String())
+	}
+	// ?limit= reemplaza la preferencia
+	rec = doSaved(r, "GET", "/tasks/dashboard?limit=3", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &dashboard)
+	if len(dashboard.UpcomingTasks) != 3 {
+		t.Errorf("expected 3 upcoming tasks with ?limit=3, got %d", len(dashboard.UpcomingTasks))
+	}
+
+	var agenda AgendaDTO
+	rec = doSaved(r, "GET", "/tasks/agenda?days=2", "", "")
+	_ = json.Unmarshal(rec.Body.Bytes(), &agenda)
+	if rec.Code != http.StatusOK || agenda.CapacityHours != 1 {
+		t.Fatalf("unexpected agenda %d %s", rec.Code, rec.Body.String())
+	}
+	over := false
+	for _, day := range agenda.Days {
+		over = over || (day.EstimatedHours > 1 && day.OverCapacity)
+	}
+	if !over {
+		t.Errorf("expected a day over capacity, got %s", rec.Body.String())
+	}
+}
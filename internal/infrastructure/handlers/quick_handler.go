@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/infrastructure/handlers/requests"
+	"uniflow-api/internal/infrastructure/quickadd"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuickAddDTO respuesta de POST /tasks/quick
+type QuickAddDTO struct {
+	Preview  bool             `json:"preview"`
+	Task     TaskDTO          `json:"task"`
+	Matches  []quickadd.Match `json:"matches"`
+	Warnings []string         `json:"warnings"`
+}
+
+// QuickAddTask maneja POST /tasks/quick
+// Interpreta texto libre ("Parcial de Cálculo viernes 10am prioridad alta #repaso")
+// y crea la tarea. Con preview=true (body o query) solo retorna el borrador.
+func (th *TaskHandler) QuickAddTask(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req requests.QuickAddRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	loc, ok := th.location(ctx, c, userID, req.TimeZone)
+	if !ok {
+		return
+	}
+
+	subjects, err := th.taskService.GetSubjectIDs(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	now := time.Now()
+	draft := quickadd.Parse(req.Text, quickadd.Options{Now: now, Location: loc, Subjects: subjects})
+	task := draft.Task(userID, now)
+	task.PeriodID = req.PeriodID
+	if req.SubjectID != "" {
+		task.SubjectID = req.SubjectID
+	}
+	if req.DueDate != nil {
+		task.DueDate = *req.DueDate
+	}
+
+	preview := req.Preview || c.Query("preview") == "true"
+	res := QuickAddDTO{
+		Preview:  preview,
+		Task:     TaskFromDomain(task),
+		Matches:  draft.Matches,
+		Warnings: draft.Warnings,
+	}
+	if res.Matches == nil {
+		res.Matches = []quickadd.Match{}
+	}
+	if res.Warnings == nil {
+		res.Warnings = []string{}
+	}
+
+	if preview {
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	switch {
+	case task.SubjectID == "":
+		c.JSON(http.StatusBadRequest, quickAddError("MISSING_SUBJECT", "no se pudo determinar la materia", draft))
+		return
+	case task.DueDate.IsZero():
+		c.JSON(http.StatusBadRequest, quickAddError("MISSING_DUE_DATE", "no se pudo determinar la fecha de entrega", draft))
+		return
+	}
+
+	userName := c.GetHeader("X-User-Name")
+	userEmail := c.GetHeader("X-User-Email")
+
+	if err := th.taskService.CreateTask(ctx, task, userID, userName, userEmail); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_TASK", err.Error()))
+		return
+	}
+
+	res.Task = TaskFromDomain(task)
+	c.JSON(http.StatusCreated, res)
+}
+
+// quickAddError error con las advertencias del parseo como detalle
+func quickAddError(code, message string, draft quickadd.Draft) ErrorResponse {
+	resp := NewErrorResponse(code, message)
+	resp.Details = strings.Join(draft.Warnings, "; ")
+	return resp
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func postQuick(r http.Handler, body string) (int, QuickAddDTO) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/quick", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	var res QuickAddDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func TestQuickAddTask(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.POST("/tasks/quick", handler.QuickAddTask)
+
+	existing := &domain.Task{UserID: "user-test", Title: "Tarea 1", SubjectID: "calculo-1", Status: domain.StatusTodo,
+		Priority: domain.PriorityLow, Type: domain.TypeAssignment, DueDate: time.Now()}
+	if err := service.CreateTask(context.Background(), existing, "user-test", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	text := `{"text": "Parcial de Cálculo viernes 10am prioridad alta #repaso", "timezone": "America/Costa_Rica"`
+
+	// Preview: interpreta sin crear
+	code, res := postQuick(r, text+`, "preview": true}`)
+	if code != http.StatusOK || !res.Preview {
+		t.Fatalf("unexpected preview response %d %+v", code, res)
+	}
+	if res.Task.SubjectID != "calculo-1" || res.Task.Type != "exam" || res.Task.Priority != "high" {
+		t.Errorf("unexpected draft %+v", res.Task)
+	}
+	if due, _ := time.Parse(time.RFC3339, res.Task.DueDate); due.Weekday() != time.Friday {
+		t.Errorf("expected friday, got %v", res.Task.DueDate)
+	}
+	if tasks, _ := service.GetAllTasks(context.Background(), "user-test"); len(tasks) != 1 {
+		t.Fatalf("preview should not persist, found %d tasks", len(tasks))
+	}
+
+	code, res = postQuick(r, text+`}`)
+	if code != http.StatusCreated || res.Task.ID == "" {
+		t.Fatalf("expected task created, got %d %+v", code, res)
+	}
+
+	// Sin materia reconocible no se crea
+	code, _ = postQuick(r, `{"text": "Leer algo mañana"}`)
+	if code != http.StatusBadRequest {
+		t.Errorf("expected 400 without subject, got %d", code)
+	}
+}
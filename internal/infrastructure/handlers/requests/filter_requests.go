@@ -1,14 +1,19 @@
 package requests
 
+import "errors"
 import "strings"
 import "time"
 
-import "uniflow-api/internal/application/ports"
+import (
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
 
 // TaskFilterRequest estructura para parsear query parameters
 type TaskFilterRequest struct {
 	Status      string `form:"status"`   // Comma-separated: "todo,in-progress"
 	Priority    string `form:"priority"` // Comma-separated: "high,urgent"
+	Type        string `form:"type"`     // Comma-separated: "exam,quiz"
 	SubjectID   string `form:"subjectId"`
 	PeriodID    string `form:"periodId"`
 	DueDateFrom string `form:"dueDateFrom"` // ISO 8601: "2025-10-01"
@@ -16,11 +21,18 @@ type TaskFilterRequest struct {
 	IsOverdue   *bool  `form:"isOverdue"`   // true/false
 	IsDueSoon   *bool  `form:"isDueSoon"`   // true/false (próximas 24h)
 	Search      string `form:"search"`      // Búsqueda libre
-	SortBy      string `form:"sortBy"`      // dueDate, priority, status, createdAt
+	Query       string `form:"q"`           // Expresión: "tag:parcial AND estimated>4 AND -type:reading"
+	View        string `form:"view"`        // ID de un filtro guardado
+	SortBy      string `form:"sortBy"`      // dueDate, priority, status, createdAt, updatedAt, completedAt, title, score; relevance (por defecto con search)
 	SortOrder   string `form:"sortOrder"`   // asc, desc
+	Sort        string `form:"sort"`        // Compuesto: "priority:desc,dueDate:asc" (reemplaza sortBy/sortOrder)
 	Page        int    `form:"page"`
 	Limit       int    `form:"limit"`
-	TimeZone    string `form:"tz"` // Ej: "America/Costa_Rica"
+	After       string `form:"after"`  // Cursor opaco (pagination.nextCursor)
+	Before      string `form:"before"` // Cursor opaco (pagination.prevCursor)
+	Count       *bool  `form:"count"`  // false: omitir el total (más rápido)
+	TimeZone    string `form:"tz"`     // Ej: "America/Costa_Rica"; las fechas se interpretan como días en esa zona
+	WeekStart   string `form:"-"`      // Primer día de la semana (preferencia del usuario) para q
 }
 
 // ToTaskFilter convierte request a domain.TaskFilter
@@ -36,12 +48,44 @@ func (req *TaskFilterRequest) ToTaskFilter(userID string) (*ports.TaskFilter, er
 		IsDueSoon: req.IsDueSoon,
 		Page:      req.Page,
 		Limit:     req.Limit,
+		After:     req.After,
+		Before:    req.Before,
+		SkipCount: req.Count != nil && !*req.Count,
 		TimeZone:  req.TimeZone,
 	}
 
-	// Parse dates
+	if req.After != "" && req.Before != "" {
+		return nil, errors.New("after y before no pueden usarse juntos")
+	}
+
+	if req.SortBy == domain.SortRelevance {
+		if req.Search == "" {
+			return nil, errors.New("sortBy=relevance requiere search")
+		}
+	} else if req.SortBy != "" && !domain.IsSortableField(req.SortBy) {
+		return nil, errors.New("sortBy inválido: " + req.SortBy)
+	}
+
+	// Parse sort compuesto
+	if req.Sort != "" {
+		fields, err := domain.ParseSort(req.Sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.Sort = fields
+	}
+
+	// Validar timezone (vacío = UTC)
+	loc, err := domain.LoadTimeZone(req.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	filter.TimeZone = loc.String()
+
+	// Parse dates: días calendario en la zona del usuario; dueDateTo incluye
+	// todo ese día
 	if req.DueDateFrom != "" {
-		t, err := time.Parse("2006-01-02", req.DueDateFrom)
+		t, err := domain.ParseLocalDate(req.DueDateFrom, loc)
 		if err != nil {
 			return nil, err
 		}
@@ -49,11 +93,12 @@ func (req *TaskFilterRequest) ToTaskFilter(userID string) (*ports.TaskFilter, er
 	}
 
 	if req.DueDateTo != "" {
-		t, err := time.Parse("2006-01-02", req.DueDateTo)
+		t, err := domain.ParseLocalDate(req.DueDateTo, loc)
 		if err != nil {
 			return nil, err
 		}
-		filter.DueDateTo = t
+		_, end := domain.DayRange(t, loc)
+		filter.DueDateTo = end.Add(-time.Millisecond)
 	}
 
 	// Parse status (comma-separated)
@@ -72,9 +117,21 @@ func (req *TaskFilterRequest) ToTaskFilter(userID string) (*ports.TaskFilter, er
 		}
 	}
 
-	// Validar timezone
-	if filter.TimeZone == "" {
-		filter.TimeZone = "UTC"
+	// Parse type (comma-separated)
+	if req.Type != "" {
+		filter.Type = strings.Split(req.Type, ",")
+		for i := range filter.Type {
+			filter.Type[i] = strings.TrimSpace(filter.Type[i])
+		}
+	}
+
+	// Parse expresión de filtro (las fechas relativas usan la zona del usuario)
+	if req.Query != "" {
+		q, err := domain.ParseQuery(req.Query, domain.QueryOptions{Now: time.Now(), Location: loc, WeekStart: req.WeekStart})
+		if err != nil {
+			return nil, err
+		}
+		filter.Query = q
 	}
 
 	// Defaults
@@ -91,14 +148,90 @@ func (req *TaskFilterRequest) ToTaskFilter(userID string) (*ports.TaskFilter, er
 
 	if filter.SortOrder == "" {
 		filter.SortOrder = "asc"
+		if filter.SortBy == "score" {
+			filter.SortOrder = "desc" // Más urgentes primero
+		}
 	}
 
-	if filter.SortBy == "" {
+	if filter.SortBy == "" && len(filter.Sort) == 0 {
 		filter.SortBy = "dueDate"
+		if filter.Search != "" {
+			filter.SortBy = domain.SortRelevance // Más relevantes primero
+		}
 	}
 
 	return filter, nil
 }
 
+// ApplySavedFilter usa los parámetros del filtro guardado como base: los
+// parámetros explícitos del request los reemplazan, salvo q, que se combina
+// con AND para acotar la lista guardada
+func (req *TaskFilterRequest) ApplySavedFilter(p domain.SavedFilterParams) {
+	override := func(dst *string, saved string) {
+		if *dst == "" {
+			*dst = saved
+		}
+	}
+	override(&req.Status, p.Status)
+	override(&req.Priority, p.Priority)
+	override(&req.Type, p.Type)
+	override(&req.SubjectID, p.SubjectID)
+	override(&req.PeriodID, p.PeriodID)
+	override(&req.Sort, p.Sort)
+	if req.IsOverdue == nil {
+		req.IsOverdue = p.IsOverdue
+	}
+	if req.IsDueSoon == nil {
+		req.IsDueSoon = p.IsDueSoon
+	}
+	switch {
+	case p.Query == "":
+	case req.Query == "":
+		req.Query = p.Query
+	default:
+		req.Query = "(" + p.Query + ") AND (" + req.Query + ")"
+	}
+}
+
+// SavedFilterRequest body de POST/PUT /saved-filters (mismos parámetros que GET /tasks)
+type SavedFilterRequest struct {
+	Name      string `json:"name" binding:"required,max=60"`
+	Status    string `json:"status"`
+	Priority  string `json:"priority"`
+	Type      string `json:"type"`
+	SubjectID string `json:"subjectId"`
+	PeriodID  string `json:"periodId"`
+	IsOverdue *bool  `json:"isOverdue"`
+	IsDueSoon *bool  `json:"isDueSoon"`
+	Query     string `json:"q"`
+	Sort      string `json:"sort"`
+}
+
+// Params parámetros a guardar
+func (req *SavedFilterRequest) Params() domain.SavedFilterParams {
+	return domain.SavedFilterParams{
+		Status:    strings.TrimSpace(req.Status),
+		Priority:  strings.TrimSpace(req.Priority),
+		Type:      strings.TrimSpace(req.Type),
+		SubjectID: strings.TrimSpace(req.SubjectID),
+		PeriodID:  strings.TrimSpace(req.PeriodID),
+		IsOverdue: req.IsOverdue,
+		IsDueSoon: req.IsDueSoon,
+		Query:     strings.TrimSpace(req.Query),
+		Sort:      strings.TrimSpace(req.Sort),
+	}
+}
+
+// Validate comprueba que los parámetros se puedan interpretar como en GET /tasks
+func (req *SavedFilterRequest) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("name no puede estar vacío")
+	}
+	var probe TaskFilterRequest
+	probe.ApplySavedFilter(req.Params())
+	_, err := probe.ToTaskFilter("")
+	return err
+}
+
 // FilterRequest alias para request generator
 type FilterRequest = TaskFilterRequest
@@ -0,0 +1,47 @@
+package requests
+
+import "uniflow-api/internal/domain"
+
+// UpdatePreferencesRequest estructura para PUT /me/preferences; solo se
+// cambian los campos presentes
+type UpdatePreferencesRequest struct {
+	TimeZone             *string                 `json:"timeZone"` // IANA, ej. "America/Costa_Rica"
+	Locale               *string                 `json:"locale"`   // BCP 47, ej. "es-CR"
+	ReminderOffsetsHours *[]int                  `json:"reminderOffsetsHours"`
+	DailyCapacityHours   *int                    `json:"dailyCapacityHours"`
+	WeekStart            *string                 `json:"weekStart"` // monday..sunday
+	Dashboard            *DashboardLimitsRequest `json:"dashboard"`
+}
+
+// DashboardLimitsRequest límites de las listas del dashboard
+type DashboardLimitsRequest struct {
+	Upcoming *int `json:"upcoming"`
+	Today    *int `json:"today"`
+}
+
+// ApplyTo copia los campos presentes sobre p
+func (req *UpdatePreferencesRequest) ApplyTo(p *domain.UserPreferences) {
+	if req.TimeZone != nil {
+		p.TimeZone = *req.TimeZone
+	}
+	if req.Locale != nil {
+		p.Locale = *req.Locale
+	}
+	if req.ReminderOffsetsHours != nil {
+		p.ReminderOffsetsHours = append([]int{}, *req.ReminderOffsetsHours...)
+	}
+	if req.DailyCapacityHours != nil {
+		p.DailyCapacityHours = *req.DailyCapacityHours
+	}
+	if req.WeekStart != nil {
+		p.WeekStart = *req.WeekStart
+	}
+	if req.Dashboard != nil {
+		if req.Dashboard.Upcoming != nil {
+			p.Dashboard.Upcoming = *req.Dashboard.Upcoming
+		}
+		if req.Dashboard.Today != nil {
+			p.Dashboard.Today = *req.Dashboard.Today
+		}
+	}
+}
@@ -2,6 +2,8 @@ package requests
 
 import (
 	"time"
+
+	"uniflow-api/internal/domain"
 )
 
 // CreateTaskRequest estructura para POST /tasks
@@ -14,6 +16,8 @@ type CreateTaskRequest struct {
 	Priority           string    `json:"priority" binding:"required,oneof=low medium high urgent"`
 	Type               string    `json:"type" binding:"required,oneof=assignment exam reading presentation lab quiz essay group-work"`
 	EstimatedTimeHours int       `json:"estimatedTimeHours"`
+	GradeWeight        float64   `json:"gradeWeight" binding:"gte=0,lte=100"`
+	IsBlocked          bool      `json:"isBlocked"`
 	Tags               []string  `json:"tags"`
 	IsGroupWork        bool      `json:"isGroupWork"`
 	GroupMembers       []string  `json:"groupMembers"`
@@ -29,6 +33,8 @@ type UpdateTaskRequest struct {
 	Priority           string    `json:"priority" binding:"required,oneof=low medium high urgent"`
 	Type               string    `json:"type" binding:"required,oneof=assignment exam reading presentation lab quiz essay group-work"`
 	EstimatedTimeHours int       `json:"estimatedTimeHours"`
+	GradeWeight        float64   `json:"gradeWeight" binding:"gte=0,lte=100"`
+	IsBlocked          bool      `json:"isBlocked"`
 	Tags               []string  `json:"tags"`
 	IsGroupWork        bool      `json:"isGroupWork"`
 	GroupMembers       []string  `json:"groupMembers"`
@@ -43,3 +49,79 @@ type UpdateTaskStatusRequest struct {
 type UpdateTaskCompleteRequest struct {
 	ActualTimeHours int `json:"actualTimeHours"`
 }
+
+// QuickAddRequest estructura para POST /tasks/quick
+type QuickAddRequest struct {
+	Text      string     `json:"text" binding:"required,max=500"`
+	SubjectID string     `json:"subjectId"` // Sobrescribe la materia detectada
+	PeriodID  string     `json:"periodId"`
+	DueDate   *time.Time `json:"dueDate"`  // Sobrescribe la fecha detectada
+	TimeZone  string     `json:"timezone"` // Ej: "America/Costa_Rica" (default: preferencia del usuario)
+	Preview   bool       `json:"preview"`  // Solo interpreta, no crea
+}
+
+// AddCollaboratorRequest estructura para POST /tasks/:id/collaborators
+// (userId o email, no ambos)
+type AddCollaboratorRequest struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email" binding:"omitempty,email"`
+	Role   string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// UpdateCollaboratorRequest estructura para PATCH /tasks/:id/collaborators/:member
+type UpdateCollaboratorRequest struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// CommentRequest estructura para POST y PATCH /tasks/:id/comments
+type CommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// TemplateRequest estructura para POST y PUT /templates
+type TemplateRequest struct {
+	Name               string                `json:"name" binding:"required"`
+	Description        string                `json:"description"`
+	Type               string                `json:"type"`     // default: assignment
+	Priority           string                `json:"priority"` // default: medium
+	EstimatedTimeHours int                   `json:"estimatedTimeHours"`
+	Tags               []string              `json:"tags"`
+	Items              []domain.TemplateItem `json:"items"`
+}
+
+// ToDomain plantilla sin normalizar (la valida el servicio)
+func (req *TemplateRequest) ToDomain() *domain.TaskTemplate {
+	return &domain.TaskTemplate{
+		Name:               req.Name,
+		Description:        req.Description,
+		Type:               req.Type,
+		Priority:           req.Priority,
+		EstimatedTimeHours: req.EstimatedTimeHours,
+		Tags:               req.Tags,
+		Items:              req.Items,
+	}
+}
+
+// FromTemplateRequest estructura para POST /tasks/from-template/:id
+type FromTemplateRequest struct {
+	DueDate   time.Time `json:"dueDate" binding:"required"` // Fecha objetivo (entrega final)
+	SubjectID string    `json:"subjectId" binding:"required"`
+	PeriodID  string    `json:"periodId"`
+	Title     string    `json:"title"`    // Prefijo de los títulos (default: nombre de la plantilla)
+	TimeZone  string    `json:"timeZone"` // Default: preferencia del usuario
+}
+
+// SaveAsTemplateRequest estructura para POST /tasks/:id/template
+type SaveAsTemplateRequest struct {
+	Name string `json:"name"` // Default: título de la tarea
+}
+
+// ClonePeriodRequest estructura para POST /periods/:from/clone
+type ClonePeriodRequest struct {
+	ToPeriodID string   `json:"toPeriodId" binding:"required"`
+	ToStart    string   `json:"toStart" binding:"required"` // "2006-01-02", inicio del período destino
+	FromStart  string   `json:"fromStart"`                  // Default: semana de la primera tarea del origen
+	SubjectIDs []string `json:"subjectIds"`                 // Vacío = todas las materias
+	TimeZone   string   `json:"timeZone"`                   // Default: preferencia del usuario
+	Preview    bool     `json:"preview"`                    // Solo devuelve el plan, no crea
+}
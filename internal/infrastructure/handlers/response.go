@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+
 	"uniflow-api/internal/domain"
 )
 
@@ -17,10 +20,15 @@ type TaskDTO struct {
 	Type               string   `json:"type"`
 	EstimatedTimeHours int      `json:"estimatedTimeHours"`
 	ActualTimeHours    *int     `json:"actualTimeHours,omitempty"`
+	GradeWeight        float64  `json:"gradeWeight"`
+	IsBlocked          bool     `json:"isBlocked"`
 	Tags               []string `json:"tags"`
 	IsGroupWork        bool     `json:"isGroupWork"`
 	GroupMembers       []string `json:"groupMembers"`
 	Attachments        []string `json:"attachments"`
+	ExternalID         string   `json:"externalId,omitempty"`
+	SharedTaskID       string   `json:"sharedTaskId,omitempty"` // Tarea del dueño (copias de colaboradores)
+	Role               string   `json:"role"`                   // owner, editor o viewer
 	CreatedAt          string   `json:"createdAt"`
 	UpdatedAt          string   `json:"updatedAt"`
 	CompletedAt        *string  `json:"completedAt,omitempty"`
@@ -40,10 +48,15 @@ func TaskFromDomain(t *domain.Task) TaskDTO {
 		Type:               t.Type,
 		EstimatedTimeHours: t.EstimatedTimeHours,
 		ActualTimeHours:    t.ActualTimeHours,
+		GradeWeight:        t.GradeWeight,
+		IsBlocked:          t.IsBlocked,
 		Tags:               t.Tags,
 		IsGroupWork:        t.IsGroupWork,
 		GroupMembers:       t.GroupMembers,
 		Attachments:        t.Attachments,
+		ExternalID:         t.ExternalID,
+		SharedTaskID:       t.SharedTaskID,
+		Role:               t.AccessRole(),
 		CreatedAt:          t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:          t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -54,6 +67,23 @@ func TaskFromDomain(t *domain.Task) TaskDTO {
 	return dto
 }
 
+// ScoredTaskDTO tarea con su score de urgencia y la explicación de cada factor
+type ScoredTaskDTO struct {
+	Rank       int                     `json:"rank"`
+	Task       TaskDTO                 `json:"task"`
+	Score      float64                 `json:"score"`
+	Blocked    bool                    `json:"blocked"`
+	Components []domain.ScoreComponent `json:"components"`
+}
+
+// SearchResultDTO resultado de GET /tasks/search: la tarea más su relevancia
+// y los fragmentos con las coincidencias marcadas con <mark> (texto escapado)
+type SearchResultDTO struct {
+	TaskDTO
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
 // GetTasksResponse estructura de respuesta para GET /tasks
 type GetTasksResponse struct {
 	Data       []TaskDTO  `json:"data"`
@@ -92,3 +122,18 @@ func NewErrorResponse(code, message string) ErrorResponse {
 		Message: message,
 	}
 }
+
+// NewFilterErrorResponse error de parámetros de filtro; los errores de la
+// expresión q indican la posición y el token problemático
+func NewFilterErrorResponse(err error) ErrorResponse {
+	var qe *domain.QueryError
+	if errors.As(err, &qe) {
+		resp := NewErrorResponse("INVALID_QUERY", qe.Error())
+		resp.Details = fmt.Sprintf("position=%d token=%q", qe.Pos+1, qe.Token)
+		return resp
+	}
+	if errors.Is(err, domain.ErrInvalidTimeZone) {
+		return NewErrorResponse(domain.ErrInvalidTimeZone.Code, err.Error())
+	}
+	return NewErrorResponse("INVALID_FILTER", err.Error())
+}
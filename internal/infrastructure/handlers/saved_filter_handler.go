@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithSavedFilters habilita las listas guardadas (/saved-filters, GET /tasks?view=
+// y sus conteos en el dashboard)
+func (th *TaskHandler) WithSavedFilters(ss *application.SavedFilterService) *TaskHandler {
+	th.savedFilters = ss
+	return th
+}
+
+// savedFilterError responde según el tipo de error del servicio
+func savedFilterError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		switch de {
+		case domain.ErrSavedFilterNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Filtro guardado no encontrado"))
+			return
+		case domain.ErrSavedFilterNameTaken:
+			c.JSON(http.StatusConflict, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrSavedFilterLimit:
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(de.Code, de.Message))
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// applyView carga el filtro guardado indicado en ?view= sobre filterReq.
+// Retorna false si ya respondió con error.
+func (th *TaskHandler) applyView(ctx context.Context, c *gin.Context, userID string, filterReq *requests.TaskFilterRequest) bool {
+	if filterReq.View == "" {
+		return true
+	}
+	if th.savedFilters == nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILTER", "los filtros guardados no están habilitados"))
+		return false
+	}
+
+	saved, err := th.savedFilters.GetSavedFilter(ctx, filterReq.View, userID)
+	if err != nil {
+		savedFilterError(c, err)
+		return false
+	}
+	filterReq.ApplySavedFilter(saved.Params)
+	return true
+}
+
+// savedFilterCounts cuenta las tareas de cada lista guardada del usuario;
+// las listas que ya no se pueden interpretar se omiten
+func (th *TaskHandler) savedFilterCounts(ctx context.Context, userID, tz, weekStart string) ([]domain.SavedFilterCount, error) {
+	saved, err := th.savedFilters.ListSavedFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.SavedFilterCount, 0, len(saved))
+	for _, sf := range saved {
+		req := requests.TaskFilterRequest{TimeZone: tz, WeekStart: weekStart, Limit: 1}
+		req.ApplySavedFilter(sf.Params)
+		filter, err := req.ToTaskFilter(userID)
+		if err != nil {
+			log.Printf("⚠️ Filtro guardado %s inválido: %v", sf.ID, err)
+			continue
+		}
+		_, pageInfo, err := th.taskService.GetTasksFiltered(ctx, *filter)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, domain.SavedFilterCount{ID: sf.ID, Name: sf.Name, Count: pageInfo.Total})
+	}
+
+	return counts, nil
+}
+
+// ListSavedFilters maneja GET /saved-filters
+func (th *TaskHandler) ListSavedFilters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	filters, err := th.savedFilters.ListSavedFilters(ctx, userID)
+	if err != nil {
+		savedFilterError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": filters})
+}
+
+// CreateSavedFilter maneja POST /saved-filters
+func (th *TaskHandler) CreateSavedFilter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, NewFilterErrorResponse(err))
+		return
+	}
+
+	saved, err := th.savedFilters.CreateSavedFilter(ctx, userID, req.Name, req.Params())
+	if err != nil {
+		savedFilterError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+// GetSavedFilter maneja GET /saved-filters/:id
+func (th *TaskHandler) GetSavedFilter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	saved, err := th.savedFilters.GetSavedFilter(ctx, c.Param("id"), userID)
+	if err != nil {
+		savedFilterError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// UpdateSavedFilter maneja PUT /saved-filters/:id
+func (th *TaskHandler) UpdateSavedFilter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, NewFilterErrorResponse(err))
+		return
+	}
+
+	saved, err := th.savedFilters.UpdateSavedFilter(ctx, c.Param("id"), userID, req.Name, req.Params())
+	if err != nil {
+		savedFilterError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSavedFilter maneja DELETE /saved-filters/:id
+func (th *TaskHandler) DeleteSavedFilter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.savedFilters.DeleteSavedFilter(ctx, c.Param("id"), userID); err != nil {
+		savedFilterError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
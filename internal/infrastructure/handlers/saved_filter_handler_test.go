@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupSavedFilterRouter(t *testing.T) (*gin.Engine, *application.TaskService) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			userID = "user-test"
+		}
+		c.Set("userID", userID)
+		c.Next()
+	})
+
+	service := application.NewTaskService(memory.NewRepo(), nil)
+	h := NewTaskHandler(service).WithSavedFilters(application.NewSavedFilterService(memory.NewSavedFilterRepo()))
+
+	r.GET("/tasks", h.GetTasks)
+	r.GET("/tasks/dashboard", h.GetDashboard)
+	r.GET("/saved-filters", h.ListSavedFilters)
+	r.POST("/saved-filters", h.CreateSavedFilter)
+	r.GET("/saved-filters/:id", h.GetSavedFilter)
+	r.PUT("/saved-filters/:id", h.UpdateSavedFilter)
+	r.DELETE("/saved-filters/:id", h.DeleteSavedFilter)
+
+	now := time.Now()
+	for _, task := range []*domain.Task{
+		{Title: "Parcial", Priority: domain.PriorityUrgent, DueDate: now.Add(48 * time.Hour)},
+		{Title: "Proyecto", Priority: domain.PriorityUrgent, DueDate: now.AddDate(0, 0, 20), IsGroupWork: true},
+		{Title: "Lectura", Priority: domain.PriorityLow, DueDate: now.Add(24 * time.Hour)},
+	} {
+		task.UserID, task.SubjectID, task.Type, task.Status = "user-test", "subject-ic-6821", domain.TypeAssignment, domain.StatusTodo
+		if err := service.CreateTask(context.Background(), task, "user-test", "Test User", "test@uniflow.edu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r, service
+}
+
+func doSaved(r http.Handler, method, path, userID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestSavedFilterView(t *testing.T) {
+	r, _ := setupSavedFilterRouter(t)
+
+	w := doSaved(r, "POST", "/saved-filters", "", `{"name": "Urgentes próximos 7 días", "priority": "urgent", "q": "due:next-7d"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var saved domain.SavedFilter
+	_ = json.Unmarshal(w.Body.Bytes(), &saved)
+
+	var page struct {
+		Data []TaskDTO `json:"data"`
+	}
+	w = doSaved(r, "GET", "/tasks?view="+saved.ID, "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if w.Code != http.StatusOK || len(page.Data) != 1 || page.Data[0].Title != "Parcial" {
+		t.Fatalf("unexpected view result %d %s", w.Code, w.Body.String())
+	}
+
+	// q del request acota la lista guardada
+	w = doSaved(r, "GET", "/tasks?view="+saved.ID+"&q=title:lectura", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 0 {
+		t.Errorf("expected request q to narrow the view, got %d tasks", len(page.Data))
+	}
+
+	// Conteos en el dashboard
+	w = doSaved(r, "GET", "/tasks/dashboard", "", "")
+	var dashboard domain.DashboardData
+	_ = json.Unmarshal(w.Body.Bytes(), &dashboard)
+	if len(dashboard.SavedFilters) != 1 || dashboard.SavedFilters[0].Count != 1 {
+		t.Errorf("unexpected dashboard counts %+v", dashboard.SavedFilters)
+	}
+
+	// Otro usuario no puede usarla
+	if w = doSaved(r, "GET", "/tasks?view="+saved.ID, "user-b", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's view, got %d", w.Code)
+	}
+
+	if w = doSaved(r, "DELETE", "/saved-filters/"+saved.ID, "", ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+	if w = doSaved(r, "GET", "/saved-filters/"+saved.ID, "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestSavedFilterValidation(t *testing.T) {
+	r, _ := setupSavedFilterRouter(t)
+
+	w := doSaved(r, "POST", "/saved-filters", "", `{"name": "Rota", "q": "estimated>muchas"}`)
+	var errResp ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if w.Code != http.StatusBadRequest || errResp.Code != "INVALID_QUERY" {
+		t.Errorf("expected INVALID_QUERY, got %d %+v", w.Code, errResp)
+	}
+
+	if w = doSaved(r, "POST", "/saved-filters", "", `{"name": "Grupales", "q": "is:group"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if w = doSaved(r, "POST", "/saved-filters", "", `{"name": "grupales", "status": "todo"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for duplicated name, got %d", w.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Parámetros del stream SSE
+const (
+	defaultHeartbeat = 15 * time.Second
+	sseRetryMs       = 3000 // espera sugerida al cliente antes de reconectar
+)
+
+// StreamHandler expone los eventos de tareas como Server-Sent Events
+type StreamHandler struct {
+	hub       *application.EventHub
+	heartbeat time.Duration
+}
+
+// NewStreamHandler crea un nuevo StreamHandler
+func NewStreamHandler(hub *application.EventHub, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	return &StreamHandler{
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+}
+
+// TaskEventDTO datos de un evento enviado por SSE
+type TaskEventDTO struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	TaskID     string   `json:"taskId"`
+	OccurredAt string   `json:"occurredAt"`
+	Task       *TaskDTO `json:"task,omitempty"`
+}
+
+func taskEventFromDomain(e *domain.TaskEvent) TaskEventDTO {
+	dto := TaskEventDTO{
+		ID:         e.ID,
+		Type:       e.Type,
+		TaskID:     e.TaskID,
+		OccurredAt: e.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if e.Task != nil {
+		t := TaskFromDomain(e.Task)
+		dto.Task = &t
+	}
+	return dto
+}
+
+// Stream maneja GET /events/stream
+// Envía los eventos de tareas del usuario (event: task.created, task.updated, ...),
+// un comentario de heartbeat periódico y reanuda desde Last-Event-ID
+// (header, o query lastEventId) usando el buffer de replay. Si el ID ya no está
+// en el buffer envía "event: reset" para que el cliente recargue su estado.
+func (sh *StreamHandler) Stream(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+
+	sub, replay, resumed := sh.hub.Subscribe(userID, lastEventID)
+	defer sh.hub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx / proxies: no bufferizar
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryMs)
+	if !resumed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for i := range replay {
+		if err := writeSSEEvent(w, &replay[i]); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(sh.heartbeat)
+	defer ticker.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, open := <-sub.C:
+			if !open {
+				// Cortado por lento: el cliente reconecta con Last-Event-ID
+				return
+			}
+			if err := writeSSEEvent(w, &event); err != nil {
+				return
+			}
+			w.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
+
+func writeSSEEvent(w gin.ResponseWriter, e *domain.TaskEvent) error {
+	data, err := json.Marshal(taskEventFromDomain(e))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/broadcast"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readSSE lee líneas del stream hasta encontrar want o expirar
+func readSSE(t *testing.T, sc *bufio.Scanner, want string) []string {
+	t.Helper()
+	var lines []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for sc.Scan() {
+			lines = append(lines, sc.Text())
+			if strings.Contains(sc.Text(), want) {
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout waiting for %q", want)
+	}
+	return lines
+}
+
+func openStream(t *testing.T, url, lastEventID string) (*bufio.Scanner, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", url+"/events/stream", nil)
+	req.Header.Set("X-User-ID", "user-test")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	return bufio.NewScanner(resp.Body), func() { cancel(); resp.Body.Close() }
+}
+
+func TestEventStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := application.NewEventHub(broadcast.NewLocal(), application.EventHubConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-User-ID"))
+		c.Next()
+	})
+	r.GET("/events/stream", NewStreamHandler(hub, 50*time.Millisecond).Stream)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sc, closeStream := openStream(t, server.URL, "")
+	readSSE(t, sc, "retry:")
+
+	task := &domain.Task{ID: "t-1", Title: "Quiz", Status: domain.StatusTodo}
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "evt_1", Type: domain.EventTaskCreated, UserID: "user-test", TaskID: "t-1", Task: task})
+	lines := readSSE(t, sc, "data:")
+	joined := strings.Join(lines, "\n")
+	if !strings.Contains(joined, "id: evt_1") || !strings.Contains(joined, "event: task.created") || !strings.Contains(joined, `"title":"Quiz"`) {
+		t.Errorf("unexpected event frame:\n%s", joined)
+	}
+
+	readSSE(t, sc, ": heartbeat")
+	closeStream()
+
+	// Evento mientras el cliente está desconectado
+	hub.Publish(context.Background(), domain.TaskEvent{ID: "evt_2", Type: domain.EventTaskDeleted, UserID: "user-test", TaskID: "t-1"})
+	time.Sleep(20 * time.Millisecond)
+
+	sc, closeStream = openStream(t, server.URL, "evt_1")
+	lines = readSSE(t, sc, "id: evt_2")
+	closeStream()
+	if strings.Contains(strings.Join(lines, "\n"), "evt_1") {
+		t.Error("resumed stream should not replay evt_1")
+	}
+
+	// ID desconocido: el cliente debe recargar
+	sc, closeStream = openStream(t, server.URL, "evt_unknown")
+	readSSE(t, sc, "event: reset")
+	closeStream()
+}
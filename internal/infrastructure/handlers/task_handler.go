@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,13 +11,17 @@ import (
 	"uniflow-api/internal/application/ports"
 	"uniflow-api/internal/domain"
 	"uniflow-api/internal/infrastructure/handlers/requests"
+	"uniflow-api/internal/infrastructure/search"
 
 	"github.com/gin-gonic/gin"
 )
 
 // TaskHandler maneja operaciones de tareas
 type TaskHandler struct {
-	taskService *application.TaskService
+	taskService  *application.TaskService
+	savedFilters *application.SavedFilterService // opcional (WithSavedFilters)
+	preferences  *application.PreferencesService // opcional (WithPreferences)
+	templates    *application.TemplateService    // opcional (WithTemplates)
 }
 
 // NewTaskHandler crea un nuevo TaskHandler
@@ -60,10 +65,22 @@ func (th *TaskHandler) GetTasks(c *gin.Context) {
 		return
 	}
 
+	// Lista guardada (?view=) como base del filtro
+	if !th.applyView(ctx, c, userID, &filterReq) {
+		return
+	}
+
+	// Fechas, "hoy" y "esta semana" según las preferencias del usuario
+	prefs, loc, ok := th.userPreferences(ctx, c, userID, filterReq.TimeZone)
+	if !ok {
+		return
+	}
+	filterReq.TimeZone, filterReq.WeekStart = loc.String(), prefs.WeekStart
+
 	// Convertir a domain filter
 	filter, err := filterReq.ToTaskFilter(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_FILTER", err.Error()))
+		c.JSON(http.StatusBadRequest, NewFilterErrorResponse(err))
 		return
 	}
 	if filter == nil {
@@ -73,6 +90,11 @@ func (th *TaskHandler) GetTasks(c *gin.Context) {
 	// Obtener tareas filtradas
 	tasks, pageInfo, err := th.taskService.GetTasksFiltered(ctx, *filter)
 	if err != nil {
+		var de *domain.DomainError
+		if errors.As(err, &de) && (de == domain.ErrInvalidCursor || de == domain.ErrCursorUnsupported) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(de.Code, de.Message))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
 		return
 	}
@@ -87,16 +109,26 @@ func (th *TaskHandler) GetTasks(c *gin.Context) {
 		"pagination": gin.H{
 			"page":       pageInfo.Page,
 			"limit":      pageInfo.Limit,
-			"total":      pageInfo.Total,
-			"totalPages": pageInfo.TotalPages,
+			"total":      optionalCount(pageInfo.Total),
+			"totalPages": optionalCount(pageInfo.TotalPages),
 			"hasNext":    pageInfo.HasNext,
 			"hasPrev":    pageInfo.HasPrev,
+			"nextCursor": pageInfo.NextCursor,
+			"prevCursor": pageInfo.PrevCursor,
 		},
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// optionalCount null en JSON cuando el conteo se omitió (count=false)
+func optionalCount(n int64) interface{} {
+	if n < 0 {
+		return nil
+	}
+	return n
+}
+
 // CreateTask maneja POST /tasks
 func (th *TaskHandler) CreateTask(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
@@ -125,6 +157,8 @@ func (th *TaskHandler) CreateTask(c *gin.Context) {
 		Type:               req.Type,
 		Status:             domain.StatusTodo,
 		EstimatedTimeHours: req.EstimatedTimeHours,
+		GradeWeight:        req.GradeWeight,
+		IsBlocked:          req.IsBlocked,
 		Tags:               req.Tags,
 		IsGroupWork:        req.IsGroupWork,
 		GroupMembers:       req.GroupMembers,
@@ -198,12 +232,18 @@ func (th *TaskHandler) UpdateTask(c *gin.Context) {
 	task.Priority = req.Priority
 	task.Type = req.Type
 	task.EstimatedTimeHours = req.EstimatedTimeHours
+	task.GradeWeight = req.GradeWeight
+	task.IsBlocked = req.IsBlocked
 	task.Tags = req.Tags
 	task.IsGroupWork = req.IsGroupWork
 	task.GroupMembers = req.GroupMembers
 	task.UpdatedAt = time.Now()
 
 	if err := th.taskService.UpdateTask(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			c.JSON(http.StatusForbidden, NewErrorResponse(domain.ErrForbidden.Code, "los colaboradores con rol viewer no pueden editar la tarea"))
+			return
+		}
 		c.JSON(http.StatusConflict, NewErrorResponse("CONFLICT", err.Error()))
 		return
 	}
@@ -327,39 +367,51 @@ func (th *TaskHandler) SearchTasks(c *gin.Context) {
 		return
 	}
 
-	limit := 20
+	// Orden por relevancia salvo que se pida otro (sortBy/sortOrder)
+	filterReq := requests.TaskFilterRequest{
+		Search:    query,
+		SortBy:    c.Query("sortBy"),
+		SortOrder: c.Query("sortOrder"),
+	}
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			filterReq.Page = parsed
+		}
+	}
 	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
-			limit = parsed
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			filterReq.Limit = parsed
 		}
 	}
-
-	// Crear filter con búsqueda
-	filter := ports.TaskFilter{
-		UserID:    userID,
-		Search:    query,
-		Limit:     limit,
-		Page:      1,
-		SortBy:    "createdAt",
-		SortOrder: "desc",
+	filter, err := filterReq.ToTaskFilter(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewFilterErrorResponse(err))
+		return
 	}
 
-	tasks, pageInfo, err := th.taskService.GetTasksFiltered(ctx, filter)
+	tasks, pageInfo, err := th.taskService.GetTasksFiltered(ctx, *filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, NewErrorResponse("SEARCH_ERROR", err.Error()))
 		return
 	}
 
-	taskDTOs := make([]TaskDTO, len(tasks))
+	q := search.ParseQuery(query)
+	results := make([]SearchResultDTO, len(tasks))
 	for i, t := range tasks {
-		taskDTOs[i] = TaskFromDomain(&t)
+		results[i] = SearchResultDTO{
+			TaskDTO:    TaskFromDomain(&t),
+			Score:      search.Score(&t, q),
+			Highlights: search.Highlights(&t, q),
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"query":      query,
-		"results":    taskDTOs,
-		"count":      len(taskDTOs),
+		"results":    results,
+		"count":      len(results),
 		"totalFound": pageInfo.Total,
+		"page":       pageInfo.Page,
+		"hasNext":    pageInfo.HasNext,
 	})
 }
 
@@ -374,7 +426,11 @@ func (th *TaskHandler) GetOverdue(c *gin.Context) {
 		return
 	}
 
-	tz := c.DefaultQuery("tz", "UTC")
+	loc, ok := th.location(ctx, c, userID, c.Query("tz"))
+	if !ok {
+		return
+	}
+	tz := loc.String()
 
 	filter := ports.TaskFilter{
 		UserID:    userID,
@@ -542,19 +598,75 @@ func (th *TaskHandler) GetDashboard(c *gin.Context) {
 		return
 	}
 
-	// limit es opcional, default 5
-	limitStr := c.DefaultQuery("limit", "5")
-	limit := 5
-	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-		limit = l
+	prefs, loc, ok := th.userPreferences(ctx, c, userID, c.Query("tz"))
+	if !ok {
+		return
 	}
-	_ = limit // Por ahora no se usa en los datos, pero está para futuro
+	opts := prefs.DashboardOptions(loc)
 
-	dashboard, err := th.taskService.GetDashboard(ctx, userID)
+	// limit es opcional: reemplaza la cantidad de próximas de las preferencias
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		opts.UpcomingLimit = l
+	}
+
+	dashboard, err := th.taskService.GetDashboard(ctx, userID, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
 		return
 	}
 
+	// Conteo de cada lista guardada
+	if th.savedFilters != nil {
+		counts, err := th.savedFilterCounts(ctx, userID, loc.String(), prefs.WeekStart)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+			return
+		}
+		dashboard.SavedFilters = counts
+	}
+
 	c.JSON(http.StatusOK, dashboard)
 }
+
+// GetNextTasks maneja GET /tasks/next
+// Retorna las tareas pendientes rankeadas por score de urgencia
+func (th *TaskHandler) GetNextTasks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	limit := 5
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 50 {
+			limit = parsed
+		}
+	}
+
+	ranked, err := th.taskService.GetNextTasks(ctx, userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+		return
+	}
+
+	items := make([]ScoredTaskDTO, len(ranked))
+	for i := range ranked {
+		items[i] = ScoredTaskDTO{
+			Rank:       i + 1,
+			Task:       TaskFromDomain(&ranked[i].Task),
+			Score:      ranked[i].Score.Score,
+			Blocked:    ranked[i].Score.Blocked,
+			Components: ranked[i].Score.Components,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks":       items,
+		"count":       len(items),
+		"generatedAt": time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
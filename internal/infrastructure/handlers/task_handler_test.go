@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,3 +100,217 @@ func TestSearchTasks(t *testing.T) {
 		t.Errorf("Expected 200, got %d", w.Code)
 	}
 }
+
+func TestSearchTasksRelevanceAndHighlights(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks/search", handler.SearchTasks)
+
+	due := time.Now().Add(72 * time.Hour)
+	for _, task := range []*domain.Task{
+		{Title: "Repaso general", Description: "Ejercicios de cálculo integral"},
+		{Title: "Parcial de Cálculo", Tags: []string{"parcial"}},
+		{Title: "Informe de física"},
+	} {
+		task.UserID, task.SubjectID, task.DueDate, task.Type = "user-test", "subject-ic-6821", due, domain.TypeAssignment
+		task.Status, task.Priority = domain.StatusTodo, domain.PriorityMedium
+		if err := service.CreateTask(context.Background(), task, "user-test", "Test User", "test@uniflow.edu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/search?q=calc", nil)
+	r.ServeHTTP(w, req)
+
+	var response struct {
+		Results []SearchResultDTO `json:"results"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if w.Code != http.StatusOK || len(response.Results) != 2 {
+		t.Fatalf("unexpected result %d %s", w.Code, w.Body.String())
+	}
+	first, second := response.Results[0], response.Results[1]
+	if first.Title != "Parcial de Cálculo" || first.Score <= second.Score {
+		t.Errorf("title match should rank first: %+v", response.Results)
+	}
+	if first.Highlights["title"] != "Parcial de <mark>Cálculo</mark>" || second.Highlights["description"] != "Ejercicios de <mark>cálculo</mark> integral" {
+		t.Errorf("unexpected highlights %v / %v", first.Highlights, second.Highlights)
+	}
+
+	// Error de tipeo con término completo
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/search?q="+url.QueryEscape("fisca "), nil)
+	r.ServeHTTP(w, req)
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response.Results) != 1 || response.Results[0].Title != "Informe de física" {
+		t.Errorf("expected fuzzy match, got %s", w.Body.String())
+	}
+
+	// relevance solo tiene sentido con búsqueda
+	r.GET("/tasks", handler.GetTasks)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks?sortBy=relevance", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for relevance without search, got %d", w.Code)
+	}
+}
+
+func TestGetTasksCursorPagination(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks", handler.GetTasks)
+
+	base := time.Now().Add(24 * time.Hour)
+	for i := 0; i < 5; i++ {
+		task := &domain.Task{
+			UserID:    "user-test",
+			Title:     "Tarea",
+			SubjectID: "subject-ic-6821",
+			Status:    domain.StatusTodo,
+			Priority:  domain.PriorityMedium,
+			Type:      domain.TypeAssignment,
+			DueDate:   base.Add(time.Duration(i) * time.Hour),
+		}
+		if err := service.CreateTask(context.Background(), task, "user-test", "Test User", "test@uniflow.edu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type page struct {
+		Data       []TaskDTO `json:"data"`
+		Pagination struct {
+			Total      *int64 `json:"total"`
+			HasNext    bool   `json:"hasNext"`
+			NextCursor string `json:"nextCursor"`
+		} `json:"pagination"`
+	}
+	get := func(query string) (int, page) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?"+query, nil)
+		r.ServeHTTP(w, req)
+		var p page
+		_ = json.Unmarshal(w.Body.Bytes(), &p)
+		return w.Code, p
+	}
+
+	code, first := get("limit=2&count=false")
+	if code != http.StatusOK || len(first.Data) != 2 || first.Pagination.Total != nil || first.Pagination.NextCursor == "" {
+		t.Fatalf("unexpected first page %d %+v", code, first.Pagination)
+	}
+
+	seen := map[string]bool{}
+	for _, d := range first.Data {
+		seen[d.ID] = true
+	}
+	cursor := first.Pagination.NextCursor
+	for cursor != "" {
+		code, p := get("limit=2&after=" + cursor)
+		if code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+		for _, d := range p.Data {
+			if seen[d.ID] {
+				t.Fatalf("duplicate task %s across pages", d.ID)
+			}
+			seen[d.ID] = true
+		}
+		cursor = p.Pagination.NextCursor
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected 5 tasks across pages, got %d", len(seen))
+	}
+
+	// Un cursor de otro orden o corrupto es un error del cliente
+	if code, _ := get("limit=2&sortBy=priority&after=" + first.Pagination.NextCursor); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for cursor from another sort, got %d", code)
+	}
+	if code, _ := get("after=x&before=y"); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for after+before, got %d", code)
+	}
+}
+
+func TestGetTasksCompositeSort(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks", handler.GetTasks)
+
+	due := time.Now().Add(48 * time.Hour)
+	for i, p := range []string{domain.PriorityHigh, domain.PriorityLow, domain.PriorityUrgent, domain.PriorityMedium} {
+		task := &domain.Task{
+			UserID:    "user-test",
+			Title:     p,
+			SubjectID: "subject-ic-6821",
+			Status:    domain.StatusTodo,
+			Priority:  p,
+			Type:      domain.TypeAssignment,
+			DueDate:   due.Add(time.Duration(i) * time.Hour),
+		}
+		if err := service.CreateTask(context.Background(), task, "user-test", "Test User", "test@uniflow.edu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks?sort=priority:desc,dueDate:asc", nil)
+	r.ServeHTTP(w, req)
+
+	var response struct {
+		Data []TaskDTO `json:"data"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	var got []string
+	for _, d := range response.Data {
+		got = append(got, d.Priority)
+	}
+	if w.Code != http.StatusOK || strings.Join(got, ",") != "urgent,high,medium,low" {
+		t.Fatalf("expected semantic priority order, got %d %v", w.Code, got)
+	}
+
+	for _, q := range []string{"sort=owner:asc", "sortBy=owner"} {
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/tasks?"+q, nil)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d", q, w.Code)
+		}
+	}
+}
+
+func TestGetTasksQueryExpression(t *testing.T) {
+	r, handler, service := setupTestRouter()
+	r.GET("/tasks", handler.GetTasks)
+
+	due := time.Now().Add(72 * time.Hour)
+	for _, task := range []*domain.Task{
+		{Title: "Parcial", Type: domain.TypeExam, Tags: []string{"parcial"}, EstimatedTimeHours: 6},
+		{Title: "Lectura", Type: domain.TypeReading, Tags: []string{"parcial"}, EstimatedTimeHours: 8},
+		{Title: "Tarea corta", Type: domain.TypeAssignment, Tags: []string{"parcial"}, EstimatedTimeHours: 1},
+	} {
+		task.UserID, task.SubjectID, task.DueDate = "user-test", "subject-ic-6821", due
+		task.Status, task.Priority = domain.StatusTodo, domain.PriorityMedium
+		if err := service.CreateTask(context.Background(), task, "user-test", "Test User", "test@uniflow.edu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks?q="+url.QueryEscape("tag:parcial AND estimated>4 AND -type:reading"), nil)
+	r.ServeHTTP(w, req)
+
+	var response struct {
+		Data []TaskDTO `json:"data"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if w.Code != http.StatusOK || len(response.Data) != 1 || response.Data[0].Title != "Parcial" {
+		t.Fatalf("unexpected result %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks?q="+url.QueryEscape("tag:parcial AND owner:me"), nil)
+	r.ServeHTTP(w, req)
+
+	var errResp ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if w.Code != http.StatusBadRequest || errResp.Code != "INVALID_QUERY" || !strings.Contains(errResp.Details, `token="owner"`) {
+		t.Errorf("expected INVALID_QUERY pointing at owner, got %d %+v", w.Code, errResp)
+	}
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/handlers/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithTemplates habilita las plantillas (/templates, POST /tasks/from-template/:id
+// y POST /tasks/:id/template)
+func (th *TaskHandler) WithTemplates(ts *application.TemplateService) *TaskHandler {
+	th.templates = ts
+	return th
+}
+
+// templateError responde según el tipo de error de las plantillas
+func templateError(c *gin.Context, err error) {
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		switch de {
+		case domain.ErrTaskNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Tarea no encontrada"))
+			return
+		case domain.ErrTemplateNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrTemplateReadOnly:
+			c.JSON(http.StatusForbidden, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrTemplateNameTaken:
+			c.JSON(http.StatusConflict, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrTemplateLimit:
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(de.Code, de.Message))
+			return
+		case domain.ErrInvalidTemplate:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(de.Code, err.Error()))
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// ListTemplates maneja GET /templates (predefinidas primero)
+func (th *TaskHandler) ListTemplates(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	templates, err := th.templates.ListTemplates(ctx, userID)
+	if err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": templates})
+}
+
+// CreateTemplate maneja POST /templates
+func (th *TaskHandler) CreateTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	template, err := th.templates.CreateTemplate(ctx, userID, req.ToDomain())
+	if err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplate maneja GET /templates/:id
+func (th *TaskHandler) GetTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	template, err := th.templates.GetTemplate(ctx, c.Param("id"), userID)
+	if err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate maneja PUT /templates/:id (las predefinidas son de solo lectura)
+func (th *TaskHandler) UpdateTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	template, err := th.templates.UpdateTemplate(ctx, c.Param("id"), userID, req.ToDomain())
+	if err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate maneja DELETE /templates/:id
+func (th *TaskHandler) DeleteTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := th.templates.DeleteTemplate(ctx, c.Param("id"), userID); err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateFromTemplate maneja POST /tasks/from-template/:id: crea una tarea por
+// ítem de la plantilla, con vencimientos relativos a dueDate
+func (th *TaskHandler) CreateFromTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.FromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	loc, ok := th.location(ctx, c, userID, req.TimeZone)
+	if !ok {
+		return
+	}
+
+	tasks, err := th.templates.InstantiateTemplate(ctx, c.Param("id"), domain.TemplateInstance{
+		UserID:    userID,
+		SubjectID: req.SubjectID,
+		PeriodID:  req.PeriodID,
+		Title:     req.Title,
+		DueDate:   req.DueDate,
+		Location:  loc,
+	}, c.GetHeader("X-User-Name"), c.GetHeader("X-User-Email"))
+	if err != nil {
+		var de *domain.DomainError
+		if !errors.As(err, &de) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_TASK", err.Error()))
+			return
+		}
+		templateError(c, err)
+		return
+	}
+
+	dtos := make([]TaskDTO, 0, len(tasks))
+	for _, t := range tasks {
+		dtos = append(dtos, TaskFromDomain(t))
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": dtos, "count": len(dtos)})
+}
+
+// SaveTaskAsTemplate maneja POST /tasks/:id/template (body opcional {"name"})
+func (th *TaskHandler) SaveTaskAsTemplate(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req requests.SaveAsTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	template, err := th.templates.SaveTaskAsTemplate(ctx, c.Param("id"), userID, req.Name)
+	if err != nil {
+		templateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTemplateRouter(t *testing.T) (*gin.Engine, *application.TaskService) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			userID = "user-test"
+		}
+		c.Set("userID", userID)
+		c.Next()
+	})
+
+	service := application.NewTaskService(memory.NewRepo(), nil)
+	h := NewTaskHandler(service).WithTemplates(application.NewTemplateService(memory.NewTemplateRepo(), service))
+
+	r.GET("/tasks", h.GetTasks)
+	r.POST("/tasks/from-template/:id", h.CreateFromTemplate)
+	r.POST("/tasks/:id/template", h.SaveTaskAsTemplate)
+	r.GET("/templates", h.ListTemplates)
+	r.POST("/templates", h.CreateTemplate)
+	r.GET("/templates/:id", h.GetTemplate)
+	r.PUT("/templates/:id", h.UpdateTemplate)
+	r.DELETE("/templates/:id", h.DeleteTemplate)
+	return r, service
+}
+
+func TestCreateFromBuiltInTemplate(t *testing.T) {
+	r, _ := setupTemplateRouter(t)
+
+	w := doSaved(r, "POST", "/tasks/from-template/builtin-lab-report", "",
+		`{"dueDate": "2025-04-10T23:59:00-06:00", "subjectId": "quimica", "periodId": "2025-1", "title": "Lab 3", "timeZone": "America/Costa_Rica"}`)
+	var resp struct {
+		Data  []TaskDTO `json:"data"`
+		Count int       `json:"count"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusCreated || resp.Count != 3 {
+		t.Fatalf("expected 201 with 3 tasks, got %d %s", w.Code, w.Body.String())
+	}
+	pre := resp.Data[0]
+	if pre.Title != "Lab 3: Pre-laboratorio" || pre.Type != domain.TypeLab || pre.SubjectID != "quimica" || pre.PeriodID != "2025-1" || pre.Status != domain.StatusTodo {
+		t.Errorf("unexpected first task %+v", pre)
+	}
+	if due, _ := time.Parse(time.RFC3339, pre.DueDate); !due.Equal(time.Date(2025, 4, 4, 5, 59, 0, 0, time.UTC)) {
+		t.Errorf("expected pre-lab a week earlier, got %s", pre.DueDate)
+	}
+
+	var page struct {
+		Data []TaskDTO `json:"data"`
+	}
+	w = doSaved(r, "GET", "/tasks", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &page)
+	if len(page.Data) != 3 {
+		t.Errorf("expected 3 stored tasks, got %s", w.Body.String())
+	}
+
+	for path, code := range map[string]int{
+		"/tasks/from-template/builtin-nope": http.StatusNotFound,
+		"/tasks/from-template/tpl-ajena":    http.StatusNotFound,
+	} {
+		w = doSaved(r, "POST", path, "", `{"dueDate": "2025-04-10T23:59:00Z", "subjectId": "quimica"}`)
+		if w.Code != code {
+			t.Errorf("%s: expected %d, got %d %s", path, code, w.Code, w.Body.String())
+		}
+	}
+	w = doSaved(r, "POST", "/tasks/from-template/builtin-exam", "", `{"dueDate": "2025-04-10T23:59:00Z"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without subjectId, got %d", w.Code)
+	}
+}
+
+func TestUserTemplatesCRUD(t *testing.T) {
+	r, _ := setupTemplateRouter(t)
+
+	body := `{"name": "Tarea semanal", "priority": "high", "tags": ["semanal"], "items": [{"title": "Ejercicios", "dueOffsetDays": -2}, {"title": "Entrega", "dueOffsetDays": 0}]}`
+	w := doSaved(r, "POST", "/templates", "", body)
+	var tpl domain.TaskTemplate
+	_ = json.Unmarshal(w.Body.Bytes(), &tpl)
+	if w.Code != http.StatusCreated || tpl.ID == "" || tpl.Type != domain.TypeAssignment || tpl.BuiltIn {
+		t.Fatalf("unexpected create %d %s", w.Code, w.Body.String())
+	}
+
+	if w = doSaved(r, "POST", "/templates", "", body); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for duplicated name, got %d", w.Code)
+	}
+	w = doSaved(r, "POST", "/templates", "", `{"name": "Mala", "items": [{"title": "x", "dueOffsetDays": 1000}]}`)
+	var errResp ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &errResp)
+	if w.Code != http.StatusBadRequest || errResp.Code != "INVALID_TEMPLATE" {
+		t.Errorf("expected 400 INVALID_TEMPLATE, got %d %s", w.Code, w.Body.String())
+	}
+
+	var list struct {
+		Data []domain.TaskTemplate `json:"data"`
+	}
+	w = doSaved(r, "GET", "/templates", "", "")
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	builtIns := len(domain.BuiltInTemplates())
+	if len(list.Data) != builtIns+1 || !list.Data[0].BuiltIn || list.Data[builtIns].ID != tpl.ID {
+		t.Errorf("expected built-ins first and then the user template, got %s", w.Body.String())
+	}
+
+	// Otros usuarios no ven la plantilla
+	if w = doSaved(r, "GET", "/templates/"+tpl.ID, "otro", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user, got %d", w.Code)
+	}
+
+	w = doSaved(r, "PUT", "/templates/"+tpl.ID, "", `{"name": "Tarea semanal", "type": "reading"}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &tpl)
+	if w.Code != http.StatusOK || tpl.Type != domain.TypeReading || len(tpl.Items) != 0 || tpl.CreatedAt.IsZero() {
+		t.Errorf("unexpected update %d %s", w.Code, w.Body.String())
+	}
+
+	for _, method := range []string{"PUT", "DELETE"} {
+		w = doSaved(r, method, "/templates/builtin-exam", "", `{"name": "Examen"}`)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s built-in: expected 403, got %d", method, w.Code)
+		}
+	}
+
+	if w = doSaved(r, "DELETE", "/templates/"+tpl.ID, "", ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+	if w = doSaved(r, "GET", "/templates/"+tpl.ID, "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestSaveTaskAsTemplate(t *testing.T) {
+	r, service := setupTemplateRouter(t)
+
+	task := &domain.Task{UserID: "user-test", Title: "Quiz semanal", Description: "Capítulo de la semana", SubjectID: "fisica",
+		DueDate: time.Now().Add(48 * time.Hour), Status: domain.StatusInProgress, Priority: domain.PriorityHigh, Type: domain.TypeQuiz,
+		EstimatedTimeHours: 2, Tags: []string{"quiz"}}
+	if err := service.CreateTask(context.Background(), task, "user-test", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	w := doSaved(r, "POST", "/tasks/"+task.ID+"/template", "", "")
+	var tpl domain.TaskTemplate
+	_ = json.Unmarshal(w.Body.Bytes(), &tpl)
+	if w.Code != http.StatusCreated || tpl.Name != "Quiz semanal" || tpl.Type != domain.TypeQuiz || tpl.Priority != domain.PriorityHigh ||
+		tpl.EstimatedTimeHours != 2 || len(tpl.Tags) != 1 || tpl.Description != "Capítulo de la semana" {
+		t.Fatalf("unexpected template %d %s", w.Code, w.Body.String())
+	}
+
+	w = doSaved(r, "POST", "/tasks/from-template/"+tpl.ID, "", `{"dueDate": "2025-05-02T12:00:00Z", "subjectId": "fisica"}`)
+	var resp struct {
+		Data []TaskDTO `json:"data"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusCreated || len(resp.Data) != 1 || resp.Data[0].Title != "Quiz semanal" || resp.Data[0].Status != domain.StatusTodo {
+		t.Errorf("unexpected instance %d %s", w.Code, w.Body.String())
+	}
+
+	if w = doSaved(r, "POST", "/tasks/nope/template", "", `{"name": "x"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown task, got %d", w.Code)
+	}
+}
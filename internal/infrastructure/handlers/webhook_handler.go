@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Límites del log de entregas
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 200
+)
+
+// WebhookHandler maneja las suscripciones a webhooks.
+// Las mismas rutas sirven a usuarios (/webhooks) y a administradores
+// (/admin/webhooks, webhooks de sistema que reciben eventos de todos los usuarios).
+type WebhookHandler struct {
+	webhookService *application.WebhookService
+}
+
+// NewWebhookHandler crea un nuevo WebhookHandler
+func NewWebhookHandler(ws *application.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: ws,
+	}
+}
+
+// CreateWebhookRequest estructura para POST /webhooks
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Events      []string `json:"events"` // vacío = todos los eventos
+	Description string   `json:"description"`
+}
+
+// UpdateWebhookRequest estructura para PATCH /webhooks/:id
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url" binding:"omitempty,url"`
+	Events      *[]string `json:"events"`
+	Description *string   `json:"description"`
+	Active      *bool     `json:"active"`
+}
+
+// WebhookDTO representación de un webhook (el secreto solo se incluye al crearlo)
+type WebhookDTO struct {
+	ID             string   `json:"id"`
+	Scope          string   `json:"scope"` // user | system
+	URL            string   `json:"url"`
+	Events         []string `json:"events"`
+	Description    string   `json:"description"`
+	Active         bool     `json:"active"`
+	FailureCount   int      `json:"failureCount"`
+	DisabledAt     *string  `json:"disabledAt,omitempty"`
+	DisabledReason string   `json:"disabledReason,omitempty"`
+	Secret         string   `json:"secret,omitempty"`
+	CreatedAt      string   `json:"createdAt"`
+	UpdatedAt      string   `json:"updatedAt"`
+}
+
+func webhookFromDomain(h *domain.Webhook) WebhookDTO {
+	dto := WebhookDTO{
+		ID:             h.ID,
+		Scope:          "user",
+		URL:            h.URL,
+		Events:         h.Events,
+		Description:    h.Description,
+		Active:         h.Active,
+		FailureCount:   h.FailureCount,
+		DisabledReason: h.DisabledReason,
+		CreatedAt:      h.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:      h.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if h.IsSystem() {
+		dto.Scope = "system"
+	}
+	if dto.Events == nil {
+		dto.Events = []string{}
+	}
+	if h.DisabledAt != nil {
+		s := h.DisabledAt.Format("2006-01-02T15:04:05Z07:00")
+		dto.DisabledAt = &s
+	}
+	return dto
+}
+
+// webhookOwner dueño de los webhooks del request: "" para administradores (sistema)
+func webhookOwner(c *gin.Context) (string, bool) {
+	if c.GetBool("isAdmin") {
+		return "", true
+	}
+	return getUserID(c)
+}
+
+// webhookError responde según el tipo de error del servicio
+func webhookError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		c.JSON(http.StatusNotFound, NewErrorResponse("NOT_FOUND", "Webhook no encontrado"))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, NewErrorResponse("INTERNAL_ERROR", err.Error()))
+}
+
+// CreateWebhook maneja POST /webhooks
+func (wh *WebhookHandler) CreateWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	hook := &domain.Webhook{
+		UserID:      owner,
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+	}
+	secret, err := wh.webhookService.CreateWebhook(ctx, hook)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_WEBHOOK", err.Error()))
+		return
+	}
+
+	dto := webhookFromDomain(hook)
+	dto.Secret = secret
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListWebhooks maneja GET /webhooks
+func (wh *WebhookHandler) ListWebhooks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	hooks, err := wh.webhookService.ListWebhooks(ctx, owner)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	dtos := make([]WebhookDTO, len(hooks))
+	for i := range hooks {
+		dtos[i] = webhookFromDomain(&hooks[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dtos})
+}
+
+// GetWebhook maneja GET /webhooks/:id
+func (wh *WebhookHandler) GetWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	hook, err := wh.webhookService.GetWebhook(ctx, c.Param("id"), owner)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookFromDomain(hook))
+}
+
+// UpdateWebhook maneja PATCH /webhooks/:id
+// Enviar {"active": true} reactiva un webhook desactivado por fallos.
+func (wh *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_REQUEST", err.Error()))
+		return
+	}
+
+	hook, err := wh.webhookService.UpdateWebhook(ctx, c.Param("id"), owner, application.WebhookPatch{
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			webhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_WEBHOOK", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookFromDomain(hook))
+}
+
+// DeleteWebhook maneja DELETE /webhooks/:id
+func (wh *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	if err := wh.webhookService.DeleteWebhook(ctx, c.Param("id"), owner); err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries maneja GET /webhooks/:id/deliveries?limit=50
+func (wh *WebhookHandler) ListDeliveries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	limit := defaultDeliveriesLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxDeliveriesLimit {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("INVALID_LIMIT", "limit debe estar entre 1 y 200"))
+			return
+		}
+		limit = n
+	}
+
+	deliveries, err := wh.webhookService.ListDeliveries(ctx, c.Param("id"), owner, limit)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": deliveries})
+}
+
+// TestWebhook maneja POST /webhooks/:id/test
+// Envía un evento webhook.test de forma síncrona y retorna el resultado de la entrega.
+func (wh *WebhookHandler) TestWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	owner, ok := webhookOwner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("UNAUTHORIZED", "userID not found in context (middleware failed)"))
+		return
+	}
+
+	delivery, err := wh.webhookService.SendTest(ctx, c.Param("id"), owner)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
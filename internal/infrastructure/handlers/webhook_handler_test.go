@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"uniflow-api/internal/application"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/middleware"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupWebhookRouter(cfg application.WebhookConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	ws := application.NewWebhookService(memory.NewWebhookRepo(), cfg)
+	h := NewWebhookHandler(ws)
+
+	admin := r.Group("/admin/webhooks", middleware.AdminKeyMiddleware("admin-secret"))
+	admin.POST("", h.CreateWebhook)
+	admin.GET("", h.ListWebhooks)
+
+	user := r.Group("/webhooks", func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-User-ID"))
+		c.Next()
+	})
+	user.POST("", h.CreateWebhook)
+	user.GET("", h.ListWebhooks)
+	user.GET("/:id", h.GetWebhook)
+	user.GET("/:id/deliveries", h.ListDeliveries)
+	user.POST("/:id/test", h.TestWebhook)
+	return r
+}
+
+func doWebhook(r http.Handler, method, path, userID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	if userID == "admin" {
+		req.Header.Set(middleware.AdminKeyHeader, "admin-secret")
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookLifecycle(t *testing.T) {
+	// El destino es un servidor local: se permiten redes privadas
+	r := setupWebhookRouter(application.WebhookConfig{AllowPrivateNetworks: true})
+
+	var received string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = req.Header.Get(application.WebhookEventHeader)
+		_, _ = w.Write([]byte("respuesta privada"))
+	}))
+	defer target.Close()
+
+	w := doWebhook(r, "POST", "/webhooks", "user-a", `{"url": "`+target.URL+`", "events": ["task.created"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created WebhookDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	if !strings.HasPrefix(created.Secret, "whsec_") || created.Scope != "user" || !created.Active {
+		t.Fatalf("unexpected webhook %+v", created)
+	}
+
+	// El secreto no vuelve a mostrarse
+	w = doWebhook(r, "GET", "/webhooks/"+created.ID, "user-a", "")
+	if strings.Contains(w.Body.String(), created.Secret) {
+		t.Error("secret should not be returned after creation")
+	}
+
+	// Otro usuario no lo ve
+	if w = doWebhook(r, "GET", "/webhooks/"+created.ID, "user-b", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for other user, got %d", w.Code)
+	}
+
+	w = doWebhook(r, "POST", "/webhooks/"+created.ID+"/test", "user-a", "")
+	var delivery domain.WebhookDelivery
+	_ = json.Unmarshal(w.Body.Bytes(), &delivery)
+	if w.Code != http.StatusOK || !delivery.Success || received != application.WebhookTestEvent {
+		t.Fatalf("unexpected test delivery %d %+v (event %q)", w.Code, delivery, received)
+	}
+	if strings.Contains(w.Body.String(), "respuesta privada") {
+		t.Error("user webhooks should not expose the response body")
+	}
+
+	w = doWebhook(r, "GET", "/webhooks/"+created.ID+"/deliveries", "user-a", "")
+	if !strings.Contains(w.Body.String(), application.WebhookTestEvent) || strings.Contains(w.Body.String(), "respuesta privada") {
+		t.Errorf("delivery log should include the test event without the response body: %s", w.Body.String())
+	}
+}
+
+func TestWebhookValidation(t *testing.T) {
+	r := setupWebhookRouter(application.WebhookConfig{})
+
+	if w := doWebhook(r, "POST", "/webhooks", "user-a", `{"url": "ftp://example.com"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for non-http url, got %d", w.Code)
+	}
+	if w := doWebhook(r, "POST", "/webhooks", "user-a", `{"url": "https://example.com", "events": ["task.exploded"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown event, got %d", w.Code)
+	}
+	for _, url := range []string{"http://127.0.0.1:9000/hook", "http://169.254.169.254/latest/meta-data"} {
+		if w := doWebhook(r, "POST", "/webhooks", "user-a", `{"url": "`+url+`"}`); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 for a private target, got %d", url, w.Code)
+		}
+	}
+}
+
+func TestSystemWebhooksRequireAdminKey(t *testing.T) {
+	r := setupWebhookRouter(application.WebhookConfig{})
+
+	if w := doWebhook(r, "POST", "/admin/webhooks", "user-a", `{"url": "https://example.com"}`); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without admin key, got %d", w.Code)
+	}
+
+	w := doWebhook(r, "POST", "/admin/webhooks", "admin", `{"url": "https://example.com"}`)
+	var created WebhookDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	if w.Code != http.StatusCreated || created.Scope != "system" {
+		t.Fatalf("expected system webhook, got %d %+v", w.Code, created)
+	}
+
+	// Los webhooks de sistema no aparecen en la lista del usuario
+	w = doWebhook(r, "GET", "/webhooks", "user-a", "")
+	if strings.Contains(w.Body.String(), created.ID) {
+		t.Error("system webhook leaked into user listing")
+	}
+}
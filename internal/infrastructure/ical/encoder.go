@@ -0,0 +1,211 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"uniflow-api/internal/domain"
+)
+
+// Componentes RFC 5545 soportados para representar tareas
+const (
+	ComponentEvent = "VEVENT"
+	ComponentTodo  = "VTODO"
+)
+
+// ProdID identifica al generador del calendario
+const ProdID = "-//UniFlow//Tasks API//ES"
+
+// dateTimeUTC formato DATE-TIME en UTC (RFC 5545 §3.3.5)
+const dateTimeUTC = "20060102T150405Z"
+
+// maxLineOctets longitud máxima de línea antes de plegar (RFC 5545 §3.1)
+const maxLineOctets = 75
+
+// EncodeOptions opciones de serialización del feed
+type EncodeOptions struct {
+	Component    string    // VEVENT (default) o VTODO
+	CalendarName string    // X-WR-CALNAME
+	Now          time.Time // DTSTAMP
+}
+
+// Encode escribe las tareas como un VCALENDAR RFC 5545
+func Encode(w io.Writer, tasks []domain.Task, opts EncodeOptions) error {
+	if opts.Component == "" {
+		opts.Component = ComponentEvent
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + ProdID)
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if opts.CalendarName != "" {
+		lw.line("X-WR-CALNAME:" + EscapeText(opts.CalendarName))
+	}
+
+	for i := range tasks {
+		writeTask(lw, &tasks[i], opts)
+	}
+
+	lw.line("END:VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+func writeTask(lw *lineWriter, t *domain.Task, opts EncodeOptions) {
+	lw.line("BEGIN:" + opts.Component)
+	lw.line("UID:" + TaskUID(t.ID))
+	lw.line("DTSTAMP:" + formatUTC(opts.Now))
+	if !t.CreatedAt.IsZero() {
+		lw.line("CREATED:" + formatUTC(t.CreatedAt))
+	}
+	if !t.UpdatedAt.IsZero() {
+		lw.line("LAST-MODIFIED:" + formatUTC(t.UpdatedAt))
+	}
+
+	lw.line("SUMMARY:" + EscapeText(t.Title))
+	if t.Description != "" {
+		lw.line("DESCRIPTION:" + EscapeText(t.Description))
+	}
+
+	categories := make([]string, 0, len(t.Tags)+1)
+	if t.Type != "" {
+		categories = append(categories, EscapeText(t.Type))
+	}
+	for _, tag := range t.Tags {
+		categories = append(categories, EscapeText(tag))
+	}
+	if len(categories) > 0 {
+		lw.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+
+	if p := icalPriority(t.Priority); p > 0 {
+		lw.line(fmt.Sprintf("PRIORITY:%d", p))
+	}
+
+	if opts.Component == ComponentTodo {
+		lw.line("DUE:" + formatUTC(t.DueDate))
+		lw.line("STATUS:" + todoStatus(t.Status))
+		if t.CompletedAt != nil {
+			lw.line("COMPLETED:" + formatUTC(*t.CompletedAt))
+			lw.line("PERCENT-COMPLETE:100")
+		}
+	} else {
+		lw.line("DTSTART:" + formatUTC(t.DueDate))
+		lw.line("STATUS:" + eventStatus(t.Status))
+		lw.line("TRANSP:TRANSPARENT")
+	}
+
+	lw.line("X-UNIFLOW-STATUS:" + EscapeText(t.Status))
+	if t.SubjectID != "" {
+		lw.line("X-UNIFLOW-SUBJECT:" + EscapeText(t.SubjectID))
+	}
+	if t.PeriodID != "" {
+		lw.line("X-UNIFLOW-PERIOD:" + EscapeText(t.PeriodID))
+	}
+
+	lw.line("END:" + opts.Component)
+}
+
+// TaskUID UID global estable de una tarea
+func TaskUID(taskID string) string {
+	return taskID + "@uniflow"
+}
+
+// todoStatus mapea el estado de la tarea al STATUS de un VTODO
+func todoStatus(status string) string {
+	switch status {
+	case domain.StatusDone:
+		return "COMPLETED"
+	case domain.StatusCancelled:
+		return "CANCELLED"
+	case domain.StatusInProgress, domain.StatusInReview:
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// eventStatus mapea el estado de la tarea al STATUS de un VEVENT.
+// VEVENT no tiene estado COMPLETED: las tareas hechas quedan CONFIRMED
+// y el estado real viaja en X-UNIFLOW-STATUS.
+func eventStatus(status string) string {
+	if status == domain.StatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+// icalPriority mapea prioridad a la escala 1 (alta) - 9 (baja)
+func icalPriority(priority string) int {
+	switch priority {
+	case domain.PriorityUrgent:
+		return 1
+	case domain.PriorityHigh:
+		return 3
+	case domain.PriorityMedium:
+		return 5
+	case domain.PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(dateTimeUTC)
+}
+
+// EscapeText escapa un valor TEXT (RFC 5545 §3.3.11)
+func EscapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	)
+	return r.Replace(s)
+}
+
+// lineWriter escribe líneas con CRLF y plegado a 75 octetos
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	// Cada línea física (incluido el espacio de continuación) ocupa <= 75 octetos
+	for len(s) > maxLineOctets {
+		cut := maxLineOctets
+		// No partir una secuencia UTF-8
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		lw.write(s[:cut])
+		s = " " + s[cut:]
+	}
+	lw.write(s)
+}
+
+func (lw *lineWriter) write(s string) {
+	if lw.err != nil {
+		return
+	}
+	_, lw.err = lw.w.WriteString(s + "\r\n")
+}
@@ -0,0 +1,73 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestEncodeTodoStatuses(t *testing.T) {
+	due := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+	completed := due.Add(-time.Hour)
+	tasks := []domain.Task{
+		{ID: "t1", Title: "Parcial, Cálculo; I", Status: domain.StatusDone, Priority: domain.PriorityHigh,
+			Type: domain.TypeExam, DueDate: due, CompletedAt: &completed},
+		{ID: "t2", Title: "Lectura", Status: domain.StatusCancelled, Type: domain.TypeReading, DueDate: due},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, tasks, EncodeOptions{Component: ComponentTodo}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"BEGIN:VTODO\r\n",
+		"UID:t1@uniflow\r\n",
+		`SUMMARY:Parcial\, Cálculo\; I` + "\r\n",
+		"DUE:20251010T180000Z\r\n",
+		"STATUS:COMPLETED\r\n",
+		"COMPLETED:20251010T170000Z\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestEncodeEventCancelled(t *testing.T) {
+	tasks := []domain.Task{{ID: "t1", Title: "Quiz", Status: domain.StatusCancelled, DueDate: time.Now()}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, tasks, EncodeOptions{}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "BEGIN:VEVENT\r\n") || !strings.Contains(buf.String(), "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected cancelled VEVENT, got:\n%s", buf.String())
+	}
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	tasks := []domain.Task{{ID: "t1", Title: strings.Repeat("á", 100), Status: domain.StatusTodo, DueDate: time.Now()}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, tasks, EncodeOptions{}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("á", 100)) {
+		t.Error("unfolded summary does not match original title")
+	}
+}
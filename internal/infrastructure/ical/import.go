@@ -0,0 +1,114 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// ExternalIDPrefix prefijo de los externalId de tareas importadas desde ICS
+const ExternalIDPrefix = "ics:"
+
+// ImportMapping indica cómo asignar materia y período a los eventos importados
+type ImportMapping struct {
+	SubjectID  string            // materia por defecto
+	PeriodID   string            // período para todas las tareas
+	SubjectMap map[string]string // palabra clave (CATEGORIES o SUMMARY) -> subjectId
+}
+
+// ToDrafts convierte eventos ICS en borradores de tareas del usuario.
+// El tipo se infiere por palabras clave y el externalId se deriva del UID,
+// de modo que reimportar el mismo archivo actualiza en lugar de duplicar.
+func ToDrafts(events []Event, userID string, m ImportMapping, now time.Time) []domain.ImportDraft {
+	keys := make([]string, 0, len(m.SubjectMap))
+	for k := range m.SubjectMap {
+		keys = append(keys, k)
+	}
+	// Claves más largas primero ("Cálculo II" antes que "Cálculo")
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	drafts := make([]domain.ImportDraft, 0, len(events))
+	for i := range events {
+		e := &events[i]
+		d := domain.ImportDraft{Line: e.Line}
+
+		subjectID := resolveSubject(e, keys, m)
+		switch {
+		case strings.TrimSpace(e.Summary) == "":
+			d.Err = fmt.Errorf("evento sin SUMMARY")
+		case subjectID == "":
+			d.Err = fmt.Errorf("no se pudo asignar materia a %q: agregue subjectId o una entrada en subjectMap", e.Summary)
+		}
+
+		externalID := ExternalIDPrefix + e.UID
+		if e.RecurrenceID != "" {
+			externalID += "#" + e.RecurrenceID
+		}
+
+		d.Task = domain.Task{
+			UserID:      userID,
+			ExternalID:  externalID,
+			Title:       strings.TrimSpace(e.Summary),
+			Description: e.Description,
+			SubjectID:   subjectID,
+			PeriodID:    m.PeriodID,
+			DueDate:     e.DueDate(),
+			Status:      eventTaskStatus(e.Status),
+			Priority:    domain.PriorityMedium,
+			Type:        domain.InferTaskType(e.Summary + " " + strings.Join(e.Categories, " ")),
+			Tags:        e.Categories,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if d.Task.Tags == nil {
+			d.Task.Tags = []string{}
+		}
+		if d.Task.Status == domain.StatusDone {
+			completed := now
+			d.Task.CompletedAt = &completed
+		}
+
+		drafts = append(drafts, d)
+	}
+	return drafts
+}
+
+// resolveSubject busca la primera clave de subjectMap presente en las
+// categorías o el título del evento; si no hay, usa la materia por defecto
+func resolveSubject(e *Event, keys []string, m ImportMapping) string {
+	summary := strings.ToLower(e.Summary)
+	for _, k := range keys {
+		lk := strings.ToLower(k)
+		for _, c := range e.Categories {
+			if strings.ToLower(c) == lk {
+				return m.SubjectMap[k]
+			}
+		}
+		if strings.Contains(summary, lk) {
+			return m.SubjectMap[k]
+		}
+	}
+	return m.SubjectID
+}
+
+// eventTaskStatus mapea STATUS de ICS al estado de la tarea
+func eventTaskStatus(status string) string {
+	switch status {
+	case "CANCELLED":
+		return domain.StatusCancelled
+	case "COMPLETED":
+		return domain.StatusDone
+	case "IN-PROCESS":
+		return domain.StatusInProgress
+	default:
+		return domain.StatusTodo
+	}
+}
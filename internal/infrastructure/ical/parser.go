@@ -0,0 +1,290 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event es un VEVENT o VTODO leído de un archivo .ics
+type Event struct {
+	Component    string // VEVENT o VTODO
+	Line         int    // línea donde inicia el componente (BEGIN:...)
+	UID          string
+	RecurrenceID string
+	Summary      string
+	Description  string
+	Categories   []string
+	Status       string
+	Start        time.Time // DTSTART
+	Due          time.Time // DUE (VTODO)
+	AllDay       bool      // DTSTART/DUE con VALUE=DATE
+}
+
+// DueDate fecha de entrega inferida: DUE si existe, si no DTSTART
+func (e *Event) DueDate() time.Time {
+	if !e.Due.IsZero() {
+		return e.Due
+	}
+	return e.Start
+}
+
+// ParseError error de parseo asociado a una línea del archivo
+type ParseError struct {
+	Line    int    `json:"line"`
+	UID     string `json:"uid,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("línea %d: %s", e.Line, e.Message)
+}
+
+// contentLine línea de contenido ya desplegada: NAME;PARAMS:VALUE
+type contentLine struct {
+	num    int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse lee un VCALENDAR y retorna sus VEVENT/VTODO.
+// Las fechas flotantes (sin Z ni TZID) y los días completos se interpretan en loc.
+// Los componentes inválidos no detienen el parseo: se reportan con su línea.
+func Parse(r io.Reader, loc *time.Location) ([]Event, []ParseError, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		events  []Event
+		errs    []ParseError
+		current *Event
+		invalid bool
+		depth   int // componentes anidados dentro del evento (VALARM)
+	)
+
+	for _, raw := range lines {
+		cl, ok := parseContentLine(raw)
+		if !ok {
+			if current != nil {
+				errs = append(errs, ParseError{Line: raw.num, UID: current.UID, Message: "línea mal formada"})
+				invalid = true
+			}
+			continue
+		}
+
+		switch {
+		case cl.name == "BEGIN" && current == nil && (cl.value == ComponentEvent || cl.value == ComponentTodo):
+			current = &Event{Component: cl.value, Line: cl.num}
+			invalid = false
+			depth = 0
+			continue
+		case cl.name == "BEGIN" && current != nil:
+			depth++
+			continue
+		case cl.name == "END" && current != nil && depth > 0:
+			depth--
+			continue
+		case cl.name == "END" && current != nil && cl.value == current.Component:
+			if current.UID == "" {
+				errs = append(errs, ParseError{Line: current.Line, Message: "componente sin UID"})
+				invalid = true
+			}
+			if !invalid && current.DueDate().IsZero() {
+				errs = append(errs, ParseError{Line: current.Line, UID: current.UID, Message: "componente sin DTSTART ni DUE"})
+				invalid = true
+			}
+			if !invalid {
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		}
+
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		switch cl.name {
+		case "UID":
+			current.UID = cl.value
+		case "RECURRENCE-ID":
+			current.RecurrenceID = cl.value
+		case "SUMMARY":
+			current.Summary = UnescapeText(cl.value)
+		case "DESCRIPTION":
+			current.Description = UnescapeText(cl.value)
+		case "STATUS":
+			current.Status = strings.ToUpper(cl.value)
+		case "CATEGORIES":
+			for _, c := range splitEscaped(cl.value) {
+				if c = strings.TrimSpace(UnescapeText(c)); c != "" {
+					current.Categories = append(current.Categories, c)
+				}
+			}
+		case "DTSTART", "DUE":
+			t, allDay, err := parseDateTime(cl, loc)
+			if err != nil {
+				errs = append(errs, ParseError{Line: cl.num, UID: current.UID, Message: err.Error()})
+				invalid = true
+				continue
+			}
+			if cl.name == "DTSTART" {
+				current.Start = t
+			} else {
+				current.Due = t
+			}
+			current.AllDay = current.AllDay || allDay
+		}
+	}
+
+	if current != nil {
+		errs = append(errs, ParseError{Line: current.Line, UID: current.UID, Message: "falta END:" + current.Component})
+	}
+
+	return events, errs, nil
+}
+
+// unfold une las líneas plegadas (RFC 5545 §3.1) conservando el número de línea inicial
+func unfold(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var out []contentLine
+	num := 0
+	for scanner.Scan() {
+		num++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(out) > 0 {
+			out[len(out)-1].value += text[1:]
+			continue
+		}
+		out = append(out, contentLine{num: num, value: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer calendario: %w", err)
+	}
+	return out, nil
+}
+
+// parseContentLine separa nombre, parámetros y valor de una línea desplegada
+func parseContentLine(raw contentLine) (contentLine, bool) {
+	line := raw.value
+	colon := indexOutsideQuotes(line, ':')
+	if colon <= 0 {
+		return raw, false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	cl := contentLine{
+		num:    raw.num,
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  value,
+	}
+	for _, p := range parts[1:] {
+		if eq := strings.IndexByte(p, '='); eq > 0 {
+			cl.params[strings.ToUpper(p[:eq])] = strings.Trim(p[eq+1:], `"`)
+		}
+	}
+	return cl, true
+}
+
+func indexOutsideQuotes(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseDateTime interpreta DATE y DATE-TIME (UTC, con TZID o flotante)
+func parseDateTime(cl contentLine, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(cl.value)
+
+	if cl.params["VALUE"] == "DATE" || len(value) == 8 {
+		d, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("fecha inválida en %s: %q", cl.name, value)
+		}
+		// Un día completo vence al final de ese día
+		return d.Add(24*time.Hour - time.Minute), true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeUTC, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("fecha-hora inválida en %s: %q", cl.name, value)
+		}
+		return t, false, nil
+	}
+
+	useLoc := loc
+	if tzid := cl.params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("TZID desconocido en %s: %q", cl.name, tzid)
+		}
+		useLoc = l
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, useLoc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("fecha-hora inválida en %s: %q", cl.name, value)
+	}
+	return t, false, nil
+}
+
+// UnescapeText revierte EscapeText
+func UnescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitEscaped divide por comas no escapadas
+func splitEscaped(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,100 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:parcial-1@uni\r\n" +
+	"SUMMARY:Primer parcial\\, Cálculo I\r\n" +
+	"DESCRIPTION:Temas 1 a 4\\ncapítulos 2-3\r\n" +
+	"CATEGORIES:MA-101,Evaluación\r\n" +
+	"DTSTART;TZID=America/Costa_Rica:20251010T080000\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT15M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:lab-2@uni\r\n" +
+	"SUMMARY:Lab 2 - Circuitos\r\n" +
+	"DTSTART;VALUE=DATE:20251015\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:roto@uni\r\n" +
+	"SUMMARY:Sin fecha válida\r\n" +
+	"DTSTART:2025-10-20\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, errs, err := Parse(strings.NewReader(sampleCalendar), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 valid events, got %d", len(events))
+	}
+	if len(errs) != 1 || errs[0].Line != 21 || errs[0].UID != "roto@uni" {
+		t.Fatalf("expected one error at line 21 for roto@uni, got %+v", errs)
+	}
+
+	parcial := events[0]
+	if parcial.Summary != "Primer parcial, Cálculo I" {
+		t.Errorf("unexpected summary %q", parcial.Summary)
+	}
+	if parcial.Description != "Temas 1 a 4\ncapítulos 2-3" {
+		t.Errorf("unexpected description %q", parcial.Description)
+	}
+	if want := time.Date(2025, 10, 10, 14, 0, 0, 0, time.UTC); !parcial.Start.Equal(want) {
+		t.Errorf("expected start %v, got %v", want, parcial.Start.UTC())
+	}
+	if !events[1].AllDay {
+		t.Error("expected lab to be an all-day event")
+	}
+}
+
+func TestParseUnfoldsRoundTrip(t *testing.T) {
+	var b strings.Builder
+	title := strings.Repeat("Ensayo largo ", 12)
+	task := domain.Task{ID: "t1", Title: title, Status: domain.StatusTodo, DueDate: time.Now().UTC().Truncate(time.Second)}
+	if err := Encode(&b, []domain.Task{task}, EncodeOptions{}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	events, errs, err := Parse(strings.NewReader(b.String()), time.UTC)
+	if err != nil || len(errs) > 0 || len(events) != 1 {
+		t.Fatalf("unexpected parse result: %v %v %d", err, errs, len(events))
+	}
+	if events[0].Summary != title {
+		t.Errorf("summary mismatch after round trip: %q", events[0].Summary)
+	}
+}
+
+func TestToDrafts(t *testing.T) {
+	events, _, _ := Parse(strings.NewReader(sampleCalendar), time.UTC)
+	mapping := ImportMapping{
+		PeriodID:   "2025-2",
+		SubjectMap: map[string]string{"MA-101": "calc-1"},
+	}
+
+	drafts := ToDrafts(events, "user-1", mapping, time.Now())
+
+	if drafts[0].Err != nil {
+		t.Fatalf("unexpected error: %v", drafts[0].Err)
+	}
+	if got := drafts[0].Task; got.Type != domain.TypeExam || got.SubjectID != "calc-1" || got.ExternalID != "ics:parcial-1@uni" {
+		t.Errorf("unexpected parcial draft: type=%s subject=%s ext=%s", got.Type, got.SubjectID, got.ExternalID)
+	}
+	if drafts[1].Task.Type != domain.TypeLab {
+		t.Errorf("expected lab type, got %s", drafts[1].Task.Type)
+	}
+	if drafts[1].Err == nil {
+		t.Error("expected missing subject error for lab without mapping")
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminKeyHeader header con la API key de administración
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminKeyMiddleware protege rutas de administración (servicio a servicio)
+// comparando X-Admin-Key con la clave configurada (ADMIN_API_KEY).
+// Si la clave está vacía, todas las requests se rechazan.
+func AdminKeyMiddleware(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(AdminKeyHeader)
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": "missing or invalid admin key",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("isAdmin", true)
+		c.Next()
+	}
+}
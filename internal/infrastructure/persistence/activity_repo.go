@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoActivityRepository implementa ActivityRepository usando MongoDB
+type MongoActivityRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoActivityRepository crea una nueva instancia de MongoActivityRepository
+func NewMongoActivityRepository(collection *mongo.Collection) *MongoActivityRepository {
+	return &MongoActivityRepository{
+		collection: collection,
+	}
+}
+
+// Create inserta una nueva entrada del hilo
+func (r *MongoActivityRepository) Create(ctx context.Context, a *domain.Activity) error {
+	if a.ID == "" {
+		a.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, a); err != nil {
+		return fmt.Errorf("error al crear actividad: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene una entrada del hilo de la tarea
+func (r *MongoActivityRepository) GetByID(ctx context.Context, ownerID, taskID, activityID string) (*domain.Activity, error) {
+	var a domain.Activity
+	err := r.collection.FindOne(ctx, bson.M{"_id": activityID, "ownerId": ownerID, "taskId": taskID}).Decode(&a)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("error al obtener actividad: %w", err)
+	}
+
+	return &a, nil
+}
+
+// Update reemplaza el texto, las menciones y la marca de editado
+func (r *MongoActivityRepository) Update(ctx context.Context, a *domain.Activity) error {
+	filter := bson.M{"_id": a.ID, "ownerId": a.OwnerID, "taskId": a.TaskID}
+	update := bson.M{"$set": bson.M{
+		"body":      a.Body,
+		"mentions":  a.Mentions,
+		"edited":    a.Edited,
+		"updatedAt": a.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error al actualizar comentario: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina una entrada del hilo
+func (r *MongoActivityRepository) Delete(ctx context.Context, ownerID, taskID, activityID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": activityID, "ownerId": ownerID, "taskId": taskID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar actividad: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// List página del hilo, más recientes primero
+func (r *MongoActivityRepository) List(ctx context.Context, q domain.ActivityQuery) ([]domain.Activity, int64, error) {
+	filter := bson.M{"ownerId": q.OwnerID, "taskId": q.TaskID}
+	if q.Kind != "" {
+		filter["kind"] = q.Kind
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al contar actividad: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((q.Page - 1) * q.Limit)).
+		SetLimit(int64(q.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al listar actividad: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []domain.Activity{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, 0, fmt.Errorf("error al decodificar actividad: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// DeleteByTask elimina el hilo completo de una tarea
+func (r *MongoActivityRepository) DeleteByTask(ctx context.Context, ownerID, taskID string) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"ownerId": ownerID, "taskId": taskID}); err != nil {
+		return fmt.Errorf("error al eliminar actividad de la tarea: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// attachmentBlobsCollection referencias a cada contenido, por checksum
+const attachmentBlobsCollection = "attachment_blobs"
+
+// MongoAttachmentRepository implementa AttachmentRepository usando MongoDB.
+// Las referencias a cada contenido viven en attachment_blobs de la misma base.
+type MongoAttachmentRepository struct {
+	collection *mongo.Collection
+	blobs      *mongo.Collection
+}
+
+// NewMongoAttachmentRepository crea una nueva instancia de MongoAttachmentRepository
+func NewMongoAttachmentRepository(collection *mongo.Collection) *MongoAttachmentRepository {
+	return &MongoAttachmentRepository{
+		collection: collection,
+		blobs:      collection.Database().Collection(attachmentBlobsCollection),
+	}
+}
+
+// blobRefDocument referencias a un contenido y la clave con la que se guardó
+type blobRefDocument struct {
+	Checksum string `bson:"_id"`
+	Key      string `bson:"key"`
+	Refs     int64  `bson:"refs"`
+}
+
+// Create inserta un nuevo adjunto
+func (r *MongoAttachmentRepository) Create(ctx context.Context, a *domain.Attachment) error {
+	if a.ID == "" {
+		a.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, a); err != nil {
+		return fmt.Errorf("error al crear adjunto: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene un adjunto
+func (r *MongoAttachmentRepository) GetByID(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	return r.findOne(ctx, bson.M{"_id": attachmentID})
+}
+
+// FindByChecksum adjunto de la tarea con el mismo contenido
+func (r *MongoAttachmentRepository) FindByChecksum(ctx context.Context, ownerID, taskID, checksum string) (*domain.Attachment, error) {
+	return r.findOne(ctx, bson.M{"ownerId": ownerID, "taskId": taskID, "checksum": checksum})
+}
+
+func (r *MongoAttachmentRepository) findOne(ctx context.Context, filter bson.M) (*domain.Attachment, error) {
+	var a domain.Attachment
+	err := r.collection.FindOne(ctx, filter).Decode(&a)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("error al obtener adjunto: %w", err)
+	}
+
+	return &a, nil
+}
+
+// ListByTask adjuntos de una tarea del dueño, por fecha de subida
+func (r *MongoAttachmentRepository) ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Attachment, error) {
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"ownerId": ownerID, "taskId": taskID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar adjuntos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	attachments := []domain.Attachment{}
+	if err = cursor.All(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("error al decodificar adjuntos: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// AcquireBlob suma una referencia con un upsert atómico; la clave solo se
+// fija al crear el documento
+func (r *MongoAttachmentRepository) AcquireBlob(ctx context.Context, checksum, newKey string) (string, bool, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	update := bson.M{"$inc": bson.M{"refs": 1}, "$setOnInsert": bson.M{"key": newKey}}
+
+	var ref blobRefDocument
+	err := r.blobs.FindOneAndUpdate(ctx, bson.M{"_id": checksum}, update, opts).Decode(&ref)
+	if mongo.IsDuplicateKeyError(err) {
+		// Dos upserts simultáneos: el otro creó el documento, ahora se incrementa
+		err = r.blobs.FindOneAndUpdate(ctx, bson.M{"_id": checksum}, update, opts).Decode(&ref)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error al referenciar contenido: %w", err)
+	}
+	return ref.Key, ref.Key == newKey, nil
+}
+
+// ReleaseBlob resta una referencia; el documento se elimina solo si sigue
+// en cero, así una subida que llegó entre medio conserva el contenido
+func (r *MongoAttachmentRepository) ReleaseBlob(ctx context.Context, checksum, key string) (bool, error) {
+	var ref blobRefDocument
+	err := r.blobs.FindOneAndUpdate(ctx,
+		bson.M{"_id": checksum, "key": key, "refs": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"refs": -1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&ref)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error al liberar contenido: %w", err)
+	}
+	if ref.Refs > 0 {
+		return false, nil
+	}
+
+	result, err := r.blobs.DeleteOne(ctx, bson.M{"_id": checksum, "key": key, "refs": bson.M{"$lte": 0}})
+	if err != nil {
+		return false, fmt.Errorf("error al liberar contenido: %w", err)
+	}
+	return result.DeletedCount == 1, nil
+}
+
+// Delete elimina un adjunto
+func (r *MongoAttachmentRepository) Delete(ctx context.Context, attachmentID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": attachmentID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar adjunto: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
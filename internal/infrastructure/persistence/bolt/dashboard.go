@@ -0,0 +1,113 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"go.etcd.io/bbolt"
+)
+
+// DueToday tareas que vencen hoy en la zona horaria indicada
+func (r *TaskRepository) DueToday(ctx context.Context, userID string, loc *time.Location) ([]domain.Task, error) {
+	start, end := domain.DayRange(time.Now(), loc)
+
+	tasks, err := r.userTasks(userID, start, end, func(t *domain.Task) bool { return t.DueDate.Before(end) })
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tareas de hoy: %w", err)
+	}
+	return tasks, nil
+}
+
+// Aggregated conteos de las tareas del usuario; las vencidas se calculan
+// respecto de until
+func (r *TaskRepository) Aggregated(ctx context.Context, userID string, until time.Time) (domain.Stats, error) {
+	stats := domain.Stats{
+		ByStatus:   map[string]int{},
+		ByPriority: map[string]int{},
+		ByType:     map[string]int{},
+		BySubject:  map[string]int{},
+	}
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		return scanUser(tx, userID, time.Time{}, time.Time{}, func(t *domain.Task) bool {
+			stats.Total++
+			switch t.Status {
+			case domain.StatusDone:
+				stats.Completed++
+			case domain.StatusTodo, domain.StatusInProgress:
+				stats.Pending++
+			}
+			if t.DueDate.Before(until) && t.Status != domain.StatusDone {
+				stats.Overdue++
+			}
+			stats.ByStatus[t.Status]++
+			stats.ByPriority[t.Priority]++
+			stats.ByType[t.Type]++
+			if t.SubjectID != "" {
+				stats.BySubject[t.SubjectID]++
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return stats, fmt.Errorf("error al agregar tareas: %w", err)
+	}
+	return stats, nil
+}
+
+// GetDashboardStats próximas, de hoy y conteos en una sola pasada por el
+// índice, que ya viene ordenado por dueDate
+func (r *TaskRepository) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
+	result := domain.DashboardData{
+		UpcomingTasks: make([]domain.DashboardTask, 0),
+		TodayTasks:    make([]domain.DashboardTask, 0),
+	}
+
+	w := opts.Windows(time.Now())
+	now, startOfWeek, startOfDay, endOfDay := w.Now, w.StartOfWeek, w.StartOfDay, w.EndOfDay
+
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		return scanUser(tx, userID, time.Time{}, time.Time{}, func(t *domain.Task) bool {
+			switch t.Status {
+			case domain.StatusTodo:
+				result.TodoCount++
+				result.TotalPending++
+			case domain.StatusInProgress:
+				result.InProgressCount++
+				result.TotalPending++
+			case domain.StatusDone:
+				if t.CompletedAt != nil && !t.CompletedAt.Before(startOfWeek) {
+					result.CompletedThisWeek++
+				}
+			}
+			if t.DueDate.Before(now) && t.Status != domain.StatusDone {
+				result.OverdueCount++
+			}
+			if !t.DueDate.Before(startOfDay) && t.DueDate.Before(endOfDay) && (w.TodayLimit == 0 || len(result.TodayTasks) < w.TodayLimit) {
+				result.TodayTasks = append(result.TodayTasks, taskToDashboardTask(t))
+			}
+			if t.DueDate.After(now) && t.Status != domain.StatusDone && len(result.UpcomingTasks) < w.UpcomingLimit {
+				result.UpcomingTasks = append(result.UpcomingTasks, taskToDashboardTask(t))
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return result, fmt.Errorf("error al obtener dashboard: %w", err)
+	}
+	return result, nil
+}
+
+// taskToDashboardTask resumen de la tarea para el dashboard
+func taskToDashboardTask(t *domain.Task) domain.DashboardTask {
+	return domain.DashboardTask{
+		ID:       t.ID,
+		Title:    t.Title,
+		DueDate:  t.DueDate.Format("2006-01-02T15:04:05Z07:00"),
+		Priority: t.Priority,
+		Status:   t.Status,
+		Type:     t.Type,
+	}
+}
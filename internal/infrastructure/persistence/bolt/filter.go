@@ -0,0 +1,62 @@
+package bolt
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/search"
+)
+
+// matches mismas condiciones que el FindByFilter de Mongo
+func matches(t *domain.Task, filter ports.TaskFilter, now time.Time) bool {
+	if len(filter.Status) > 0 && !slices.Contains(filter.Status, t.Status) {
+		return false
+	}
+	if len(filter.Priority) > 0 && !slices.Contains(filter.Priority, t.Priority) {
+		return false
+	}
+	if len(filter.Type) > 0 && !slices.Contains(filter.Type, t.Type) {
+		return false
+	}
+	if filter.SubjectID != "" && t.SubjectID != filter.SubjectID {
+		return false
+	}
+	if filter.PeriodID != "" && t.PeriodID != filter.PeriodID {
+		return false
+	}
+	if filter.IsOverdue != nil && *filter.IsOverdue && (!t.DueDate.Before(now) || t.Status == domain.StatusDone) {
+		return false
+	}
+	if filter.IsDueSoon != nil && *filter.IsDueSoon && (t.DueDate.Before(now) || t.DueDate.After(now.Add(24*time.Hour))) {
+		return false
+	}
+	if filter.Query != nil && !filter.Query.Matches(t) {
+		return false
+	}
+	return true
+}
+
+// FindByFilter recorre el índice userId+dueDate (acotado por
+// dueDateFrom/dueDateTo), filtra y pagina en memoria con las mismas reglas
+// que el resto de los backends
+func (r *TaskRepository) FindByFilter(ctx context.Context, filter ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	now := time.Now()
+	tasks, err := r.userTasks(filter.UserID, filter.DueDateFrom, filter.DueDateTo, func(t *domain.Task) bool {
+		return matches(t, filter, now)
+	})
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if filter.Search != "" {
+		q := search.ParseQuery(filter.Search)
+		if q.Empty() {
+			return search.Paginate(nil, filter, now)
+		}
+		return search.Paginate(search.Rank(tasks, q), filter, now)
+	}
+	return domain.PaginateInMemory(tasks, filter, now)
+}
@@ -0,0 +1,402 @@
+// Package bolt implementa el repositorio de tareas sobre un archivo bbolt
+// embebido: persiste entre reinicios sin necesitar Mongo ni PostgreSQL
+// (desarrollo local, demos, despliegues de un solo nodo). Se activa con
+// BOLT_PATH.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Buckets del archivo:
+//
+//	tasks          id → tarea en BSON (mismo formato que en Mongo)
+//	tasks_by_due   userID 0x00 dueDate(ms) id → id  (índice userId+dueDate)
+//	tasks_by_ext   userID 0x00 externalID     → id
+//	tasks_by_share ownerID 0x00 sharedTaskID 0x00 id → id  (copias de colaboradores)
+var (
+	bucketTasks    = []byte("tasks")
+	bucketByDue    = []byte("tasks_by_due")
+	bucketByExt    = []byte("tasks_by_ext")
+	bucketByShared = []byte("tasks_by_share")
+)
+
+// TaskRepository implementa ports.TaskRepository sobre bbolt
+type TaskRepository struct {
+	db *bbolt.DB
+}
+
+var _ ports.TaskRepository = (*TaskRepository)(nil)
+
+// Open abre (o crea) el archivo y sus buckets. Un archivo solo admite un
+// proceso: si otro lo tiene abierto, falla pasado el timeout.
+func Open(path string) (*TaskRepository, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("abrir %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{bucketTasks, bucketByDue, bucketByExt, bucketByShared} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &TaskRepository{db: db}, nil
+}
+
+// Close cierra el archivo
+func (r *TaskRepository) Close() error {
+	return r.db.Close()
+}
+
+// newID ID aleatorio de 24 caracteres hex (mismo formato que los ObjectID de Mongo)
+func newID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// userPrefix prefijo de las claves de índice de un usuario
+func userPrefix(userID string) []byte {
+	return append([]byte(userID), 0)
+}
+
+// dueBytes fecha en milisegundos, big endian con el bit de signo invertido
+// para que el orden de bytes coincida con el cronológico
+func dueBytes(t time.Time) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(t.UnixMilli())^(1<<63))
+	return b
+}
+
+func dueKey(t *domain.Task) []byte {
+	key := append(userPrefix(t.UserID), dueBytes(t.DueDate)...)
+	return append(key, t.ID...)
+}
+
+func extKey(userID, externalID string) []byte {
+	return append(userPrefix(userID), externalID...)
+}
+
+// sharedPrefix prefijo de las copias de una tarea compartida
+func sharedPrefix(ownerID, taskID string) []byte {
+	return append(append(userPrefix(ownerID), taskID...), 0)
+}
+
+func sharedKey(t *domain.Task) []byte {
+	return append(sharedPrefix(t.OwnerID, t.SharedTaskID), t.ID...)
+}
+
+// putIndexes agrega las entradas de índice de la tarea
+func putIndexes(tx *bbolt.Tx, t *domain.Task) error {
+	if err := tx.Bucket(bucketByDue).Put(dueKey(t), []byte(t.ID)); err != nil {
+		return err
+	}
+	if t.SharedTaskID != "" {
+		if err := tx.Bucket(bucketByShared).Put(sharedKey(t), []byte(t.ID)); err != nil {
+			return err
+		}
+	}
+	if t.ExternalID != "" {
+		return tx.Bucket(bucketByExt).Put(extKey(t.UserID, t.ExternalID), []byte(t.ID))
+	}
+	return nil
+}
+
+// deleteIndexes elimina las entradas de índice de la versión guardada
+func deleteIndexes(tx *bbolt.Tx, t *domain.Task) error {
+	if err := tx.Bucket(bucketByDue).Delete(dueKey(t)); err != nil {
+		return err
+	}
+	if t.SharedTaskID != "" {
+		if err := tx.Bucket(bucketByShared).Delete(sharedKey(t)); err != nil {
+			return err
+		}
+	}
+	if t.ExternalID != "" {
+		ext := tx.Bucket(bucketByExt)
+		// Solo si apunta a esta tarea (otra pudo reutilizar el externalId)
+		if id := ext.Get(extKey(t.UserID, t.ExternalID)); string(id) == t.ID {
+			return ext.Delete(extKey(t.UserID, t.ExternalID))
+		}
+	}
+	return nil
+}
+
+func getTask(tx *bbolt.Tx, id []byte) (*domain.Task, error) {
+	raw := tx.Bucket(bucketTasks).Get(id)
+	if raw == nil {
+		return nil, nil
+	}
+	var t domain.Task
+	if err := bson.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("tarea %s corrupta: %w", id, err)
+	}
+	return &t, nil
+}
+
+func putTask(tx *bbolt.Tx, t *domain.Task) error {
+	raw, err := bson.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketTasks).Put([]byte(t.ID), raw)
+}
+
+// scanUser recorre las tareas del usuario en orden de dueDate, limitadas a
+// [from, to] cuando no son cero. fn devuelve false para cortar.
+func scanUser(tx *bbolt.Tx, userID string, from, to time.Time, fn func(*domain.Task) bool) error {
+	prefix := userPrefix(userID)
+	seek := prefix
+	if !from.IsZero() {
+		seek = append(append([]byte{}, prefix...), dueBytes(from)...)
+	}
+	var end []byte
+	if !to.IsZero() {
+		// Claves con la fecha tope incluida: prefijo + to(ms) + cualquier id
+		end = append(append([]byte{}, prefix...), dueBytes(to.Add(time.Millisecond))...)
+	}
+
+	c := tx.Bucket(bucketByDue).Cursor()
+	for k, id := c.Seek(seek); k != nil && bytes.HasPrefix(k, prefix); k, id = c.Next() {
+		if end != nil && bytes.Compare(k, end) >= 0 {
+			break
+		}
+		t, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if t != nil && !fn(t) {
+			break
+		}
+	}
+	return nil
+}
+
+// userTasks tareas del usuario (en orden de dueDate) que cumplen keep
+func (r *TaskRepository) userTasks(userID string, from, to time.Time, keep func(*domain.Task) bool) ([]domain.Task, error) {
+	out := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		return scanUser(tx, userID, from, to, func(t *domain.Task) bool {
+			if keep == nil || keep(t) {
+				out = append(out, *t)
+			}
+			return true
+		})
+	})
+	return out, err
+}
+
+// Create inserta una nueva tarea
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if task.ID == "" {
+		task.ID = newID()
+	}
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(bucketTasks).Get([]byte(task.ID)) != nil {
+			return fmt.Errorf("ya existe una tarea con ID %s", task.ID)
+		}
+		if err := putTask(tx, task); err != nil {
+			return err
+		}
+		return putIndexes(tx, task)
+	})
+	if err != nil {
+		return fmt.Errorf("error al crear tarea: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtiene una tarea y verifica pertenencia al usuario
+func (r *TaskRepository) GetByID(ctx context.Context, taskID, userID string) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		task, err = getTask(tx, []byte(taskID))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+	if task == nil || task.UserID != userID {
+		return nil, fmt.Errorf("tarea no encontrada")
+	}
+	return task, nil
+}
+
+// GetByExternalID obtiene la tarea importada con ese ID de origen
+func (r *TaskRepository) GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		id := tx.Bucket(bucketByExt).Get(extKey(userID, externalID))
+		if id == nil {
+			return nil
+		}
+		var err error
+		task, err = getTask(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+	if task == nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// GetAll obtiene todas las tareas del usuario
+func (r *TaskRepository) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
+	tasks, err := r.userTasks(userID, time.Time{}, time.Time{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar tareas: %w", err)
+	}
+	return tasks, nil
+}
+
+// GetByUserAndStatus obtiene tareas por usuario y estado
+func (r *TaskRepository) GetByUserAndStatus(ctx context.Context, userID, status string) ([]domain.Task, error) {
+	if domain.StatusRank(status) == 0 {
+		return nil, fmt.Errorf("estado inválido: %s", status)
+	}
+	tasks, err := r.userTasks(userID, time.Time{}, time.Time{}, func(t *domain.Task) bool { return t.Status == status })
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar tareas por estado: %w", err)
+	}
+	return tasks, nil
+}
+
+// Update reemplaza la tarea (solo si pertenece al usuario)
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	var notFound bool
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		old, err := getTask(tx, []byte(task.ID))
+		if err != nil {
+			return err
+		}
+		if old == nil || old.UserID != task.UserID {
+			notFound = true
+			return nil
+		}
+		if err := deleteIndexes(tx, old); err != nil {
+			return err
+		}
+		if err := putTask(tx, task); err != nil {
+			return err
+		}
+		return putIndexes(tx, task)
+	})
+	if err != nil {
+		return fmt.Errorf("error al actualizar tarea: %w", err)
+	}
+	if notFound {
+		return fmt.Errorf("tarea no encontrada o no pertenece al usuario")
+	}
+	return nil
+}
+
+// UpdateStatus igual que Update (no se aplican reglas de estado)
+func (r *TaskRepository) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
+// UpdateShared igual que Update (no se aplican reglas de estado)
+func (r *TaskRepository) UpdateShared(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
+// DeleteShared igual que Delete
+func (r *TaskRepository) DeleteShared(ctx context.Context, taskID, userID string) error {
+	return r.Delete(ctx, taskID, userID)
+}
+
+// Delete elimina una tarea (solo si pertenece al usuario)
+func (r *TaskRepository) Delete(ctx context.Context, taskID, userID string) error {
+	var notFound bool
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		old, err := getTask(tx, []byte(taskID))
+		if err != nil {
+			return err
+		}
+		if old == nil || old.UserID != userID {
+			notFound = true
+			return nil
+		}
+		if err := deleteIndexes(tx, old); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketTasks).Delete([]byte(taskID))
+	})
+	if err != nil {
+		return fmt.Errorf("error al eliminar tarea: %w", err)
+	}
+	if notFound {
+		return fmt.Errorf("tarea no encontrada")
+	}
+	return nil
+}
+
+// ListShared copias de los colaboradores de una tarea, por fecha de alta
+func (r *TaskRepository) ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error) {
+	tasks := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		prefix := sharedPrefix(ownerID, taskID)
+		c := tx.Bucket(bucketByShared).Cursor()
+		for k, id := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, id = c.Next() {
+			t, err := getTask(tx, id)
+			if err != nil {
+				return err
+			}
+			if t != nil {
+				tasks = append(tasks, *t)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar colaboradores: %w", err)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+// Find listado con filtros; equivale a FindByFilter
+func (r *TaskRepository) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.FindByFilter(ctx, f)
+}
+
+// Search búsqueda de texto; equivale a FindByFilter con Search
+func (r *TaskRepository) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.FindByFilter(ctx, f)
+}
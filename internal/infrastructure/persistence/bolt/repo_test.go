@@ -0,0 +1,85 @@
+package bolt
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/repotest"
+)
+
+func openTemp(t *testing.T, path string) *TaskRepository {
+	t.Helper()
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestTaskRepositoryContract(t *testing.T) {
+	repotest.TaskRepository(t, func(t *testing.T) ports.TaskRepository {
+		return openTemp(t, filepath.Join(t.TempDir(), "tasks.db"))
+	})
+}
+
+func TestTasksSurviveReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "tasks.db")
+
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := &domain.Task{UserID: "u1", Title: "Informe", DueDate: time.Now(), Status: domain.StatusTodo,
+		Priority: domain.PriorityLow, Type: domain.TypeEssay, ExternalID: "csv-7"}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	repo.Close()
+
+	repo = openTemp(t, path)
+	got, err := repo.GetByExternalID(ctx, "u1", "csv-7")
+	if err != nil || got.ID != task.ID || got.Title != "Informe" {
+		t.Fatalf("task lost after reopen: %+v %v", got, err)
+	}
+}
+
+func TestIndexesFollowUpdates(t *testing.T) {
+	ctx := context.Background()
+	repo := openTemp(t, filepath.Join(t.TempDir(), "tasks.db"))
+	now := time.Now()
+
+	task := &domain.Task{UserID: "u1", Title: "A", DueDate: now.Add(48 * time.Hour), Status: domain.StatusTodo,
+		Priority: domain.PriorityLow, Type: domain.TypeEssay, ExternalID: "old"}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	task.DueDate, task.ExternalID = now.Add(time.Hour), "new"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	// El índice de fechas no conserva la entrada vieja
+	tasks, _, err := repo.FindByFilter(ctx, domain.TaskFilter{UserID: "u1", DueDateFrom: now, DueDateTo: now.Add(2 * time.Hour)})
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected the task in the new range: %v %v", tasks, err)
+	}
+	if all, _ := repo.GetAll(ctx, "u1"); len(all) != 1 {
+		t.Errorf("stale index entries: %d tasks", len(all))
+	}
+	if _, err := repo.GetByExternalID(ctx, "u1", "old"); err != domain.ErrTaskNotFound {
+		t.Errorf("old externalId still indexed: %v", err)
+	}
+
+	if err := repo.Delete(ctx, task.ID, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByExternalID(ctx, "u1", "new"); err != domain.ErrTaskNotFound {
+		t.Errorf("externalId still indexed after delete: %v", err)
+	}
+}
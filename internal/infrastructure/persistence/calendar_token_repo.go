@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCalendarTokenRepository implementa CalendarTokenRepository usando MongoDB
+type MongoCalendarTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCalendarTokenRepository crea una nueva instancia de MongoCalendarTokenRepository
+func NewMongoCalendarTokenRepository(collection *mongo.Collection) *MongoCalendarTokenRepository {
+	return &MongoCalendarTokenRepository{
+		collection: collection,
+	}
+}
+
+// Create inserta un nuevo token de calendario
+func (r *MongoCalendarTokenRepository) Create(ctx context.Context, token *domain.CalendarToken) error {
+	if token.ID == "" {
+		token.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("error al crear token de calendario: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash busca un token por su hash
+func (r *MongoCalendarTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.CalendarToken, error) {
+	var token domain.CalendarToken
+	err := r.collection.FindOne(ctx, bson.M{"tokenHash": tokenHash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCalendarTokenNotFound
+		}
+		return nil, fmt.Errorf("error al obtener token de calendario: %w", err)
+	}
+
+	return &token, nil
+}
+
+// ListByUser lista los tokens de un usuario ordenados por fecha de creación
+func (r *MongoCalendarTokenRepository) ListByUser(ctx context.Context, userID string) ([]domain.CalendarToken, error) {
+	opts := options.Find()
+	opts.SetSort(bson.M{"createdAt": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar tokens de calendario: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []domain.CalendarToken
+	if err = cursor.All(ctx, &tokens); err != nil {
+		return nil, fmt.Errorf("error al decodificar tokens de calendario: %w", err)
+	}
+
+	if tokens == nil {
+		tokens = []domain.CalendarToken{}
+	}
+
+	return tokens, nil
+}
+
+// Revoke marca el token como revocado (solo si pertenece al usuario)
+func (r *MongoCalendarTokenRepository) Revoke(ctx context.Context, tokenID, userID string, at time.Time) error {
+	filter := bson.M{
+		"_id":    tokenID,
+		"userId": userID,
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revokedAt": at}})
+	if err != nil {
+		return fmt.Errorf("error al revocar token de calendario: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrCalendarTokenNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed actualiza la fecha del último acceso al feed
+func (r *MongoCalendarTokenRepository) TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": tokenID}, bson.M{"$set": bson.M{"lastUsedAt": at}})
+	if err != nil {
+		return fmt.Errorf("error al actualizar token de calendario: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/persistence/memory"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testCollection colección tasks en una base temporal de TEST_MONGO_URI;
+// sin la variable el test se omite, salvo en CI (variable CI definida)
+func testCollection(tb testing.TB) *mongo.Collection {
+	tb.Helper()
+	uri := os.Getenv("TEST_MONGO_URI")
+	if uri == "" {
+		if os.Getenv("CI") != "" {
+			tb.Fatal("TEST_MONGO_URI es obligatoria en CI")
+		}
+		tb.Skip("TEST_MONGO_URI no configurada")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		tb.Fatal(err)
+	}
+	db := client.Database("uniflow_test_" + primitive.NewObjectID().Hex())
+	tb.Cleanup(func() {
+		_ = db.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+	coll := db.Collection("tasks")
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "dueDate", Value: 1}}})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return coll
+}
+
+// dashboardTasks tareas que cubren todas las secciones del dashboard. Las
+// fechas evitan los bordes exactos (inicio del día, now) donde memoria usa
+// comparaciones estrictas.
+func dashboardTasks(userID string, now time.Time, n int) []*domain.Task {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	templates := []struct {
+		status    string
+		due       time.Time
+		completed *time.Time
+	}{
+		{domain.StatusTodo, now.Add(-72 * time.Hour), nil},                                     // vencida
+		{domain.StatusInProgress, now.Add(-2 * time.Hour), nil},                                // vencida
+		{domain.StatusTodo, now.Add(48 * time.Hour), nil},                                      // próxima
+		{domain.StatusInProgress, now.Add(96 * time.Hour), nil},                                // próxima
+		{domain.StatusTodo, startOfDay.Add(12*time.Hour + time.Minute), nil},                   // hoy
+		{domain.StatusDone, now.Add(24 * time.Hour), ptr(now)},                                 // completada esta semana
+		{domain.StatusDone, now.Add(-30 * 24 * time.Hour), ptr(now.Add(-20 * 24 * time.Hour))}, // completada antes
+		{domain.StatusTodo, now.Add(10 * 24 * time.Hour), nil},                                 // próxima
+	}
+
+	tasks := make([]*domain.Task, 0, n)
+	for i := 0; i < n; i++ {
+		tpl := templates[i%len(templates)]
+		tasks = append(tasks, &domain.Task{
+			ID:        primitive.NewObjectID().Hex(),
+			UserID:    userID,
+			Title:     fmt.Sprintf("Tarea %d", i),
+			SubjectID: "subject-1",
+			// Milisegundos: la precisión con la que guarda Mongo
+			DueDate:     tpl.due.Add(time.Duration(i/len(templates)) * time.Second).Truncate(time.Millisecond),
+			Status:      tpl.status,
+			Priority:    domain.PriorityMedium,
+			Type:        domain.TypeAssignment,
+			Tags:        []string{},
+			CompletedAt: tpl.completed,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	return tasks
+}
+
+func ptr(t time.Time) *time.Time {
+	t = t.Truncate(time.Millisecond)
+	return &t
+}
+
+func dashboardIDs(tasks []domain.DashboardTask) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestDashboardFacetsToDashboard(t *testing.T) {
+	due := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	raw, err := bson.Marshal(bson.M{
+		"upcoming": bson.A{bson.M{"_id": "a", "title": "A", "dueDate": due, "status": domain.StatusTodo}},
+		"today":    bson.A{},
+		"counts": bson.A{bson.M{
+			"_id": nil, "overdue": 2, "pending": 5, "completedThisWeek": 1, "inProgress": 3, "todo": 2,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var facets dashboardFacets
+	if err := bson.Unmarshal(raw, &facets); err != nil {
+		t.Fatal(err)
+	}
+
+	d := facets.toDashboard()
+	if len(d.UpcomingTasks) != 1 || d.UpcomingTasks[0].ID != "a" || d.UpcomingTasks[0].DueDate != "2025-03-10T15:00:00Z" {
+		t.Errorf("unexpected upcoming %+v", d.UpcomingTasks)
+	}
+	if d.TodayTasks == nil || len(d.TodayTasks) != 0 {
+		t.Errorf("today should be an empty list, got %#v", d.TodayTasks)
+	}
+	if d.OverdueCount != 2 || d.TotalPending != 5 || d.CompletedThisWeek != 1 || d.InProgressCount != 3 || d.TodoCount != 2 {
+		t.Errorf("unexpected counts %+v", d)
+	}
+
+	// Usuario sin tareas: $group no emite documento
+	empty := dashboardFacets{}.toDashboard()
+	if empty.UpcomingTasks == nil || empty.TodayTasks == nil || empty.TotalPending != 0 {
+		t.Errorf("unexpected empty dashboard %+v", empty)
+	}
+}
+
+func TestDashboardParityWithMemory(t *testing.T) {
+	coll := testCollection(t)
+	ctx := context.Background()
+	mongoRepo := NewMongoTaskRepository(coll)
+	memRepo := memory.NewRepo()
+
+	// "Hoy" y "esta semana" en una zona distinta de la del servidor
+	loc, err := time.LoadLocation("America/Costa_Rica")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().In(loc)
+	for _, task := range dashboardTasks("user-parity", now, 8) {
+		cp := *task
+		if err := mongoRepo.Create(ctx, task); err != nil {
+			t.Fatal(err)
+		}
+		_ = memRepo.Create(ctx, &cp)
+	}
+	_ = mongoRepo.Create(ctx, &domain.Task{UserID: "other", Title: "x", DueDate: now, Status: domain.StatusTodo})
+
+	got, err := mongoRepo.GetDashboardStats(ctx, "user-parity", domain.DashboardOptions{Location: loc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := memRepo.GetDashboardStats(ctx, "user-parity", domain.DashboardOptions{Location: loc})
+
+	if got.OverdueCount != want.OverdueCount || got.TotalPending != want.TotalPending ||
+		got.CompletedThisWeek != want.CompletedThisWeek || got.InProgressCount != want.InProgressCount ||
+		got.TodoCount != want.TodoCount {
+		t.Errorf("counts differ:\nmongo  %+v\nmemory %+v", got, want)
+	}
+	// Se comparan IDs: Mongo devuelve las fechas en UTC
+	if fmt.Sprint(dashboardIDs(got.UpcomingTasks)) != fmt.Sprint(dashboardIDs(want.UpcomingTasks)) {
+		t.Errorf("upcoming differ: %v vs %v", dashboardIDs(got.UpcomingTasks), dashboardIDs(want.UpcomingTasks))
+	}
+	if fmt.Sprint(dashboardIDs(got.TodayTasks)) != fmt.Sprint(dashboardIDs(want.TodayTasks)) {
+		t.Errorf("today differ: %v vs %v", dashboardIDs(got.TodayTasks), dashboardIDs(want.TodayTasks))
+	}
+}
+
+// sequentialDashboardStats implementación anterior (2 Find + 5
+// CountDocuments en serie), solo como referencia del benchmark
+func sequentialDashboardStats(ctx context.Context, coll *mongo.Collection, userID string) (domain.DashboardData, error) {
+	result := domain.DashboardData{}
+	now := time.Now()
+	weekAgo := now.AddDate(0, 0, -7)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	finds := []struct {
+		filter bson.M
+		opts   *options.FindOptions
+	}{
+		{bson.M{"userId": userID, "dueDate": bson.M{"$gt": now}, "status": bson.M{"$ne": domain.StatusDone}},
+			options.Find().SetSort(bson.M{"dueDate": 1}).SetLimit(5)},
+		{bson.M{"userId": userID, "dueDate": bson.M{"$gte": startOfDay, "$lt": startOfDay.Add(24 * time.Hour)}}, nil},
+	}
+	for _, f := range finds {
+		cursor, err := coll.Find(ctx, f.filter, f.opts)
+		if err != nil {
+			return result, err
+		}
+		var tasks []domain.Task
+		if err := cursor.All(ctx, &tasks); err != nil {
+			return result, err
+		}
+	}
+
+	counts := []bson.M{
+		{"userId": userID, "dueDate": bson.M{"$lt": now}, "status": bson.M{"$ne": domain.StatusDone}},
+		{"userId": userID, "status": bson.M{"$in": []string{domain.StatusTodo, domain.StatusInProgress}}},
+		{"userId": userID, "status": domain.StatusDone, "completedAt": bson.M{"$gte": weekAgo}},
+		{"userId": userID, "status": domain.StatusInProgress},
+		{"userId": userID, "status": domain.StatusTodo},
+	}
+	for _, filter := range counts {
+		if _, err := coll.CountDocuments(ctx, filter); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// BenchmarkDashboard compara el $facet con las 7 consultas en serie:
+//
+//	TEST_MONGO_URI=mongodb://localhost:27017 go test -run x -bench Dashboard ./internal/infrastructure/persistence/
+func BenchmarkDashboard(b *testing.B) {
+	coll := testCollection(b)
+	ctx := context.Background()
+	repo := NewMongoTaskRepository(coll)
+	for _, task := range dashboardTasks("user-bench", time.Now(), 500) {
+		if err := repo.Create(ctx, task); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("facet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetDashboardStats(ctx, "user-bench", domain.DashboardOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sequentialDashboardStats(ctx, coll, "user-bench"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoEventBroadcaster implementa ports.EventBroadcaster con change streams:
+// cada réplica inserta sus eventos en una colección y todas observan las inserciones.
+// Requiere que MongoDB corra como replica set (Atlas/Cosmos lo hacen por defecto).
+type MongoEventBroadcaster struct {
+	collection *mongo.Collection
+}
+
+// NewMongoEventBroadcaster crea una nueva instancia de MongoEventBroadcaster
+func NewMongoEventBroadcaster(collection *mongo.Collection) *MongoEventBroadcaster {
+	return &MongoEventBroadcaster{
+		collection: collection,
+	}
+}
+
+// Broadcast inserta el evento en la colección compartida
+func (b *MongoEventBroadcaster) Broadcast(ctx context.Context, event domain.TaskEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := b.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("error al difundir evento: %w", err)
+	}
+
+	return nil
+}
+
+// changeEvent documento recibido del change stream
+type changeEvent struct {
+	FullDocument domain.TaskEvent `bson:"fullDocument"`
+}
+
+// Run observa las inserciones y reconecta (retomando desde el último
+// resume token) si el stream se corta, hasta que ctx se cancele
+func (b *MongoEventBroadcaster) Run(ctx context.Context, deliver func(domain.TaskEvent)) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": "insert"}}},
+	}
+
+	var resumeToken bson.Raw
+	backoff := time.Second
+
+	for ctx.Err() == nil {
+		opts := options.ChangeStream()
+		if resumeToken != nil {
+			opts.SetResumeAfter(resumeToken)
+		}
+
+		stream, err := b.collection.Watch(ctx, pipeline, opts)
+		if err != nil {
+			log.Printf("⚠️ Error al abrir change stream de eventos (reintento en %s): %v", backoff, err)
+			if !sleepCtx(ctx, backoff) {
+				return nil
+			}
+			if backoff < time.Minute {
+				backoff *= 2
+			}
+			continue
+		}
+		backoff = time.Second
+
+		for stream.Next(ctx) {
+			var change changeEvent
+			if err := stream.Decode(&change); err != nil {
+				log.Printf("⚠️ Error al decodificar evento: %v", err)
+				continue
+			}
+			resumeToken = stream.ResumeToken()
+			deliver(change.FullDocument)
+		}
+
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️ Change stream de eventos interrumpido: %v", err)
+		}
+		_ = stream.Close(context.Background())
+	}
+
+	return nil
+}
+
+// sleepCtx espera d o hasta que ctx se cancele; false si se canceló
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
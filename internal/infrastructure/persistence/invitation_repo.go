@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoInvitationRepository implementa InvitationRepository usando MongoDB
+type MongoInvitationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoInvitationRepository crea una nueva instancia de MongoInvitationRepository
+func NewMongoInvitationRepository(collection *mongo.Collection) *MongoInvitationRepository {
+	return &MongoInvitationRepository{
+		collection: collection,
+	}
+}
+
+// Create inserta una nueva invitación
+func (r *MongoInvitationRepository) Create(ctx context.Context, inv *domain.Invitation) error {
+	if inv.ID == "" {
+		inv.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, inv); err != nil {
+		return fmt.Errorf("error al crear invitación: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene una invitación
+func (r *MongoInvitationRepository) GetByID(ctx context.Context, invitationID string) (*domain.Invitation, error) {
+	var inv domain.Invitation
+	err := r.collection.FindOne(ctx, bson.M{"_id": invitationID}).Decode(&inv)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("error al obtener invitación: %w", err)
+	}
+
+	return &inv, nil
+}
+
+// ListByTask invitaciones pendientes de una tarea del dueño
+func (r *MongoInvitationRepository) ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Invitation, error) {
+	return r.list(ctx, bson.M{"ownerId": ownerID, "taskId": taskID})
+}
+
+// ListByEmail invitaciones pendientes para un email
+func (r *MongoInvitationRepository) ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error) {
+	return r.list(ctx, bson.M{"email": email})
+}
+
+func (r *MongoInvitationRepository) list(ctx context.Context, filter bson.M) ([]domain.Invitation, error) {
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar invitaciones: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	invitations := []domain.Invitation{}
+	if err = cursor.All(ctx, &invitations); err != nil {
+		return nil, fmt.Errorf("error al decodificar invitaciones: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Delete elimina una invitación
+func (r *MongoInvitationRepository) Delete(ctx context.Context, invitationID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": invitationID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar invitación: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrInvitationNotFound
+	}
+
+	return nil
+}
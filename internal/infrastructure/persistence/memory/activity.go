@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// ActivityRepo implementa ports.ActivityRepository en memoria
+type ActivityRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.Activity
+	seq  int64
+}
+
+func NewActivityRepo() *ActivityRepo {
+	return &ActivityRepo{data: make(map[string]*domain.Activity)}
+}
+
+func (r *ActivityRepo) Create(ctx context.Context, a *domain.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a.ID == "" {
+		r.seq++
+		a.ID = "act-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	cp := *a
+	cp.Mentions = append([]string(nil), a.Mentions...)
+	r.data[a.ID] = &cp
+	return nil
+}
+
+func (r *ActivityRepo) GetByID(ctx context.Context, ownerID, taskID, activityID string) (*domain.Activity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.data[activityID]
+	if !ok || a.OwnerID != ownerID || a.TaskID != taskID {
+		return nil, domain.ErrCommentNotFound
+	}
+	cp := *a
+	cp.Mentions = append([]string(nil), a.Mentions...)
+	return &cp, nil
+}
+
+func (r *ActivityRepo) Update(ctx context.Context, a *domain.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur, ok := r.data[a.ID]
+	if !ok || cur.OwnerID != a.OwnerID || cur.TaskID != a.TaskID {
+		return domain.ErrCommentNotFound
+	}
+	cp := *a
+	cp.Mentions = append([]string(nil), a.Mentions...)
+	r.data[a.ID] = &cp
+	return nil
+}
+
+func (r *ActivityRepo) Delete(ctx context.Context, ownerID, taskID, activityID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.data[activityID]
+	if !ok || a.OwnerID != ownerID || a.TaskID != taskID {
+		return domain.ErrCommentNotFound
+	}
+	delete(r.data, activityID)
+	return nil
+}
+
+func (r *ActivityRepo) List(ctx context.Context, q domain.ActivityQuery) ([]domain.Activity, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]domain.Activity, 0)
+	for _, a := range r.data {
+		if a.OwnerID == q.OwnerID && a.TaskID == q.TaskID && (q.Kind == "" || a.Kind == q.Kind) {
+			cp := *a
+			cp.Mentions = append([]string(nil), a.Mentions...)
+			all = append(all, cp)
+		}
+	}
+	// Más recientes primero
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].CreatedAt.After(all[j].CreatedAt)
+		}
+		return all[i].ID > all[j].ID
+	})
+
+	total := int64(len(all))
+	start := (q.Page - 1) * q.Limit
+	if start >= len(all) {
+		return []domain.Activity{}, total, nil
+	}
+	end := start + q.Limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[start:end], total, nil
+}
+
+func (r *ActivityRepo) DeleteByTask(ctx context.Context, ownerID, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, a := range r.data {
+		if a.OwnerID == ownerID && a.TaskID == taskID {
+			delete(r.data, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// AttachmentRepo implementa ports.AttachmentRepository en memoria
+type AttachmentRepo struct {
+	mu    sync.RWMutex
+	data  map[string]*domain.Attachment
+	blobs map[string]*blobRef // por checksum
+	seq   int64
+}
+
+// blobRef referencias a un contenido y la clave con la que se guardó
+type blobRef struct {
+	key  string
+	refs int64
+}
+
+func NewAttachmentRepo() *AttachmentRepo {
+	return &AttachmentRepo{data: make(map[string]*domain.Attachment), blobs: make(map[string]*blobRef)}
+}
+
+func (r *AttachmentRepo) Create(ctx context.Context, a *domain.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a.ID == "" {
+		r.seq++
+		a.ID = "att-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	cp := *a
+	r.data[a.ID] = &cp
+	return nil
+}
+
+func (r *AttachmentRepo) GetByID(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.data[attachmentID]
+	if !ok {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *AttachmentRepo) FindByChecksum(ctx context.Context, ownerID, taskID, checksum string) (*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, a := range r.data {
+		if a.OwnerID == ownerID && a.TaskID == taskID && a.Checksum == checksum {
+			cp := *a
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrAttachmentNotFound
+}
+
+func (r *AttachmentRepo) ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.Attachment, 0)
+	for _, a := range r.data {
+		if a.OwnerID == ownerID && a.TaskID == taskID {
+			out = append(out, *a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *AttachmentRepo) AcquireBlob(ctx context.Context, checksum, newKey string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ref, ok := r.blobs[checksum]
+	if !ok {
+		ref = &blobRef{key: newKey}
+		r.blobs[checksum] = ref
+	}
+	ref.refs++
+	return ref.key, !ok, nil
+}
+
+func (r *AttachmentRepo) ReleaseBlob(ctx context.Context, checksum, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ref, ok := r.blobs[checksum]
+	if !ok || ref.key != key {
+		return false, nil
+	}
+	if ref.refs--; ref.refs > 0 {
+		return false, nil
+	}
+	delete(r.blobs, checksum)
+	return true, nil
+}
+
+func (r *AttachmentRepo) Delete(ctx context.Context, attachmentID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.data[attachmentID]; !ok {
+		return domain.ErrAttachmentNotFound
+	}
+	delete(r.data, attachmentID)
+	return nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// CalendarTokenRepo implementa ports.CalendarTokenRepository en memoria
+type CalendarTokenRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.CalendarToken
+	seq  int64
+}
+
+func NewCalendarTokenRepo() *CalendarTokenRepo {
+	return &CalendarTokenRepo{data: make(map[string]*domain.CalendarToken)}
+}
+
+func (r *CalendarTokenRepo) Create(ctx context.Context, token *domain.CalendarToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token.ID == "" {
+		r.seq++
+		token.ID = "ct-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	cp := *token
+	r.data[token.ID] = &cp
+	return nil
+}
+
+func (r *CalendarTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*domain.CalendarToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.data {
+		if t.TokenHash == tokenHash {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrCalendarTokenNotFound
+}
+
+func (r *CalendarTokenRepo) ListByUser(ctx context.Context, userID string) ([]domain.CalendarToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.CalendarToken, 0)
+	for _, t := range r.data {
+		if t.UserID == userID {
+			out = append(out, *t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (r *CalendarTokenRepo) Revoke(ctx context.Context, tokenID, userID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.data[tokenID]
+	if !ok || t.UserID != userID {
+		return domain.ErrCalendarTokenNotFound
+	}
+	if t.RevokedAt == nil {
+		t.RevokedAt = &at
+	}
+	return nil
+}
+
+func (r *CalendarTokenRepo) TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.data[tokenID]
+	if !ok {
+		return domain.ErrCalendarTokenNotFound
+	}
+	t.LastUsedAt = &at
+	return nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// InvitationRepo implementa ports.InvitationRepository en memoria
+type InvitationRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.Invitation
+	seq  int64
+}
+
+func NewInvitationRepo() *InvitationRepo {
+	return &InvitationRepo{data: make(map[string]*domain.Invitation)}
+}
+
+func (r *InvitationRepo) Create(ctx context.Context, inv *domain.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if inv.ID == "" {
+		r.seq++
+		inv.ID = "inv-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	cp := *inv
+	r.data[inv.ID] = &cp
+	return nil
+}
+
+func (r *InvitationRepo) GetByID(ctx context.Context, invitationID string) (*domain.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	inv, ok := r.data[invitationID]
+	if !ok {
+		return nil, domain.ErrInvitationNotFound
+	}
+	cp := *inv
+	return &cp, nil
+}
+
+func (r *InvitationRepo) ListByTask(ctx context.Context, ownerID, taskID string) ([]domain.Invitation, error) {
+	return r.list(func(inv *domain.Invitation) bool { return inv.OwnerID == ownerID && inv.TaskID == taskID }), nil
+}
+
+func (r *InvitationRepo) ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error) {
+	return r.list(func(inv *domain.Invitation) bool { return inv.Email == email }), nil
+}
+
+// list invitaciones que cumplen keep, por fecha de creación
+func (r *InvitationRepo) list(keep func(*domain.Invitation) bool) []domain.Invitation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.Invitation, 0)
+	for _, inv := range r.data {
+		if keep(inv) {
+			out = append(out, *inv)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (r *InvitationRepo) Delete(ctx context.Context, invitationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.data[invitationID]; !ok {
+		return domain.ErrInvitationNotFound
+	}
+	delete(r.data, invitationID)
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"uniflow-api/internal/domain"
+)
+
+// PreferencesRepo implementa ports.PreferencesRepository en memoria
+type PreferencesRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.UserPreferences
+}
+
+func NewPreferencesRepo() *PreferencesRepo {
+	return &PreferencesRepo{data: make(map[string]*domain.UserPreferences)}
+}
+
+func (r *PreferencesRepo) Get(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.data[userID]
+	if !ok {
+		return nil, domain.ErrPreferencesNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (r *PreferencesRepo) Save(ctx context.Context, p *domain.UserPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *p
+	r.data[p.UserID] = &cp
+	return nil
+}
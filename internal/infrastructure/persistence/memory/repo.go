@@ -3,27 +3,33 @@ package memory
 import (
 	"context"
 	"errors"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
 
 	"uniflow-api/internal/application/ports"
 	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/search"
 )
 
 var ErrNotFound = errors.New("task not found")
 
 type Repo struct {
-	mu   sync.RWMutex
-	data map[string]*domain.Task
+	mu    sync.RWMutex
+	data  map[string]*domain.Task
+	index *search.Index // búsqueda de texto (title, description, tags)
+	seq   int64         // evita IDs repetidos en inserciones masivas (import)
 }
 
 func NewRepo() *Repo {
-	return &Repo{data: make(map[string]*domain.Task)}
+	return &Repo{data: make(map[string]*domain.Task), index: search.NewIndex()}
 }
 
+// nextID se llama con el lock de escritura tomado
 func (r *Repo) nextID() string {
-	return "t-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	r.seq++
+	return "t-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
 }
 
 func (r *Repo) Create(ctx context.Context, task *domain.Task) error {
@@ -35,6 +41,7 @@ func (r *Repo) Create(ctx context.Context, task *domain.Task) error {
 	}
 	cp := *task
 	r.data[task.ID] = &cp
+	r.index.Add(&cp)
 	return nil
 }
 
@@ -54,6 +61,19 @@ func (r *Repo) GetByID(ctx context.Context, taskID, userID string) (*domain.Task
 	return &cp, nil
 }
 
+func (r *Repo) GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.data {
+		if t.UserID == userID && t.ExternalID == externalID {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
 func (r *Repo) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -94,9 +114,20 @@ func (r *Repo) Update(ctx context.Context, task *domain.Task) error {
 	}
 	cp := *task
 	r.data[task.ID] = &cp
+	r.index.Add(&cp)
 	return nil
 }
 
+// UpdateStatus igual que Update (la memoria no aplica reglas de estado)
+func (r *Repo) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
+// UpdateShared igual que Update (la memoria no aplica reglas de estado)
+func (r *Repo) UpdateShared(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
 func (r *Repo) Delete(ctx context.Context, taskID, userID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -110,20 +141,69 @@ func (r *Repo) Delete(ctx context.Context, taskID, userID string) error {
 		return ErrNotFound
 	}
 	delete(r.data, taskID)
+	r.index.Remove(taskID)
 	return nil
 }
 
+// DeleteShared igual que Delete
+func (r *Repo) DeleteShared(ctx context.Context, taskID, userID string) error {
+	return r.Delete(ctx, taskID, userID)
+}
+
+// ListShared copias de los colaboradores de una tarea, por fecha de alta
+func (r *Repo) ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.Task, 0)
+	for _, t := range r.data {
+		if t.OwnerID == ownerID && t.SharedTaskID == taskID {
+			out = append(out, *t)
+		}
+	}
+	sortShared(out)
+	return out, nil
+}
+
+// sortShared orden de ListShared: fecha de alta e ID
+func sortShared(tasks []domain.Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
 // Métodos para Fase 3 (stubs por ahora)
 func (r *Repo) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
 	return []domain.Task{}, domain.PageInfo{}, nil
 }
 
+// DueToday tareas que vencen hoy en la zona horaria indicada, por dueDate
 func (r *Repo) DueToday(ctx context.Context, userID string, loc *time.Location) ([]domain.Task, error) {
-	return []domain.Task{}, nil
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start, end := domain.DayRange(time.Now(), loc)
+	tasks := make([]domain.Task, 0)
+	for _, t := range r.data {
+		if t.UserID == userID && !t.DueDate.Before(start) && t.DueDate.Before(end) {
+			tasks = append(tasks, *t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(tasks[j].DueDate)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
 }
 
+// Search búsqueda de texto; equivale a FindByFilter con Search
 func (r *Repo) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
-	return []domain.Task{}, domain.PageInfo{}, nil
+	return r.FindByFilter(ctx, f)
 }
 
 func (r *Repo) Aggregated(ctx context.Context, userID string, until time.Time) (domain.Stats, error) {
@@ -134,9 +214,23 @@ func (r *Repo) FindByFilter(ctx context.Context, filter ports.TaskFilter) ([]dom
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	// Búsqueda de texto: el índice reduce las tareas a las que contienen
+	// todos los términos
+	var query search.Query
+	var candidates map[string]struct{}
+	if filter.Search != "" {
+		query = search.ParseQuery(filter.Search)
+		candidates = r.index.Candidates(filter.UserID, query)
+	}
+
 	// Obtener todas las tareas del usuario
 	all := make([]domain.Task, 0)
-	for _, t := range r.data {
+	for id, t := range r.data {
+		if candidates != nil {
+			if _, ok := candidates[id]; !ok {
+				continue
+			}
+		}
 		if t.UserID == filter.UserID {
 			all = append(all, *t)
 		}
@@ -197,6 +291,14 @@ func (r *Repo) FindByFilter(ctx context.Context, filter ports.TaskFilter) ([]dom
 			continue
 		}
 
+		// Filtro por rango de fechas (ambos extremos incluidos)
+		if !filter.DueDateFrom.IsZero() && t.DueDate.Before(filter.DueDateFrom) {
+			continue
+		}
+		if !filter.DueDateTo.IsZero() && t.DueDate.After(filter.DueDateTo) {
+			continue
+		}
+
 		// Filtro por fecha vencida (isOverdue)
 		if filter.IsOverdue != nil && *filter.IsOverdue {
 			if t.DueDate.After(time.Now()) || t.Status == domain.StatusDone {
@@ -204,46 +306,33 @@ func (r *Repo) FindByFilter(ctx context.Context, filter ports.TaskFilter) ([]dom
 			}
 		}
 
-		filtered = append(filtered, t)
-	}
+		// Filtro por tareas próximas (24h)
+		if filter.IsDueSoon != nil && *filter.IsDueSoon {
+			now := time.Now()
+			if t.DueDate.Before(now) || t.DueDate.After(now.Add(24*time.Hour)) {
+				continue
+			}
+		}
 
-	// PAGINACIÓN
-	page := filter.Page
-	if page <= 0 {
-		page = 1
-	}
-	limit := filter.Limit
-	if limit <= 0 {
-		limit = 20
-	}
+		// Expresión de filtro (q)
+		if filter.Query != nil && !filter.Query.Matches(&t) {
+			continue
+		}
 
-	start := (page - 1) * limit
-	if start > len(filtered) {
-		start = len(filtered)
-	}
-	end := start + limit
-	if end > len(filtered) {
-		end = len(filtered)
+		filtered = append(filtered, t)
 	}
 
-	slice := filtered[start:end]
-
-	total := int64(len(filtered))
-	totalPages := (total + int64(limit) - 1) / int64(limit)
-	pi := domain.PageInfo{
-		Total:      total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
-		HasNext:    int64(page) < totalPages,
-		HasPrev:    page > 1,
+	if filter.Search != "" {
+		return search.Paginate(search.Rank(filtered, query), filter, time.Now())
 	}
 
-	return slice, pi, nil
+	// ORDENAMIENTO y PAGINACIÓN (page/limit o cursor) con desempate por ID:
+	// el mapa no garantiza orden y la paginación necesita resultados deterministas
+	return domain.PaginateInMemory(filtered, filter, time.Now())
 }
 
 // GetDashboardStats implementa el método del repositorio para memoria
-func (r *Repo) GetDashboardStats(ctx context.Context, userID string) (domain.DashboardData, error) {
+func (r *Repo) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -257,18 +346,22 @@ func (r *Repo) GetDashboardStats(ctx context.Context, userID string) (domain.Das
 		TodoCount:         0,
 	}
 
-	now := time.Now()
-	weekAgo := now.AddDate(0, 0, -7)
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	w := opts.Windows(time.Now())
+	now, startOfWeek, startOfDay, endOfDay := w.Now, w.StartOfWeek, w.StartOfDay, w.EndOfDay
 
-	// Recolectar tareas del usuario
+	// Recolectar tareas del usuario, por dueDate (las listas se recortan)
 	var userTasks []domain.Task
 	for _, t := range r.data {
 		if t.UserID == userID {
 			userTasks = append(userTasks, *t)
 		}
 	}
+	sort.Slice(userTasks, func(i, j int) bool {
+		if !userTasks[i].DueDate.Equal(userTasks[j].DueDate) {
+			return userTasks[i].DueDate.Before(userTasks[j].DueDate)
+		}
+		return userTasks[i].ID < userTasks[j].ID
+	})
 
 	// Procesar tareas
 	for _, t := range userTasks {
@@ -283,7 +376,7 @@ func (r *Repo) GetDashboardStats(ctx context.Context, userID string) (domain.Das
 
 		// Completadas esta semana
 		if t.Status == domain.StatusDone && t.CompletedAt != nil {
-			if t.CompletedAt.After(weekAgo) {
+			if !t.CompletedAt.Before(startOfWeek) {
 				result.CompletedThisWeek++
 			}
 		}
@@ -294,7 +387,7 @@ func (r *Repo) GetDashboardStats(ctx context.Context, userID string) (domain.Das
 		}
 
 		// Hoy
-		if t.DueDate.After(startOfDay) && t.DueDate.Before(endOfDay) {
+		if !t.DueDate.Before(startOfDay) && t.DueDate.Before(endOfDay) {
 			task := taskToDashboardTask(&t)
 			result.TodayTasks = append(result.TodayTasks, task)
 		}
@@ -306,9 +399,12 @@ func (r *Repo) GetDashboardStats(ctx context.Context, userID string) (domain.Das
 		}
 	}
 
-	// Limitar a 5 próximas
-	if len(result.UpcomingTasks) > 5 {
-		result.UpcomingTasks = result.UpcomingTasks[:5]
+	// Limitar según las preferencias del usuario
+	if len(result.UpcomingTasks) > w.UpcomingLimit {
+		result.UpcomingTasks = result.UpcomingTasks[:w.UpcomingLimit]
+	}
+	if w.TodayLimit > 0 && len(result.TodayTasks) > w.TodayLimit {
+		result.TodayTasks = result.TodayTasks[:w.TodayLimit]
 	}
 
 	return result, nil
@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"testing"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/infrastructure/persistence/repotest"
+)
+
+func TestRepoContract(t *testing.T) {
+	repotest.TaskRepository(t, func(t *testing.T) ports.TaskRepository { return NewRepo() })
+}
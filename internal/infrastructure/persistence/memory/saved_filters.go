@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// SavedFilterRepo implementa ports.SavedFilterRepository en memoria
+type SavedFilterRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.SavedFilter
+	seq  int64
+}
+
+func NewSavedFilterRepo() *SavedFilterRepo {
+	return &SavedFilterRepo{data: make(map[string]*domain.SavedFilter)}
+}
+
+func (r *SavedFilterRepo) Create(ctx context.Context, f *domain.SavedFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if f.ID == "" {
+		r.seq++
+		f.ID = "sf-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	cp := *f
+	r.data[f.ID] = &cp
+	return nil
+}
+
+func (r *SavedFilterRepo) GetByID(ctx context.Context, filterID, userID string) (*domain.SavedFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.data[filterID]
+	if !ok || f.UserID != userID {
+		return nil, domain.ErrSavedFilterNotFound
+	}
+	cp := *f
+	return &cp, nil
+}
+
+func (r *SavedFilterRepo) ListByUser(ctx context.Context, userID string) ([]domain.SavedFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.SavedFilter, 0)
+	for _, f := range r.data {
+		if f.UserID == userID {
+			out = append(out, *f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *SavedFilterRepo) Update(ctx context.Context, f *domain.SavedFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.data[f.ID]
+	if !ok || existing.UserID != f.UserID {
+		return domain.ErrSavedFilterNotFound
+	}
+	existing.Name = f.Name
+	existing.Params = f.Params
+	existing.UpdatedAt = f.UpdatedAt
+	return nil
+}
+
+func (r *SavedFilterRepo) Delete(ctx context.Context, filterID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.data[filterID]
+	if !ok || f.UserID != userID {
+		return domain.ErrSavedFilterNotFound
+	}
+	delete(r.data, filterID)
+	return nil
+}
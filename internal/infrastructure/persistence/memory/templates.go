@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// TemplateRepo implementa ports.TemplateRepository en memoria
+type TemplateRepo struct {
+	mu   sync.RWMutex
+	data map[string]*domain.TaskTemplate
+	seq  int64
+}
+
+func NewTemplateRepo() *TemplateRepo {
+	return &TemplateRepo{data: make(map[string]*domain.TaskTemplate)}
+}
+
+func (r *TemplateRepo) Create(ctx context.Context, t *domain.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t.ID == "" {
+		r.seq++
+		t.ID = "tpl-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+	}
+	r.data[t.ID] = cloneTemplate(t)
+	return nil
+}
+
+func (r *TemplateRepo) GetByID(ctx context.Context, templateID, userID string) (*domain.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.data[templateID]
+	if !ok || t.UserID != userID {
+		return nil, domain.ErrTemplateNotFound
+	}
+	return cloneTemplate(t), nil
+}
+
+func (r *TemplateRepo) ListByUser(ctx context.Context, userID string) ([]domain.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.TaskTemplate, 0)
+	for _, t := range r.data {
+		if t.UserID == userID {
+			out = append(out, *cloneTemplate(t))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *TemplateRepo) Update(ctx context.Context, t *domain.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.data[t.ID]
+	if !ok || existing.UserID != t.UserID {
+		return domain.ErrTemplateNotFound
+	}
+	cp := cloneTemplate(t)
+	cp.CreatedAt = existing.CreatedAt
+	r.data[t.ID] = cp
+	return nil
+}
+
+func (r *TemplateRepo) Delete(ctx context.Context, templateID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.data[templateID]
+	if !ok || t.UserID != userID {
+		return domain.ErrTemplateNotFound
+	}
+	delete(r.data, templateID)
+	return nil
+}
+
+// cloneTemplate copia la plantilla sin compartir slices con el llamador
+func cloneTemplate(t *domain.TaskTemplate) *domain.TaskTemplate {
+	cp := *t
+	cp.Tags = append([]string{}, t.Tags...)
+	cp.Items = append([]domain.TemplateItem{}, t.Items...)
+	return &cp
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// maxDeliveriesPerHook entregas que se conservan por webhook
+const maxDeliveriesPerHook = 200
+
+// WebhookRepo implementa ports.WebhookRepository en memoria
+type WebhookRepo struct {
+	mu         sync.RWMutex
+	hooks      map[string]*domain.Webhook
+	deliveries map[string][]domain.WebhookDelivery
+	seq        int64
+}
+
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{
+		hooks:      make(map[string]*domain.Webhook),
+		deliveries: make(map[string][]domain.WebhookDelivery),
+	}
+}
+
+func (r *WebhookRepo) nextID(prefix string) string {
+	r.seq++
+	return prefix + strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatInt(r.seq, 10)
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, hook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if hook.ID == "" {
+		hook.ID = r.nextID("wh-")
+	}
+	cp := *hook
+	r.hooks[hook.ID] = &cp
+	return nil
+}
+
+func (r *WebhookRepo) GetByID(ctx context.Context, hookID, userID string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	h, ok := r.hooks[hookID]
+	if !ok || h.UserID != userID {
+		return nil, domain.ErrWebhookNotFound
+	}
+	cp := *h
+	return &cp, nil
+}
+
+func (r *WebhookRepo) ListByUser(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return r.list(func(h *domain.Webhook) bool { return h.UserID == userID }), nil
+}
+
+func (r *WebhookRepo) ListActive(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return r.list(func(h *domain.Webhook) bool {
+		return h.Active && (h.UserID == userID || h.IsSystem())
+	}), nil
+}
+
+func (r *WebhookRepo) list(match func(*domain.Webhook) bool) []domain.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.Webhook, 0)
+	for _, h := range r.hooks {
+		if match(h) {
+			out = append(out, *h)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+func (r *WebhookRepo) Update(ctx context.Context, hook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hooks[hook.ID]
+	if !ok || h.UserID != hook.UserID {
+		return domain.ErrWebhookNotFound
+	}
+	cp := *hook
+	cp.Secret = h.Secret
+	cp.CreatedAt = h.CreatedAt
+	r.hooks[hook.ID] = &cp
+	return nil
+}
+
+func (r *WebhookRepo) Delete(ctx context.Context, hookID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hooks[hookID]
+	if !ok || h.UserID != userID {
+		return domain.ErrWebhookNotFound
+	}
+	delete(r.hooks, hookID)
+	delete(r.deliveries, hookID)
+	return nil
+}
+
+func (r *WebhookRepo) RecordFailure(ctx context.Context, hookID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hooks[hookID]
+	if !ok {
+		return 0, domain.ErrWebhookNotFound
+	}
+	h.FailureCount++
+	return h.FailureCount, nil
+}
+
+func (r *WebhookRepo) ResetFailures(ctx context.Context, hookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hooks[hookID]
+	if !ok {
+		return domain.ErrWebhookNotFound
+	}
+	h.FailureCount = 0
+	return nil
+}
+
+func (r *WebhookRepo) Disable(ctx context.Context, hookID, reason string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hooks[hookID]
+	if !ok {
+		return domain.ErrWebhookNotFound
+	}
+	h.Active = false
+	h.DisabledAt = &at
+	h.DisabledReason = reason
+	h.UpdatedAt = at
+	return nil
+}
+
+func (r *WebhookRepo) AddDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = r.nextID("whd-")
+	}
+	list := append(r.deliveries[d.WebhookID], *d)
+	if len(list) > maxDeliveriesPerHook {
+		list = list[len(list)-maxDeliveriesPerHook:]
+	}
+	r.deliveries[d.WebhookID] = list
+	return nil
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, hookID string, limit int) ([]domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := r.deliveries[hookID]
+	out := make([]domain.WebhookDelivery, 0, len(list))
+	// Más recientes primero
+	for i := len(list) - 1; i >= 0 && (limit <= 0 || len(out) < limit); i-- {
+		out = append(out, list[i])
+	}
+	return out, nil
+}
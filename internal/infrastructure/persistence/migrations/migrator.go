@@ -0,0 +1,230 @@
+// Package migrations aplica los cambios de esquema de Mongo (índices y
+// backfills de datos) en orden. Cada paso tiene una versión, se registra en
+// la colección schema_migrations al terminar y debe ser idempotente: si una
+// réplica se cae a la mitad, el paso se vuelve a ejecutar completo.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Colecciones del historial y del lock
+const (
+	HistoryCollection = "schema_migrations"
+	LockCollection    = "schema_migrations_lock"
+)
+
+const (
+	lockID = "lock"
+
+	// lockLease vencimiento del lock si la réplica que lo tiene muere; se
+	// renueva antes de cada paso
+	lockLease = 5 * time.Minute
+
+	// lockPoll espera entre intentos mientras otra réplica migra
+	lockPoll = 2 * time.Second
+)
+
+// ErrLockTimeout el lock sigue tomado por otra réplica al vencer el contexto
+var ErrLockTimeout = errors.New("migrations: lock tomado por otra instancia")
+
+// Migration paso versionado del esquema
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record migración aplicada (documento de schema_migrations)
+type Record struct {
+	Version     int       `bson:"_id" json:"version"`
+	Description string    `bson:"description" json:"description"`
+	AppliedAt   time.Time `bson:"appliedAt" json:"appliedAt"`
+	DurationMs  int64     `bson:"durationMs" json:"durationMs"`
+}
+
+// Status estado de una migración conocida
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time // nil si está pendiente
+}
+
+// Migrator ejecuta las migraciones pendientes con un lock entre réplicas
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+	owner      string
+}
+
+// NewMigrator crea un Migrator; las migraciones se ordenan por versión
+func NewMigrator(db *mongo.Database, migrations []Migration) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	if err := validate(sorted); err != nil {
+		return nil, err
+	}
+
+	host, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+		owner:      host + "-" + strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+	}, nil
+}
+
+// validate exige versiones positivas, únicas y con función Up
+func validate(sorted []Migration) error {
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return fmt.Errorf("migrations: versión inválida %d", m.Version)
+		}
+		if m.Up == nil {
+			return fmt.Errorf("migrations: la versión %d no tiene Up", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return fmt.Errorf("migrations: versión repetida %d", m.Version)
+		}
+	}
+	return nil
+}
+
+// Run aplica las migraciones pendientes en orden. Si otra réplica tiene el
+// lock espera a que termine (hasta que venza ctx) y luego solo aplica lo
+// que siga pendiente. Devuelve las versiones aplicadas.
+func (m *Migrator) Run(ctx context.Context) ([]int, error) {
+	if err := m.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.release()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if err := m.renew(ctx); err != nil {
+			return done, err
+		}
+
+		log.Printf("Aplicando migración %d: %s", mig.Version, mig.Description)
+		start := time.Now()
+		if err := mig.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migración %d (%s): %w", mig.Version, mig.Description, err)
+		}
+
+		rec := Record{
+			Version:     mig.Version,
+			Description: mig.Description,
+			AppliedAt:   time.Now().UTC(),
+			DurationMs:  time.Since(start).Milliseconds(),
+		}
+		if _, err := m.db.Collection(HistoryCollection).InsertOne(ctx, rec); err != nil {
+			return done, fmt.Errorf("registrar migración %d: %w", mig.Version, err)
+		}
+		done = append(done, mig.Version)
+	}
+	return done, nil
+}
+
+// Status estado de todas las migraciones conocidas
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		out[i] = Status{Version: mig.Version, Description: mig.Description}
+		if rec, ok := applied[mig.Version]; ok {
+			at := rec.AppliedAt
+			out[i].AppliedAt = &at
+		}
+	}
+	return out, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	cursor, err := m.db.Collection(HistoryCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("leer %s: %w", HistoryCollection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("leer %s: %w", HistoryCollection, err)
+	}
+	out := make(map[int]Record, len(records))
+	for _, r := range records {
+		out[r.Version] = r
+	}
+	return out, nil
+}
+
+// acquire toma el lock: lo crea si no existe o lo reemplaza si venció.
+// Reintenta mientras otra réplica lo tenga.
+func (m *Migrator) acquire(ctx context.Context) error {
+	locks := m.db.Collection(LockCollection)
+	for {
+		now := time.Now().UTC()
+		filter := bson.M{"_id": lockID, "$or": bson.A{
+			bson.M{"owner": m.owner},
+			bson.M{"expiresAt": bson.M{"$lt": now}},
+		}}
+		update := bson.M{"$set": bson.M{"owner": m.owner, "lockedAt": now, "expiresAt": now.Add(lockLease)}}
+		_, err := locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return nil
+		}
+		// El upsert choca con el _id existente cuando el lock está vigente
+		if !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("tomar lock de migraciones: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrLockTimeout
+		case <-time.After(lockPoll):
+		}
+	}
+}
+
+// renew extiende el lock antes de cada paso
+func (m *Migrator) renew(ctx context.Context) error {
+	res, err := m.db.Collection(LockCollection).UpdateOne(ctx,
+		bson.M{"_id": lockID, "owner": m.owner},
+		bson.M{"$set": bson.M{"expiresAt": time.Now().UTC().Add(lockLease)}})
+	if err != nil {
+		return fmt.Errorf("renovar lock de migraciones: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("migrations: se perdió el lock (venció durante un paso)")
+	}
+	return nil
+}
+
+// release suelta el lock (solo si sigue siendo propio)
+func (m *Migrator) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := m.db.Collection(LockCollection).DeleteOne(ctx, bson.M{"_id": lockID, "owner": m.owner}); err != nil {
+		log.Printf("⚠️ No se pudo liberar el lock de migraciones: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAllMigrationsAreValid(t *testing.T) {
+	all := All()
+	if err := validate(all); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Version <= all[i-1].Version {
+			t.Errorf("versions must be listed in ascending order: %d after %d", all[i].Version, all[i-1].Version)
+		}
+	}
+}
+
+func TestNewMigratorRejectsInvalidSets(t *testing.T) {
+	up := func(context.Context, *mongo.Database) error { return nil }
+	cases := map[string][]Migration{
+		"duplicate": {{Version: 1, Up: up}, {Version: 1, Up: up}},
+		"zero":      {{Version: 0, Up: up}},
+		"no up":     {{Version: 1}},
+	}
+	for name, set := range cases {
+		if _, err := NewMigrator(nil, set); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	m, err := NewMigrator(nil, []Migration{{Version: 2, Up: up}, {Version: 1, Up: up}})
+	if err != nil || m.migrations[0].Version != 1 {
+		t.Errorf("migrations should be sorted by version: %v %v", m, err)
+	}
+}
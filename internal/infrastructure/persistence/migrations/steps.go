@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// taskTextIndex nombre del índice de texto de tareas (solo puede haber uno
+// por colección)
+const taskTextIndex = "task_search"
+
+// All migraciones de la API, en orden. Las versiones nunca se reutilizan ni
+// se editan una vez publicadas: un cambio nuevo es una versión nueva.
+func All() []Migration {
+	return []Migration{
+		{Version: 1, Description: "índices de tareas", Up: taskIndexes},
+		{Version: 2, Description: "índice de texto de tareas (title, description, tags)", Up: taskTextIndexUp},
+		{Version: 3, Description: "índices de webhooks y entregas", Up: webhookIndexes},
+		{Version: 4, Description: "índices de tokens de calendario, eventos y filtros guardados", Up: miscIndexes},
+		{Version: 5, Description: "tags, groupMembers y attachments nulos o ausentes como arrays vacíos", Up: normalizeTaskArrays},
+		{Version: 6, Description: "índices de tareas compartidas e invitaciones", Up: sharingIndexes},
+		{Version: 7, Description: "índices del hilo de comentarios y actividad", Up: activityIndexes},
+		{Version: 8, Description: "índices de adjuntos", Up: attachmentIndexes},
+		{Version: 9, Description: "índice de plantillas de tareas", Up: templateIndexes},
+		{Version: 10, Description: "referencias a los contenidos de adjuntos existentes", Up: attachmentBlobRefs},
+	}
+}
+
+func taskIndexes(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db.Collection("tasks"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "dueDate", Value: 1}}},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "status", Value: 1}, {Key: "dueDate", Value: 1}}},
+		// Import idempotente (GetByExternalID)
+		{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "externalId", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"externalId": bson.M{"$exists": true}}),
+		},
+	})
+}
+
+// taskTextIndexUp reemplaza cualquier índice de texto anterior (el que
+// creaba el antiguo mongoSetup.js solo cubría title y description)
+func taskTextIndexUp(ctx context.Context, db *mongo.Database) error {
+	tasks := db.Collection("tasks")
+	if err := dropTextIndexesExcept(ctx, tasks, taskTextIndex); err != nil {
+		return err
+	}
+	return createIndexes(ctx, tasks, []mongo.IndexModel{{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "tags", Value: "text"}},
+		Options: options.Index().
+			SetName(taskTextIndex).
+			SetWeights(bson.M{"title": 3, "tags": 2, "description": 1}).
+			SetDefaultLanguage("spanish"),
+	}})
+}
+
+func webhookIndexes(ctx context.Context, db *mongo.Database) error {
+	if err := createIndexes(ctx, db.Collection("webhooks"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "active", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+	// Historial de entregas: se conserva 30 días
+	return createIndexes(ctx, db.Collection("webhook_deliveries"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "webhookId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(60 * 60 * 24 * 30)},
+	})
+}
+
+func miscIndexes(ctx context.Context, db *mongo.Database) error {
+	if err := createIndexes(ctx, db.Collection("calendar_tokens"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+	// Eventos en tiempo real entre réplicas (EVENTS_BROADCASTER=mongo): se conservan 1 día
+	if err := createIndexes(ctx, db.Collection("task_events"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "occurredAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(60 * 60 * 24)},
+	}); err != nil {
+		return err
+	}
+	return createIndexes(ctx, db.Collection("saved_filters"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: 1}}},
+	})
+}
+
+// normalizeTaskArrays los slices nil de Go se guardan como null; los
+// clientes esperan arrays
+func normalizeTaskArrays(ctx context.Context, db *mongo.Database) error {
+	tasks := db.Collection("tasks")
+	for _, field := range []string{"tags", "groupMembers", "attachments"} {
+		// {field: null} también encuentra los documentos sin el campo
+		res, err := tasks.UpdateMany(ctx, bson.M{field: nil}, bson.M{"$set": bson.M{field: bson.A{}}})
+		if err != nil {
+			return fmt.Errorf("normalizar %s: %w", field, err)
+		}
+		if res.ModifiedCount > 0 {
+			log.Printf("  %s: %d tareas normalizadas", field, res.ModifiedCount)
+		}
+	}
+	return nil
+}
+
+// sharingIndexes copias de colaboradores (ListShared) e invitaciones por
+// email, por tarea y por destinatario
+func sharingIndexes(ctx context.Context, db *mongo.Database) error {
+	if err := createIndexes(ctx, db.Collection("tasks"), []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "ownerId", Value: 1}, {Key: "sharedTaskId", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"sharedTaskId": bson.M{"$exists": true}}),
+	}}); err != nil {
+		return err
+	}
+	return createIndexes(ctx, db.Collection("task_invitations"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "taskId", Value: 1}}},
+		{Keys: bson.D{{Key: "email", Value: 1}, {Key: "createdAt", Value: 1}}},
+	})
+}
+
+func activityIndexes(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db.Collection("task_activity"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "taskId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "taskId", Value: 1}, {Key: "kind", Value: 1}, {Key: "createdAt", Value: -1}}},
+	})
+}
+
+func attachmentIndexes(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db.Collection("task_attachments"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "taskId", Value: 1}, {Key: "checksum", Value: 1}}},
+		{Keys: bson.D{{Key: "checksum", Value: 1}}},
+	})
+}
+
+// attachmentBlobRefs cuenta las referencias de los adjuntos ya subidos; los
+// contenidos que ya tienen documento no se tocan
+func attachmentBlobRefs(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("task_attachments").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$checksum"},
+			{Key: "key", Value: bson.D{{Key: "$first", Value: "$blobKey"}}},
+			{Key: "refs", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$merge", Value: bson.D{
+			{Key: "into", Value: "attachment_blobs"},
+			{Key: "whenMatched", Value: "keepExisting"},
+		}}},
+	})
+	if err != nil {
+		return fmt.Errorf("contar referencias de adjuntos: %w", err)
+	}
+	return cursor.Close(ctx)
+}
+
+func templateIndexes(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db.Collection("task_templates"), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: 1}}},
+	})
+}
+
+// createIndexes crea los índices; si ya existen con la misma definición
+// Mongo no hace nada, así que el paso es idempotente
+func createIndexes(ctx context.Context, coll *mongo.Collection, models []mongo.IndexModel) error {
+	if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("crear índices de %s: %w", coll.Name(), err)
+	}
+	return nil
+}
+
+// dropTextIndexesExcept elimina los índices de texto con otro nombre
+func dropTextIndexesExcept(ctx context.Context, coll *mongo.Collection, keep string) error {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("listar índices de %s: %w", coll.Name(), err)
+	}
+	var specs []struct {
+		Name string   `bson:"name"`
+		Key  bson.Raw `bson:"key"`
+	}
+	if err := cursor.All(ctx, &specs); err != nil {
+		return fmt.Errorf("listar índices de %s: %w", coll.Name(), err)
+	}
+	for _, spec := range specs {
+		// Los índices de texto guardan la clave interna _fts
+		if _, err := spec.Key.LookupErr("_fts"); err != nil || spec.Name == keep {
+			continue
+		}
+		if _, err := coll.Indexes().DropOne(ctx, spec.Name); err != nil {
+			return fmt.Errorf("eliminar índice %s: %w", spec.Name, err)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 	"uniflow-api/internal/application/ports"
 	"uniflow-api/internal/domain"
@@ -16,6 +17,10 @@ import (
 // MongoTaskRepository implementa TaskRepository usando MongoDB
 type MongoTaskRepository struct {
 	collection *mongo.Collection
+
+	// textIndexMissing se activa la primera vez que $text falla por falta
+	// del índice de texto; desde entonces la búsqueda usa regex
+	textIndexMissing atomic.Bool
 }
 
 // NewMongoTaskRepository crea una nueva instancia de MongoTaskRepository
@@ -60,6 +65,25 @@ func (r *MongoTaskRepository) GetByID(ctx context.Context, taskID, userID string
 	return &task, nil
 }
 
+// GetByExternalID obtiene una tarea importada por su ID de origen
+func (r *MongoTaskRepository) GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error) {
+	filter := bson.M{
+		"userId":     userID,
+		"externalId": externalID,
+	}
+
+	var task domain.Task
+	err := r.collection.FindOne(ctx, filter).Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+
+	return &task, nil
+}
+
 // GetAll obtiene todas las tareas de un usuario
 func (r *MongoTaskRepository) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
 	// Filtro: solo tareas del usuario actual
@@ -141,8 +165,7 @@ func (r *MongoTaskRepository) FindByFilter(ctx context.Context, filter ports.Tas
 
 	// Filtro por tareas próximas (24h)
 	if filter.IsDueSoon != nil && *filter.IsDueSoon {
-		loc, _ := time.LoadLocation(filter.TimeZone)
-		now := time.Now().In(loc)
+		now := time.Now()
 		in24h := now.Add(24 * time.Hour)
 
 		mongoFilter["dueDate"] = bson.M{
@@ -151,46 +174,78 @@ func (r *MongoTaskRepository) FindByFilter(ctx context.Context, filter ports.Tas
 		}
 	}
 
-	// Filtro por búsqueda de texto
+	// Expresión de filtro (q); $and no se usa en otros filtros de arriba
+	if filter.Query != nil {
+		mongoFilter["$and"] = bson.A{queryToMongo(filter.Query)}
+	}
+
+	// Búsqueda de texto: Mongo trae los candidatos y la relevancia se calcula
+	// en Go (tildes, prefijos, errores de tipeo)
 	if filter.Search != "" {
-		mongoFilter["$text"] = bson.M{"$search": filter.Search}
+		return r.findBySearch(ctx, mongoFilter, filter)
 	}
 
-	// Contar total
-	total, err := r.collection.CountDocuments(ctx, mongoFilter)
-	if err != nil {
-		return nil, domain.PageInfo{}, err
+	fields := filter.SortFields()
+	before := filter.Before != ""
+	var cur *domain.Cursor
+	if filter.IsCursorMode() {
+		token := filter.After
+		if before {
+			token = filter.Before
+		}
+		var err error
+		if cur, err = domain.DecodeCursor(token, fields); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
 	}
 
-	// Ordenamiento
-	opts := options.Find()
-	sortField := "dueDate"
-	if filter.SortBy == "priority" {
-		sortField = "priority"
-	} else if filter.SortBy == "status" {
-		sortField = "status"
-	} else if filter.SortBy == "createdAt" {
-		sortField = "createdAt"
+	// El score de urgencia depende del instante: el del cursor si lo trae
+	now := time.Now()
+	if filter.SortsByScore() {
+		now = domain.ScoreTime(cur, now)
 	}
 
-	sortOrder := 1 // asc
-	if filter.SortOrder == "desc" {
-		sortOrder = -1
+	// Contar total (opcional: en colecciones grandes es la parte más costosa)
+	total := int64(-1)
+	if !filter.SkipCount {
+		var err error
+		if total, err = r.collection.CountDocuments(ctx, mongoFilter); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
 	}
-	opts.SetSort(bson.M{sortField: sortOrder})
 
-	// Paginación
-	skip := int64((filter.Page - 1) * filter.Limit)
-	opts.SetSkip(skip)
-	opts.SetLimit(int64(filter.Limit))
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+	skip := 0
+	if cur == nil && filter.Page > 1 {
+		skip = (filter.Page - 1) * limit
+	}
 
-	// Si hay búsqueda de texto, agregar score
-	if filter.Search != "" {
-		opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
-		opts.SetSort(bson.M{"score": bson.M{"$meta": "textScore"}})
+	// Pipeline: filtro, claves de orden calculadas (rangos de prioridad y
+	// estado, score de urgencia), keyset si hay cursor, orden con _id como
+	// desempate y una fila extra para saber si hay más. Con before se lee en
+	// sentido inverso y BuildPage restaura el orden.
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: mongoFilter}}}
+	if add := sortKeyFields(fields, now); len(add) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: add}})
+	}
+	if cur != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: keysetFilter(fields, cur, before)}})
+	}
+	sortDoc := bson.D{}
+	for _, f := range fields {
+		sortDoc = append(sortDoc, bson.E{Key: mongoSortField(f.Field), Value: sortDirection(f.Desc, before)})
 	}
+	sortDoc = append(sortDoc, bson.E{Key: "_id", Value: sortDirection(fields[len(fields)-1].Desc, before)})
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortDoc}})
+	if skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit + 1}})
 
-	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, domain.PageInfo{}, err
 	}
@@ -201,22 +256,170 @@ func (r *MongoTaskRepository) FindByFilter(ctx context.Context, filter ports.Tas
 		return nil, domain.PageInfo{}, err
 	}
 
-	if tasks == nil {
-		tasks = []domain.Task{}
+	tasks, pageInfo := domain.BuildPage(tasks, filter, total, now)
+	return tasks, pageInfo, nil
+}
+
+// Campos calculados para ordenar (no se persisten)
+const (
+	priorityRankField = "_priorityRank"
+	statusRankField   = "_statusRank"
+	completedAtField  = "_completedAtKey"
+	scoreField        = "_score"
+)
+
+// mongoSortField campo del documento (o calculado) para un campo de orden
+func mongoSortField(field string) string {
+	switch field {
+	case "priority":
+		return priorityRankField
+	case "status":
+		return statusRankField
+	case "completedAt":
+		return completedAtField
+	case "score":
+		return scoreField
+	case "createdAt", "updatedAt", "title":
+		return field
+	default:
+		return "dueDate"
 	}
+}
 
-	// Calcular paginación
-	totalPages := (total + int64(filter.Limit) - 1) / int64(filter.Limit)
-	pageInfo := domain.PageInfo{
-		Total:      total,
-		Page:       filter.Page,
-		Limit:      filter.Limit,
-		TotalPages: totalPages,
-		HasNext:    int64(filter.Page) < totalPages,
-		HasPrev:    filter.Page > 1,
+// sortKeyFields expresiones $addFields con el mismo orden que domain.SortValueOf:
+// rango 1..n según ValidPriorities/ValidStatuses (0 si es desconocido),
+// completedAt ausente como época 0 y el score de urgencia en el instante now
+func sortKeyFields(fields []domain.SortField, now time.Time) bson.M {
+	add := bson.M{}
+	for _, f := range fields {
+		switch f.Field {
+		case "priority":
+			add[priorityRankField] = bson.M{"$add": bson.A{bson.M{"$indexOfArray": bson.A{domain.ValidPriorities, "$priority"}}, 1}}
+		case "status":
+			add[statusRankField] = bson.M{"$add": bson.A{bson.M{"$indexOfArray": bson.A{domain.ValidStatuses, "$status"}}, 1}}
+		case "completedAt":
+			add[completedAtField] = bson.M{"$ifNull": bson.A{"$completedAt", time.UnixMilli(0).UTC()}}
+		case "score":
+			add[scoreField] = scoreExpr(now)
+		}
 	}
+	return add
+}
 
-	return tasks, pageInfo, nil
+// scoreExpr domain.ComputeScore como expresión de agregación, con las
+// operaciones en el mismo orden para que el valor coincida con el del cursor
+// (calculado en Go): horas restantes como Duration.Hours, suma de cada
+// valor*peso*100 y redondeo a dos decimales
+func scoreExpr(now time.Time) bson.M {
+	ms := bson.M{"$subtract": bson.A{"$dueDate", now}}
+	hoursLeft := bson.M{"$add": bson.A{
+		bson.M{"$trunc": bson.M{"$divide": bson.A{ms, 3600000}}},
+		bson.M{"$divide": bson.A{bson.M{"$mod": bson.A{ms, 3600000}}, 3600000}},
+	}}
+	overdue := bson.M{"$lte": bson.A{"$$hoursLeft", 0}}
+	remaining := bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{
+		bson.M{"$ifNull": bson.A{"$estimatedTimeHours", 0}},
+		bson.M{"$ifNull": bson.A{"$actualTimeHours", 0}},
+	}}}}
+
+	due := bson.M{"$cond": bson.A{overdue, 1, bson.M{"$exp": bson.M{"$divide": bson.A{
+		bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{"$$hoursLeft", 24}}, -1}}, domain.ScoreDueDecayDays,
+	}}}}}
+	effort := bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$eq": bson.A{"$$remaining", 0}}, "then": 0},
+			bson.M{"case": overdue, "then": 1},
+		},
+		"default": bson.M{"$min": bson.A{bson.M{"$divide": bson.A{
+			"$$remaining", bson.M{"$divide": bson.A{"$$hoursLeft", domain.ScoreEffortShare}},
+		}}, 1}},
+	}}
+	grade := bson.M{"$cond": bson.A{
+		bson.M{"$lte": bson.A{"$gradeWeight", 0}}, 0,
+		bson.M{"$min": bson.A{bson.M{"$divide": bson.A{"$gradeWeight", domain.ScoreGradeCap}}, 1}},
+	}}
+
+	points := func(value interface{}, weight float64) bson.M {
+		return bson.M{"$multiply": bson.A{value, weight, 100}}
+	}
+	total := bson.M{"$add": bson.A{
+		points(due, domain.ScoreWeightDue),
+		points(scoreTable("$priority", domain.ValidPriorities, domain.PriorityScore), domain.ScoreWeightPriority),
+		points(scoreTable("$type", domain.ValidTypes, domain.TypeScore), domain.ScoreWeightType),
+		points(effort, domain.ScoreWeightEffort),
+		points(grade, domain.ScoreWeightGrade),
+	}}
+	total = bson.M{"$cond": bson.A{"$isBlocked", bson.M{"$multiply": bson.A{total, domain.ScoreBlockedFactor}}, total}}
+	rounded := bson.M{"$divide": bson.A{bson.M{"$floor": bson.M{"$add": bson.A{bson.M{"$multiply": bson.A{total, 100}}, 0.5}}}, 100}}
+
+	return bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{"$status", bson.A{domain.StatusDone, domain.StatusCancelled}}},
+		0,
+		bson.M{"$let": bson.M{
+			"vars": bson.M{"hoursLeft": hoursLeft, "remaining": remaining},
+			"in":   rounded,
+		}},
+	}}
+}
+
+// scoreTable valor relativo de field según value (0 si no está en values)
+func scoreTable(field string, values []string, value func(string) float64) bson.M {
+	branches := bson.A{}
+	for _, v := range values {
+		branches = append(branches, bson.M{"case": bson.M{"$eq": bson.A{field, v}}, "then": value(v)})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": 0}}
+}
+
+// sortDirection 1 asc / -1 desc, invertido al leer hacia atrás
+func sortDirection(desc, reverse bool) int {
+	if desc != reverse {
+		return -1
+	}
+	return 1
+}
+
+// mongoSortValue valor del cursor con el tipo almacenado en Mongo
+func mongoSortValue(field string, v domain.SortValue) interface{} {
+	switch field {
+	case "priority", "status":
+		return int(v.Num)
+	case "score":
+		return v.Num
+	case "title":
+		return v.Str
+	default:
+		return time.UnixMilli(int64(v.Num)).UTC()
+	}
+}
+
+// keysetFilter condición "posterior al cursor" (anterior si before):
+// (f1 > v1) OR (f1 = v1 AND f2 > v2) OR ... OR (todos iguales AND _id > id)
+func keysetFilter(fields []domain.SortField, cur *domain.Cursor, before bool) bson.M {
+	ors := bson.A{}
+	eq := bson.M{}
+	for i, f := range fields {
+		key := mongoSortField(f.Field)
+		val := mongoSortValue(f.Field, cur.Values[i])
+		cond := bson.M{key: bson.M{keysetOp(f.Desc, before): val}}
+		for k, v := range eq {
+			cond[k] = v
+		}
+		ors = append(ors, cond)
+		eq[key] = val
+	}
+	last := bson.M{"_id": bson.M{keysetOp(fields[len(fields)-1].Desc, before): cur.ID}}
+	for k, v := range eq {
+		last[k] = v
+	}
+	return bson.M{"$or": append(ors, last)}
+}
+
+func keysetOp(desc, reverse bool) string {
+	if desc != reverse {
+		return "$lt"
+	}
+	return "$gt"
 }
 
 // GetByUserAndStatus obtiene tareas filtradas por usuario y estado
@@ -260,6 +463,23 @@ func (r *MongoTaskRepository) Update(ctx context.Context, task *domain.Task) err
 		return fmt.Errorf("no se puede actualizar: %w", err)
 	}
 
+	return r.replace(ctx, task)
+}
+
+// UpdateStatus persiste un cambio de estado; completar, cancelar o reabrir
+// no pasa por CanBeModified
+func (r *MongoTaskRepository) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	return r.replace(ctx, task)
+}
+
+// UpdateShared reemplaza una tarea compartida sin las reglas de estado
+// (sincroniza copias completadas o canceladas)
+func (r *MongoTaskRepository) UpdateShared(ctx context.Context, task *domain.Task) error {
+	return r.replace(ctx, task)
+}
+
+// replace reemplaza el documento si pertenece al usuario
+func (r *MongoTaskRepository) replace(ctx context.Context, task *domain.Task) error {
 	// Filtro: asegurarse que pertenece al usuario (usando string ID)
 	filter := bson.M{
 		"_id":    task.ID,
@@ -291,6 +511,16 @@ func (r *MongoTaskRepository) Delete(ctx context.Context, taskID, userID string)
 		return err
 	}
 
+	return r.deleteOne(ctx, taskID, userID)
+}
+
+// DeleteShared elimina una tarea compartida aunque esté completada
+func (r *MongoTaskRepository) DeleteShared(ctx context.Context, taskID, userID string) error {
+	return r.deleteOne(ctx, taskID, userID)
+}
+
+// deleteOne elimina el documento si pertenece al usuario
+func (r *MongoTaskRepository) deleteOne(ctx context.Context, taskID, userID string) error {
 	filter := bson.M{
 		"_id":    taskID,
 		"userId": userID,
@@ -308,147 +538,177 @@ func (r *MongoTaskRepository) Delete(ctx context.Context, taskID, userID string)
 	return nil
 }
 
+// ListShared copias de los colaboradores de una tarea (índice
+// ownerId+sharedTaskId)
+func (r *MongoTaskRepository) ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error) {
+	filter := bson.M{
+		"ownerId":      ownerID,
+		"sharedTaskId": taskID,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar colaboradores: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []domain.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("error al decodificar colaboradores: %w", err)
+	}
+
+	return tasks, nil
+}
+
 // Métodos para Fase 3 (stubs por ahora)
 func (r *MongoTaskRepository) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
 	return []domain.Task{}, domain.PageInfo{}, nil
 }
 
+// DueToday tareas que vencen hoy en la zona horaria indicada (índice
+// userId+dueDate)
 func (r *MongoTaskRepository) DueToday(ctx context.Context, userID string, loc *time.Location) ([]domain.Task, error) {
-	return []domain.Task{}, nil
+	start, end := domain.DayRange(time.Now(), loc)
+	filter := bson.M{
+		"userId":  userID,
+		"dueDate": bson.M{"$gte": start, "$lt": end},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "dueDate", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tareas de hoy: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []domain.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("error al decodificar tareas de hoy: %w", err)
+	}
+	return tasks, nil
 }
 
+// Search búsqueda de texto; equivale a FindByFilter con Search
 func (r *MongoTaskRepository) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
-	return []domain.Task{}, domain.PageInfo{}, nil
+	return r.FindByFilter(ctx, f)
 }
 
 func (r *MongoTaskRepository) Aggregated(ctx context.Context, userID string, until time.Time) (domain.Stats, error) {
 	return domain.Stats{}, nil
 }
 
-// GetDashboardStats retorna estadísticas agregadas para el dashboard
-func (r *MongoTaskRepository) GetDashboardStats(ctx context.Context, userID string) (domain.DashboardData, error) {
+// GetDashboardStats obtiene próximas, de hoy y conteos del dashboard en un
+// solo viaje a la base (ver dashboardPipeline)
+func (r *MongoTaskRepository) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
 	result := domain.DashboardData{
-		UpcomingTasks:     make([]domain.DashboardTask, 0),
-		TodayTasks:        make([]domain.DashboardTask, 0),
-		OverdueCount:      0,
-		TotalPending:      0,
-		CompletedThisWeek: 0,
-		InProgressCount:   0,
-		TodoCount:         0,
-	}
-
-	now := time.Now()
-	weekAgo := now.AddDate(0, 0, -7)
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
-
-	// ===== TAREAS PRÓXIMAS (Upcoming) =====
-	upcomingFilter := bson.M{
-		"userId":  userID,
-		"dueDate": bson.M{"$gt": now},
-		"status":  bson.M{"$ne": domain.StatusDone},
+		UpcomingTasks: make([]domain.DashboardTask, 0),
+		TodayTasks:    make([]domain.DashboardTask, 0),
 	}
-	opts := options.Find()
-	opts.SetSort(bson.M{"dueDate": 1})
-	opts.SetLimit(5)
 
-	cursor, err := r.collection.Find(ctx, upcomingFilter, opts)
+	cursor, err := r.collection.Aggregate(ctx, dashboardPipeline(userID, opts.Windows(time.Now())))
 	if err != nil {
-		return result, fmt.Errorf("error al obtener tareas próximas: %w", err)
+		return result, fmt.Errorf("error al obtener dashboard: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var upcomingTasks []domain.Task
-	if err = cursor.All(ctx, &upcomingTasks); err != nil {
-		return result, fmt.Errorf("error al decodificar tareas próximas: %w", err)
+	var facets []dashboardFacets
+	if err := cursor.All(ctx, &facets); err != nil {
+		return result, fmt.Errorf("error al decodificar dashboard: %w", err)
 	}
-
-	for _, t := range upcomingTasks {
-		result.UpcomingTasks = append(result.UpcomingTasks, taskToDashboardTaskMongo(&t))
-	}
-
-	// ===== TAREAS HOY =====
-	todayFilter := bson.M{
-		"userId": userID,
-		"dueDate": bson.M{
-			"$gte": startOfDay,
-			"$lt":  endOfDay,
-		},
-	}
-	cursor, err = r.collection.Find(ctx, todayFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al obtener tareas de hoy: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	var todayTasks []domain.Task
-	if err = cursor.All(ctx, &todayTasks); err != nil {
-		return result, fmt.Errorf("error al decodificar tareas de hoy: %w", err)
-	}
-
-	for _, t := range todayTasks {
-		result.TodayTasks = append(result.TodayTasks, taskToDashboardTaskMongo(&t))
-	}
-
-	// ===== CONTEOS AGREGADOS =====
-	// Vencidas
-	overdueFilter := bson.M{
-		"userId":  userID,
-		"dueDate": bson.M{"$lt": now},
-		"status":  bson.M{"$ne": domain.StatusDone},
-	}
-	overdueCount, err := r.collection.CountDocuments(ctx, overdueFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al contar vencidas: %w", err)
+	if len(facets) == 0 {
+		return result, nil
 	}
-	result.OverdueCount = int(overdueCount)
+	return facets[0].toDashboard(), nil
+}
 
-	// Pendientes (todo + in-progress)
-	pendingFilter := bson.M{
-		"userId": userID,
-		"status": bson.M{"$in": []string{domain.StatusTodo, domain.StatusInProgress}},
-	}
-	pendingCount, err := r.collection.CountDocuments(ctx, pendingFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al contar pendientes: %w", err)
-	}
-	result.TotalPending = int(pendingCount)
+// dashboardFacets documento único que devuelve dashboardPipeline
+type dashboardFacets struct {
+	Upcoming []domain.Task `bson:"upcoming"`
+	Today    []domain.Task `bson:"today"`
+	Counts   []struct {
+		Overdue           int `bson:"overdue"`
+		Pending           int `bson:"pending"`
+		CompletedThisWeek int `bson:"completedThisWeek"`
+		InProgress        int `bson:"inProgress"`
+		Todo              int `bson:"todo"`
+	} `bson:"counts"` // vacío si el usuario no tiene tareas
+}
 
-	// Completadas esta semana
-	completedWeekFilter := bson.M{
-		"userId":      userID,
-		"status":      domain.StatusDone,
-		"completedAt": bson.M{"$gte": weekAgo},
+func (f dashboardFacets) toDashboard() domain.DashboardData {
+	result := domain.DashboardData{
+		UpcomingTasks: make([]domain.DashboardTask, 0, len(f.Upcoming)),
+		TodayTasks:    make([]domain.DashboardTask, 0, len(f.Today)),
 	}
-	completedWeekCount, err := r.collection.CountDocuments(ctx, completedWeekFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al contar completadas esta semana: %w", err)
+	for i := range f.Upcoming {
+		result.UpcomingTasks = append(result.UpcomingTasks, taskToDashboardTaskMongo(&f.Upcoming[i]))
 	}
-	result.CompletedThisWeek = int(completedWeekCount)
-
-	// In-progress
-	inProgressFilter := bson.M{
-		"userId": userID,
-		"status": domain.StatusInProgress,
+	for i := range f.Today {
+		result.TodayTasks = append(result.TodayTasks, taskToDashboardTaskMongo(&f.Today[i]))
 	}
-	inProgressCount, err := r.collection.CountDocuments(ctx, inProgressFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al contar in-progress: %w", err)
+	if len(f.Counts) > 0 {
+		c := f.Counts[0]
+		result.OverdueCount = c.Overdue
+		result.TotalPending = c.Pending
+		result.CompletedThisWeek = c.CompletedThisWeek
+		result.InProgressCount = c.InProgress
+		result.TodoCount = c.Todo
 	}
-	result.InProgressCount = int(inProgressCount)
+	return result
+}
 
-	// Todo
-	todoFilter := bson.M{
-		"userId": userID,
-		"status": domain.StatusTodo,
-	}
-	todoCount, err := r.collection.CountDocuments(ctx, todoFilter)
-	if err != nil {
-		return result, fmt.Errorf("error al contar todo: %w", err)
+// dashboardPipeline un $match por usuario (índice userId+dueDate) y un
+// $facet con las tres secciones del dashboard:
+//   - upcoming: no completadas que vencen después de now (las UpcomingLimit primeras)
+//   - today: vencen hoy (día calendario en la zona del usuario)
+//   - counts: vencidas, pendientes, completadas desde el inicio de la semana,
+//     en progreso y por hacer, con un solo $group
+func dashboardPipeline(userID string, w domain.DashboardWindows) mongo.Pipeline {
+	now, startOfWeek, startOfDay, endOfDay := w.Now, w.StartOfWeek, w.StartOfDay, w.EndOfDay
+
+	countIf := func(cond bson.M) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{cond, 1, 0}}}
+	}
+	statusIs := func(status string) bson.M {
+		return bson.M{"$eq": bson.A{"$status", status}}
+	}
+	notDone := bson.M{"$ne": bson.A{"$status", domain.StatusDone}}
+	byDueDate := bson.D{{Key: "$sort", Value: bson.D{{Key: "dueDate", Value: 1}, {Key: "_id", Value: 1}}}}
+	today := bson.A{
+		bson.D{{Key: "$match", Value: bson.M{"dueDate": bson.M{"$gte": startOfDay, "$lt": endOfDay}}}},
+		byDueDate,
+	}
+	if w.TodayLimit > 0 {
+		today = append(today, bson.D{{Key: "$limit", Value: w.TodayLimit}})
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"userId": userID}}},
+		{{Key: "$facet", Value: bson.M{
+			"upcoming": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{
+					"dueDate": bson.M{"$gt": now},
+					"status":  bson.M{"$ne": domain.StatusDone},
+				}}},
+				byDueDate,
+				bson.D{{Key: "$limit", Value: w.UpcomingLimit}},
+			},
+			"today": today,
+			"counts": bson.A{
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id":     nil,
+					"overdue": countIf(bson.M{"$and": bson.A{bson.M{"$lt": bson.A{"$dueDate", now}}, notDone}}),
+					"pending": countIf(bson.M{"$in": bson.A{"$status", bson.A{domain.StatusTodo, domain.StatusInProgress}}}),
+					"completedThisWeek": countIf(bson.M{"$and": bson.A{
+						statusIs(domain.StatusDone),
+						bson.M{"$gte": bson.A{"$completedAt", startOfWeek}},
+					}}),
+					"inProgress": countIf(statusIs(domain.StatusInProgress)),
+					"todo":       countIf(statusIs(domain.StatusTodo)),
+				}}},
+			},
+		}}},
 	}
-	result.TodoCount = int(todoCount)
-
-	return result, nil
 }
 
 // Helper: convertir Task a DashboardTask para MongoDB
@@ -0,0 +1,25 @@
+package persistence
+
+import (
+	"testing"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/infrastructure/persistence/repotest"
+)
+
+// TestMongoSharedTasks sincronización de copias completadas contra MongoDB:
+//
+//	TEST_MONGO_URI=mongodb://localhost:27017 go test -run MongoShared ./internal/infrastructure/persistence/
+func TestMongoSharedTasks(t *testing.T) {
+	repotest.SharedTasks(t, func(t *testing.T) ports.TaskRepository {
+		return NewMongoTaskRepository(testCollection(t))
+	})
+}
+
+// TestMongoScoreSort el score calculado en la agregación ordena igual que
+// domain.ComputeScore y el cursor lo continúa
+func TestMongoScoreSort(t *testing.T) {
+	repotest.ScoreSort(t, func(t *testing.T) ports.TaskRepository {
+		return NewMongoTaskRepository(testCollection(t))
+	})
+}
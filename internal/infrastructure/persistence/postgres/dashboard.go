@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// DueToday tareas que vencen hoy en la zona horaria indicada
+func (r *TaskRepository) DueToday(ctx context.Context, userID string, loc *time.Location) ([]domain.Task, error) {
+	start, end := domain.DayRange(time.Now(), loc)
+
+	tasks, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks
+		WHERE user_id = $1 AND due_date >= $2 AND due_date < $3 ORDER BY due_date, id`, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tareas de hoy: %w", err)
+	}
+	return tasks, nil
+}
+
+// Aggregated conteos de las tareas del usuario; las vencidas se calculan
+// respecto de until
+func (r *TaskRepository) Aggregated(ctx context.Context, userID string, until time.Time) (domain.Stats, error) {
+	stats := domain.Stats{
+		ByStatus:   map[string]int{},
+		ByPriority: map[string]int{},
+		ByType:     map[string]int{},
+		BySubject:  map[string]int{},
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT status, priority, type, subject_id, count(*),
+		count(*) FILTER (WHERE due_date < $2 AND status <> $3)
+		FROM tasks WHERE user_id = $1
+		GROUP BY status, priority, type, subject_id`, userID, until, domain.StatusDone)
+	if err != nil {
+		return stats, fmt.Errorf("error al agregar tareas: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status, priority, typ, subject string
+		var count, overdue int
+		if err := rows.Scan(&status, &priority, &typ, &subject, &count, &overdue); err != nil {
+			return stats, err
+		}
+		stats.Total += count
+		stats.Overdue += overdue
+		switch status {
+		case domain.StatusDone:
+			stats.Completed += count
+		case domain.StatusTodo, domain.StatusInProgress:
+			stats.Pending += count
+		}
+		stats.ByStatus[status] += count
+		stats.ByPriority[priority] += count
+		stats.ByType[typ] += count
+		if subject != "" {
+			stats.BySubject[subject] += count
+		}
+	}
+	return stats, rows.Err()
+}
+
+// GetDashboardStats mismas secciones que el dashboard de Mongo: próximas,
+// de hoy y conteos, estos últimos en una sola consulta
+func (r *TaskRepository) GetDashboardStats(ctx context.Context, userID string, opts domain.DashboardOptions) (domain.DashboardData, error) {
+	result := domain.DashboardData{
+		UpcomingTasks: make([]domain.DashboardTask, 0),
+		TodayTasks:    make([]domain.DashboardTask, 0),
+	}
+
+	w := opts.Windows(time.Now())
+	now, startOfWeek, startOfDay, endOfDay := w.Now, w.StartOfWeek, w.StartOfDay, w.EndOfDay
+
+	// LIMIT NULL = sin límite
+	var todayLimit *int
+	if w.TodayLimit > 0 {
+		todayLimit = &w.TodayLimit
+	}
+
+	// ===== TAREAS PRÓXIMAS (Upcoming) =====
+	upcoming, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks
+		WHERE user_id = $1 AND due_date > $2 AND status <> $3
+		ORDER BY due_date, id LIMIT $4`, userID, now, domain.StatusDone, w.UpcomingLimit)
+	if err != nil {
+		return result, fmt.Errorf("error al obtener tareas próximas: %w", err)
+	}
+	for i := range upcoming {
+		result.UpcomingTasks = append(result.UpcomingTasks, taskToDashboardTask(&upcoming[i]))
+	}
+
+	// ===== TAREAS HOY =====
+	today, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks
+		WHERE user_id = $1 AND due_date >= $2 AND due_date < $3
+		ORDER BY due_date, id LIMIT $4`, userID, startOfDay, endOfDay, todayLimit)
+	if err != nil {
+		return result, fmt.Errorf("error al obtener tareas de hoy: %w", err)
+	}
+	for i := range today {
+		result.TodayTasks = append(result.TodayTasks, taskToDashboardTask(&today[i]))
+	}
+
+	// ===== CONTEOS AGREGADOS =====
+	err = r.pool.QueryRow(ctx, `SELECT
+		count(*) FILTER (WHERE due_date < $2 AND status <> $3),
+		count(*) FILTER (WHERE status IN ($4, $5)),
+		count(*) FILTER (WHERE status = $3 AND completed_at >= $6),
+		count(*) FILTER (WHERE status = $5),
+		count(*) FILTER (WHERE status = $4)
+		FROM tasks WHERE user_id = $1`,
+		userID, now, domain.StatusDone, domain.StatusTodo, domain.StatusInProgress, startOfWeek,
+	).Scan(&result.OverdueCount, &result.TotalPending, &result.CompletedThisWeek, &result.InProgressCount, &result.TodoCount)
+	if err != nil {
+		return result, fmt.Errorf("error al contar tareas: %w", err)
+	}
+
+	return result, nil
+}
+
+// taskToDashboardTask resumen de la tarea para el dashboard
+func taskToDashboardTask(t *domain.Task) domain.DashboardTask {
+	return domain.DashboardTask{
+		ID:       t.ID,
+		Title:    t.Title,
+		DueDate:  t.DueDate.Format("2006-01-02T15:04:05Z07:00"),
+		Priority: t.Priority,
+		Status:   t.Status,
+		Type:     t.Type,
+	}
+}
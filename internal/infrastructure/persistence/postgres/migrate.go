@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey clave del advisory lock que serializa las migraciones
+// entre réplicas
+const migrationLockKey int64 = 0x756e69666c6f77 // "uniflow"
+
+// sqlMigration archivo migrations/NNN_descripcion.sql
+type sqlMigration struct {
+	Version     int
+	Description string
+	SQL         string
+}
+
+// loadMigrations lee los archivos embebidos en orden de versión
+func loadMigrations() ([]sqlMigration, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var out []sqlMigration
+	for _, name := range names {
+		base := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+		num, desc, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(num)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migración con nombre inválido: %s", name)
+		}
+		sql, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sqlMigration{Version: version, Description: strings.ReplaceAll(desc, "_", " "), SQL: string(sql)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	for i := 1; i < len(out); i++ {
+		if out[i].Version == out[i-1].Version {
+			return nil, fmt.Errorf("versión de migración repetida: %d", out[i].Version)
+		}
+	}
+	return out, nil
+}
+
+// Migrate aplica las migraciones pendientes, cada una en su transacción y
+// registrada en schema_migrations. Un advisory lock de sesión hace que las
+// réplicas que arrancan a la vez esperen a la primera en lugar de repetir
+// los pasos. Devuelve las versiones aplicadas.
+func Migrate(ctx context.Context, pool *pgxpool.Pool) ([]int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return nil, fmt.Errorf("tomar lock de migraciones: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			log.Printf("⚠️ No se pudo liberar el lock de migraciones: %v", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return nil, fmt.Errorf("crear schema_migrations: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		applied[v] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		log.Printf("Aplicando migración SQL %d: %s", m.Version, m.Description)
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return done, err
+		}
+		if _, err := tx.Exec(ctx, m.SQL); err != nil {
+			_ = tx.Rollback(ctx)
+			return done, fmt.Errorf("migración %d (%s): %w", m.Version, m.Description, err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, description) VALUES ($1, $2)", m.Version, m.Description); err != nil {
+			_ = tx.Rollback(ctx)
+			return done, fmt.Errorf("registrar migración %d: %w", m.Version, err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return done, err
+		}
+		done = append(done, m.Version)
+	}
+	return done, nil
+}
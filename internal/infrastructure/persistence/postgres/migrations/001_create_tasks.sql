@@ -0,0 +1,30 @@
+-- Tareas. Los arrays vacíos se guardan como '{}' (nunca NULL) y external_id
+-- es NULL cuando la tarea no viene de un import.
+CREATE TABLE IF NOT EXISTS tasks (
+    id                   TEXT PRIMARY KEY,
+    user_id              TEXT NOT NULL,
+    title                TEXT NOT NULL DEFAULT '',
+    description          TEXT NOT NULL DEFAULT '',
+    subject_id           TEXT NOT NULL DEFAULT '',
+    period_id            TEXT NOT NULL DEFAULT '',
+    due_date             TIMESTAMPTZ NOT NULL,
+    status               TEXT NOT NULL,
+    priority             TEXT NOT NULL,
+    type                 TEXT NOT NULL,
+    estimated_time_hours INTEGER NOT NULL DEFAULT 0,
+    actual_time_hours    INTEGER,
+    grade_weight         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    is_blocked           BOOLEAN NOT NULL DEFAULT FALSE,
+    tags                 TEXT[] NOT NULL DEFAULT '{}',
+    is_group_work        BOOLEAN NOT NULL DEFAULT FALSE,
+    group_members        TEXT[] NOT NULL DEFAULT '{}',
+    attachments          TEXT[] NOT NULL DEFAULT '{}',
+    external_id          TEXT,
+    created_at           TIMESTAMPTZ NOT NULL,
+    updated_at           TIMESTAMPTZ NOT NULL,
+    completed_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS tasks_user_due_idx ON tasks (user_id, due_date);
+CREATE INDEX IF NOT EXISTS tasks_user_status_due_idx ON tasks (user_id, status, due_date);
+CREATE INDEX IF NOT EXISTS tasks_user_external_idx ON tasks (user_id, external_id) WHERE external_id IS NOT NULL;
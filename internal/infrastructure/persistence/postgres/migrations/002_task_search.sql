@@ -0,0 +1,15 @@
+-- Búsqueda de texto. La API guarda en search_* los términos ya normalizados
+-- por el paquete search (sin tildes, plurales reducidos), así el tsvector
+-- usa la configuración 'simple' y coincide con la tokenización de los otros
+-- backends. Pesos: título A, tags B, descripción C.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_title TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_tags TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_body TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', search_title), 'A') ||
+    setweight(to_tsvector('simple', search_tags), 'B') ||
+    setweight(to_tsvector('simple', search_body), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks USING GIN (search_vector);
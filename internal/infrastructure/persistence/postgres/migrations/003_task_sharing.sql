@@ -0,0 +1,7 @@
+-- Tareas compartidas: cada colaborador tiene su propia fila (copia) que
+-- apunta a la del dueño. Vacías en las tareas que no son copias.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS shared_task_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS owner_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tasks_shared_idx ON tasks (owner_id, shared_task_id) WHERE shared_task_id <> '';
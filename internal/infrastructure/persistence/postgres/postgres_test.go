@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/infrastructure/persistence/repotest"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testDatabaseURL servidor usado por los tests: TEST_DATABASE_URL o uno local
+// levantado por TestMain con initdb/pg_ctl. Vacío si no hay ninguno; en CI
+// (variable CI definida) eso es un error y no se omiten los tests.
+var testDatabaseURL string
+
+func TestMain(m *testing.M) {
+	testDatabaseURL = os.Getenv("TEST_DATABASE_URL")
+	stop := func() {}
+	if testDatabaseURL == "" {
+		var err error
+		testDatabaseURL, stop, err = startLocalPostgres()
+		if err != nil {
+			if os.Getenv("CI") != "" {
+				log.Fatalf("PostgreSQL no disponible en CI (defina TEST_DATABASE_URL): %v", err)
+			}
+			log.Printf("Tests de PostgreSQL omitidos: %v", err)
+			stop = func() {}
+		}
+	}
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// pgBinary busca un ejecutable de PostgreSQL en el PATH o en las rutas
+// habituales de los paquetes de Debian/Ubuntu
+func pgBinary(name string) (string, error) {
+	if p, err := exec.LookPath(name); err == nil {
+		return p, nil
+	}
+	matches, _ := filepath.Glob("/usr/lib/postgresql/*/bin/" + name)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%s no encontrado (defina TEST_DATABASE_URL)", name)
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// startLocalPostgres crea un cluster temporal y lo arranca en un puerto
+// libre, escuchando solo en un socket unix dentro del directorio temporal
+func startLocalPostgres() (string, func(), error) {
+	initdb, err := pgBinary("initdb")
+	if err != nil {
+		return "", nil, err
+	}
+	pgCtl, err := pgBinary("pg_ctl")
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "uniflow-pg-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	data := filepath.Join(dir, "data")
+
+	if out, err := exec.Command(initdb, "-D", data, "-U", "postgres", "--auth=trust", "--encoding=UTF8").CombinedOutput(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("initdb: %v: %s", err, out)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	opts := fmt.Sprintf("-p %d -k %s -c listen_addresses=''", port, dir)
+	if out, err := exec.Command(pgCtl, "-D", data, "-o", opts, "-l", filepath.Join(dir, "log"), "-w", "start").CombinedOutput(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("pg_ctl start: %v: %s", err, out)
+	}
+
+	stop := func() {
+		_ = exec.Command(pgCtl, "-D", data, "-m", "immediate", "stop").Run()
+		cleanup()
+	}
+	return fmt.Sprintf("postgres://postgres@/postgres?host=%s&port=%d&sslmode=disable", dir, port), stop, nil
+}
+
+// newTestPool pool aislado en un esquema propio, migrado y eliminado al terminar
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	if testDatabaseURL == "" {
+		t.Skip("PostgreSQL no disponible")
+	}
+	ctx := context.Background()
+
+	admin, err := Connect(ctx, testDatabaseURL)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := "test_" + newID()
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+
+	cfg, err := pgxpool.ParseConfig(testDatabaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pool.Close()
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	if _, err := Migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return pool
+}
+
+func TestTaskRepositoryContract(t *testing.T) {
+	repotest.TaskRepository(t, func(t *testing.T) ports.TaskRepository {
+		return NewTaskRepository(newTestPool(t))
+	})
+}
+
+func TestMigrateIsIdempotentAndSerialized(t *testing.T) {
+	pool := newTestPool(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Ya migrado por newTestPool: varias réplicas a la vez no aplican nada
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied, err := Migrate(ctx, pool)
+			if err == nil && len(applied) > 0 {
+				err = fmt.Errorf("applied %v again", applied)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	migrations, _ := loadMigrations()
+	var count int
+	if err := pool.QueryRow(ctx, "SELECT count(*) FROM schema_migrations").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != len(migrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(migrations), count)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 || m.Description == "" || m.SQL == "" {
+			t.Errorf("unexpected migration %d: %+v", i, m)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// sqlBuilder acumula condiciones WHERE con argumentos posicionales ($1, $2, ...)
+type sqlBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg agrega un argumento y devuelve su placeholder
+func (b *sqlBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *sqlBuilder) where(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+func (b *sqlBuilder) whereClause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// filterConditions mismas condiciones que el FindByFilter de Mongo
+func filterConditions(filter ports.TaskFilter, now time.Time) *sqlBuilder {
+	b := &sqlBuilder{}
+	b.where("user_id = " + b.arg(filter.UserID))
+
+	if len(filter.Status) > 0 {
+		b.where("status = ANY(" + b.arg(filter.Status) + ")")
+	}
+	if len(filter.Priority) > 0 {
+		b.where("priority = ANY(" + b.arg(filter.Priority) + ")")
+	}
+	if len(filter.Type) > 0 {
+		b.where("type = ANY(" + b.arg(filter.Type) + ")")
+	}
+	if filter.SubjectID != "" {
+		b.where("subject_id = " + b.arg(filter.SubjectID))
+	}
+	if filter.PeriodID != "" {
+		b.where("period_id = " + b.arg(filter.PeriodID))
+	}
+	if !filter.DueDateFrom.IsZero() {
+		b.where("due_date >= " + b.arg(filter.DueDateFrom))
+	}
+	if !filter.DueDateTo.IsZero() {
+		b.where("due_date <= " + b.arg(filter.DueDateTo))
+	}
+	if filter.IsOverdue != nil && *filter.IsOverdue {
+		b.where("due_date < " + b.arg(now) + " AND status <> " + b.arg(domain.StatusDone))
+	}
+	if filter.IsDueSoon != nil && *filter.IsDueSoon {
+		b.where("due_date >= " + b.arg(now) + " AND due_date <= " + b.arg(now.Add(24*time.Hour)))
+	}
+	if filter.Query != nil {
+		b.where(queryToSQL(b, filter.Query))
+	}
+	return b
+}
+
+// queryColumns columna de cada campo de domain.QueryNode (nombres bson)
+var queryColumns = map[string]string{
+	"status":             "status",
+	"priority":           "priority",
+	"type":               "type",
+	"tags":               "tags",
+	"subjectId":          "subject_id",
+	"periodId":           "period_id",
+	"title":              "title",
+	"estimatedTimeHours": "estimated_time_hours",
+	"gradeWeight":        "grade_weight",
+	"dueDate":            "due_date",
+	"createdAt":          "created_at",
+	"completedAt":        "completed_at",
+	"isBlocked":          "is_blocked",
+	"isGroupWork":        "is_group_work",
+}
+
+var sqlComparisons = map[string]string{"eq": "=", "gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+
+// queryToSQL traduce una expresión de filtro con la misma semántica que
+// domain.QueryNode.Matches. Las hojas nunca valen NULL (completed_at vacío
+// no cumple un rango, y NOT de eso sí), igual que en memoria y en Mongo.
+func queryToSQL(b *sqlBuilder, n *domain.QueryNode) string {
+	col := queryColumns[n.Field]
+	switch n.Kind {
+	case domain.QueryAnd, domain.QueryOr:
+		parts := make([]string, len(n.Children))
+		for i, c := range n.Children {
+			parts[i] = queryToSQL(b, c)
+		}
+		op := " AND "
+		if n.Kind == domain.QueryOr {
+			op = " OR "
+		}
+		return "(" + strings.Join(parts, op) + ")"
+	case domain.QueryNot:
+		return "NOT " + queryToSQL(b, n.Children[0])
+	case domain.QueryIn:
+		if col == "tags" {
+			return "(tags && " + b.arg(n.Values) + "::text[])"
+		}
+		return "(" + col + " = ANY(" + b.arg(n.Values) + "))"
+	case domain.QueryContains:
+		return "(strpos(lower(" + col + "), lower(" + b.arg(n.Text) + ")) > 0)"
+	case domain.QueryNumber:
+		return "(" + col + " " + sqlComparisons[n.Cmp] + " " + b.arg(n.Num) + "::float8)"
+	case domain.QueryTime:
+		cond := col + " IS NOT NULL"
+		if !n.From.IsZero() {
+			cond += " AND " + col + " >= " + b.arg(n.From)
+		}
+		if !n.To.IsZero() {
+			cond += " AND " + col + " < " + b.arg(n.To)
+		}
+		return "(" + cond + ")"
+	case domain.QueryBool:
+		return "(" + col + " = " + b.arg(n.Bool) + ")"
+	}
+	return "FALSE"
+}
+
+// rankExpr rango semántico 1..n (0 si es desconocido), igual que domain.PriorityRank/StatusRank
+func rankExpr(col string, values []string) string {
+	var sb strings.Builder
+	sb.WriteString("CASE " + col)
+	for i, v := range values {
+		sb.WriteString(" WHEN '" + strings.ReplaceAll(v, "'", "''") + "' THEN " + strconv.Itoa(i+1))
+	}
+	sb.WriteString(" ELSE 0 END")
+	return sb.String()
+}
+
+// sortExpr expresión SQL con el mismo orden que domain.SortValueOf. Los
+// textos se comparan byte a byte (COLLATE "C") como en Go y en Mongo.
+func sortExpr(field string) string {
+	switch field {
+	case "priority":
+		return rankExpr("priority", domain.ValidPriorities)
+	case "status":
+		return rankExpr("status", domain.ValidStatuses)
+	case "createdAt":
+		return "created_at"
+	case "updatedAt":
+		return "updated_at"
+	case "completedAt":
+		return "COALESCE(completed_at, 'epoch'::timestamptz)"
+	case "title":
+		return `title COLLATE "C"`
+	default:
+		return "due_date"
+	}
+}
+
+const idSortExpr = `id COLLATE "C"`
+
+// sortValueArg valor del cursor con el tipo de la columna
+func sortValueArg(field string, v domain.SortValue) any {
+	switch field {
+	case "priority", "status":
+		return int(v.Num)
+	case "title":
+		return v.Str
+	default:
+		return time.UnixMilli(int64(v.Num)).UTC()
+	}
+}
+
+func sqlDirection(desc, reverse bool) string {
+	if desc != reverse {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+func keysetOp(desc, reverse bool) string {
+	if desc != reverse {
+		return "<"
+	}
+	return ">"
+}
+
+// keysetCondition "posterior al cursor" (anterior si before):
+// (f1 > v1) OR (f1 = v1 AND f2 > v2) OR ... OR (todos iguales AND id > cursor.id)
+func keysetCondition(b *sqlBuilder, fields []domain.SortField, cur *domain.Cursor, before bool) string {
+	var ors []string
+	var eq []string
+	for i, f := range fields {
+		expr := sortExpr(f.Field)
+		val := b.arg(sortValueArg(f.Field, cur.Values[i]))
+		ors = append(ors, "("+strings.Join(append(append([]string{}, eq...), expr+" "+keysetOp(f.Desc, before)+" "+val), " AND ")+")")
+		eq = append(eq, expr+" = "+val)
+	}
+	last := idSortExpr + " " + keysetOp(fields[len(fields)-1].Desc, before) + " " + b.arg(cur.ID)
+	ors = append(ors, "("+strings.Join(append(eq, last), " AND ")+")")
+	return "(" + strings.Join(ors, " OR ") + ")"
+}
+
+// FindByFilter obtiene tareas con filtros, orden compuesto y paginación por
+// página o por cursor
+func (r *TaskRepository) FindByFilter(ctx context.Context, filter ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	now := time.Now()
+	b := filterConditions(filter, now)
+
+	// Búsqueda de texto: candidatos por tsvector y relevancia en Go
+	if filter.Search != "" {
+		return r.findBySearch(ctx, b, filter)
+	}
+
+	fields := filter.SortFields()
+	before := filter.Before != ""
+	var cur *domain.Cursor
+	if filter.IsCursorMode() {
+		token := filter.After
+		if before {
+			token = filter.Before
+		}
+		var err error
+		if cur, err = domain.DecodeCursor(token, fields); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+	}
+
+	// El score de urgencia se calcula en memoria
+	if filter.SortsByScore() {
+		all, err := r.queryTasks(ctx, "SELECT "+taskColumns+" FROM tasks"+b.whereClause(), b.args...)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+		return domain.PaginateInMemory(all, filter, now)
+	}
+
+	total := int64(-1)
+	if !filter.SkipCount {
+		if err := r.pool.QueryRow(ctx, "SELECT count(*) FROM tasks"+b.whereClause(), b.args...).Scan(&total); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+	skip := 0
+	if cur == nil && filter.Page > 1 {
+		skip = (filter.Page - 1) * limit
+	}
+
+	// Con before se lee en sentido inverso y BuildPage restaura el orden
+	if cur != nil {
+		b.where(keysetCondition(b, fields, cur, before))
+	}
+	order := make([]string, 0, len(fields)+1)
+	for _, f := range fields {
+		order = append(order, sortExpr(f.Field)+" "+sqlDirection(f.Desc, before))
+	}
+	order = append(order, idSortExpr+" "+sqlDirection(fields[len(fields)-1].Desc, before))
+
+	sql := "SELECT " + taskColumns + " FROM tasks" + b.whereClause() +
+		" ORDER BY " + strings.Join(order, ", ") +
+		" LIMIT " + strconv.Itoa(limit+1) + " OFFSET " + strconv.Itoa(skip)
+	rows, err := r.queryTasks(ctx, sql, b.args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	tasks, pageInfo := domain.BuildPage(rows, filter, total, now)
+	return tasks, pageInfo, nil
+}
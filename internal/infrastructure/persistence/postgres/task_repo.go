@@ -0,0 +1,272 @@
+// Package postgres implementa los repositorios sobre PostgreSQL (pgx). Se
+// activa con DATABASE_URL; el esquema se crea con las migraciones SQL
+// embebidas (Migrate).
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/search"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect abre el pool y verifica la conexión
+func Connect(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+// TaskRepository implementa ports.TaskRepository sobre la tabla tasks
+type TaskRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ ports.TaskRepository = (*TaskRepository)(nil)
+
+// NewTaskRepository crea el repositorio
+func NewTaskRepository(pool *pgxpool.Pool) *TaskRepository {
+	return &TaskRepository{pool: pool}
+}
+
+// taskColumns columnas en el orden que espera scanTask
+const taskColumns = `id, user_id, title, description, subject_id, period_id, due_date, status, priority, type,
+	estimated_time_hours, actual_time_hours, grade_weight, is_blocked, tags, is_group_work, group_members,
+	attachments, external_id, created_at, updated_at, completed_at, shared_task_id, owner_id, role`
+
+// newID ID aleatorio de 24 caracteres hex (mismo formato que los ObjectID de Mongo)
+func newID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// dbTime las fechas se guardan con precisión de milisegundos, igual que en
+// Mongo: los cursores usan milisegundos y deben coincidir exactamente
+func dbTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+func dbTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := dbTime(*t)
+	return &v
+}
+
+// nonNil los arrays nunca se guardan como NULL
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// searchColumns términos normalizados para el tsvector (ver 002_task_search.sql)
+func searchColumns(t *domain.Task) (title, tags, body string) {
+	return strings.Join(search.Terms(t.Title), " "),
+		strings.Join(search.Terms(strings.Join(t.Tags, " ")), " "),
+		strings.Join(search.Terms(t.Description), " ")
+}
+
+// taskArgs valores de taskColumns (más las columnas de búsqueda al final)
+func taskArgs(t *domain.Task) []any {
+	sTitle, sTags, sBody := searchColumns(t)
+	return []any{
+		t.ID, t.UserID, t.Title, t.Description, t.SubjectID, t.PeriodID, dbTime(t.DueDate), t.Status, t.Priority, t.Type,
+		t.EstimatedTimeHours, t.ActualTimeHours, t.GradeWeight, t.IsBlocked, nonNil(t.Tags), t.IsGroupWork, nonNil(t.GroupMembers),
+		nonNil(t.Attachments), nullableString(t.ExternalID), dbTime(t.CreatedAt), dbTime(t.UpdatedAt), dbTimePtr(t.CompletedAt),
+		t.SharedTaskID, t.OwnerID, t.Role,
+		sTitle, sTags, sBody,
+	}
+}
+
+// scanTask lee una fila con taskColumns
+func scanTask(row pgx.Row) (domain.Task, error) {
+	var t domain.Task
+	var externalID *string
+	err := row.Scan(&t.ID, &t.UserID, &t.Title, &t.Description, &t.SubjectID, &t.PeriodID, &t.DueDate, &t.Status, &t.Priority, &t.Type,
+		&t.EstimatedTimeHours, &t.ActualTimeHours, &t.GradeWeight, &t.IsBlocked, &t.Tags, &t.IsGroupWork, &t.GroupMembers,
+		&t.Attachments, &externalID, &t.CreatedAt, &t.UpdatedAt, &t.CompletedAt, &t.SharedTaskID, &t.OwnerID, &t.Role)
+	if err != nil {
+		return t, err
+	}
+	if externalID != nil {
+		t.ExternalID = *externalID
+	}
+	t.DueDate, t.CreatedAt, t.UpdatedAt = t.DueDate.UTC(), t.CreatedAt.UTC(), t.UpdatedAt.UTC()
+	if t.CompletedAt != nil {
+		c := t.CompletedAt.UTC()
+		t.CompletedAt = &c
+	}
+	return t, nil
+}
+
+// queryTasks ejecuta la consulta y lee todas las filas
+func (r *TaskRepository) queryTasks(ctx context.Context, sql string, args ...any) ([]domain.Task, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0)
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// Create inserta una nueva tarea
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if task.ID == "" {
+		task.ID = newID()
+	}
+	_, err := r.pool.Exec(ctx, `INSERT INTO tasks (`+taskColumns+`, search_title, search_tags, search_body)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)`,
+		taskArgs(task)...)
+	if err != nil {
+		return fmt.Errorf("error al crear tarea: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtiene una tarea y verifica pertenencia al usuario
+func (r *TaskRepository) GetByID(ctx context.Context, taskID, userID string) (*domain.Task, error) {
+	t, err := scanTask(r.pool.QueryRow(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = $1 AND user_id = $2`, taskID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("tarea no encontrada")
+		}
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+	return &t, nil
+}
+
+// GetByExternalID obtiene la tarea importada con ese ID de origen
+func (r *TaskRepository) GetByExternalID(ctx context.Context, userID, externalID string) (*domain.Task, error) {
+	t, err := scanTask(r.pool.QueryRow(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE user_id = $1 AND external_id = $2 LIMIT 1`, userID, externalID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+	return &t, nil
+}
+
+// GetAll obtiene todas las tareas del usuario
+func (r *TaskRepository) GetAll(ctx context.Context, userID string) ([]domain.Task, error) {
+	tasks, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks WHERE user_id = $1 ORDER BY due_date, id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar tareas: %w", err)
+	}
+	return tasks, nil
+}
+
+// GetByUserAndStatus obtiene tareas por usuario y estado
+func (r *TaskRepository) GetByUserAndStatus(ctx context.Context, userID, status string) ([]domain.Task, error) {
+	if domain.StatusRank(status) == 0 {
+		return nil, fmt.Errorf("estado inválido: %s", status)
+	}
+	tasks, err := r.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE user_id = $1 AND status = $2 ORDER BY due_date, id`, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar tareas por estado: %w", err)
+	}
+	return tasks, nil
+}
+
+// Update reemplaza la tarea (solo si pertenece al usuario)
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE tasks SET
+		title = $3, description = $4, subject_id = $5, period_id = $6, due_date = $7, status = $8, priority = $9, type = $10,
+		estimated_time_hours = $11, actual_time_hours = $12, grade_weight = $13, is_blocked = $14, tags = $15,
+		is_group_work = $16, group_members = $17, attachments = $18, external_id = $19, created_at = $20,
+		updated_at = $21, completed_at = $22, shared_task_id = $23, owner_id = $24, role = $25,
+		search_title = $26, search_tags = $27, search_body = $28
+		WHERE id = $1 AND user_id = $2`, taskArgs(task)...)
+	if err != nil {
+		return fmt.Errorf("error al actualizar tarea: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("tarea no encontrada o no pertenece al usuario")
+	}
+	return nil
+}
+
+// UpdateStatus igual que Update (no se aplican reglas de estado)
+func (r *TaskRepository) UpdateStatus(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
+// UpdateShared igual que Update (no se aplican reglas de estado)
+func (r *TaskRepository) UpdateShared(ctx context.Context, task *domain.Task) error {
+	return r.Update(ctx, task)
+}
+
+// DeleteShared igual que Delete
+func (r *TaskRepository) DeleteShared(ctx context.Context, taskID, userID string) error {
+	return r.Delete(ctx, taskID, userID)
+}
+
+// Delete elimina una tarea (solo si pertenece al usuario)
+func (r *TaskRepository) Delete(ctx context.Context, taskID, userID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tasks WHERE id = $1 AND user_id = $2`, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("error al eliminar tarea: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("tarea no encontrada")
+	}
+	return nil
+}
+
+// ListShared copias de los colaboradores de una tarea, por fecha de alta
+func (r *TaskRepository) ListShared(ctx context.Context, ownerID, taskID string) ([]domain.Task, error) {
+	tasks, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks
+		WHERE owner_id = $1 AND shared_task_id = $2 ORDER BY created_at, id`, ownerID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar colaboradores: %w", err)
+	}
+	return tasks, nil
+}
+
+// Find listado con filtros; equivale a FindByFilter
+func (r *TaskRepository) Find(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.FindByFilter(ctx, f)
+}
+
+// Search búsqueda de texto; equivale a FindByFilter con Search
+func (r *TaskRepository) Search(ctx context.Context, f domain.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	return r.FindByFilter(ctx, f)
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/search"
+)
+
+// maxSearchCandidates tope de tareas que se puntúan en memoria por búsqueda
+const maxSearchCandidates = 2000
+
+// findBySearch igual que en Mongo: el tsvector reduce las tareas a
+// candidatas y search.Rank calcula la relevancia. Si no hay ninguna
+// coincidencia se puntúan las tareas del filtro sin condición de texto,
+// para encontrar términos con errores de tipeo.
+func (r *TaskRepository) findBySearch(ctx context.Context, b *sqlBuilder, filter ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	now := time.Now()
+	q := search.ParseQuery(filter.Search)
+	if q.Empty() {
+		return search.Paginate(nil, filter, now)
+	}
+
+	base := "SELECT " + taskColumns + " FROM tasks" + b.whereClause()
+	limit := " LIMIT " + strconv.Itoa(maxSearchCandidates)
+	baseArgs := append([]any(nil), b.args...)
+
+	b.where("search_vector @@ to_tsquery('simple', " + b.arg(tsQuery(q)) + ")")
+	candidates, err := r.queryTasks(ctx, "SELECT "+taskColumns+" FROM tasks"+b.whereClause()+limit, b.args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	hits := search.Rank(candidates, q)
+
+	if len(hits) == 0 {
+		all, err := r.queryTasks(ctx, base+limit, baseArgs...)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+		hits = search.Rank(all, q)
+	}
+
+	return search.Paginate(hits, filter, now)
+}
+
+// tsQuery todos los términos con AND; los prefijos con :*
+func tsQuery(q search.Query) string {
+	parts := make([]string, len(q.Terms))
+	for i, t := range q.Terms {
+		parts[i] = "'" + strings.ReplaceAll(t.Text, "'", "''") + "'"
+		if t.Prefix {
+			parts[i] += ":*"
+		}
+	}
+	return strings.Join(parts, " & ")
+}
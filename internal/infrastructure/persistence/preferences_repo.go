@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoPreferencesRepository implementa PreferencesRepository usando MongoDB
+// (un documento por usuario, _id = userId)
+type MongoPreferencesRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoPreferencesRepository crea una nueva instancia de MongoPreferencesRepository
+func NewMongoPreferencesRepository(collection *mongo.Collection) *MongoPreferencesRepository {
+	return &MongoPreferencesRepository{
+		collection: collection,
+	}
+}
+
+// Get obtiene las preferencias del usuario
+func (r *MongoPreferencesRepository) Get(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	var p domain.UserPreferences
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&p)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrPreferencesNotFound
+		}
+		return nil, fmt.Errorf("error al obtener preferencias: %w", err)
+	}
+
+	return &p, nil
+}
+
+// Save crea o reemplaza las preferencias del usuario
+func (r *MongoPreferencesRepository) Save(ctx context.Context, p *domain.UserPreferences) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": p.UserID}, p, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("error al guardar preferencias: %w", err)
+	}
+
+	return nil
+}
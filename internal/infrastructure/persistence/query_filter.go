@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"regexp"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// queryToMongo traduce una expresión de filtro (domain.ParseQuery) a un
+// filtro de Mongo con la misma semántica que domain.QueryNode.Matches
+func queryToMongo(n *domain.QueryNode) bson.M {
+	switch n.Kind {
+	case domain.QueryAnd, domain.QueryOr:
+		children := make(bson.A, len(n.Children))
+		for i, c := range n.Children {
+			children[i] = queryToMongo(c)
+		}
+		if n.Kind == domain.QueryAnd {
+			return bson.M{"$and": children}
+		}
+		return bson.M{"$or": children}
+	case domain.QueryNot:
+		return bson.M{"$nor": bson.A{queryToMongo(n.Children[0])}}
+	case domain.QueryIn:
+		return bson.M{n.Field: bson.M{"$in": n.Values}}
+	case domain.QueryContains:
+		return bson.M{n.Field: bson.M{"$regex": regexp.QuoteMeta(n.Text), "$options": "i"}}
+	case domain.QueryNumber:
+		if n.Cmp == "eq" {
+			return bson.M{n.Field: n.Num}
+		}
+		return bson.M{n.Field: bson.M{"$" + n.Cmp: n.Num}}
+	case domain.QueryTime:
+		cond := bson.M{}
+		if !n.From.IsZero() {
+			cond["$gte"] = n.From
+		}
+		if !n.To.IsZero() {
+			cond["$lt"] = n.To
+		}
+		return bson.M{n.Field: cond}
+	case domain.QueryBool:
+		return bson.M{n.Field: n.Bool}
+	}
+	return bson.M{}
+}
@@ -0,0 +1,573 @@
+// Package repotest contiene el contrato de comportamiento de
+// ports.TaskRepository. Cada backend lo ejecuta desde sus tests para
+// garantizar que filtros, orden, paginación y búsqueda coinciden.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+)
+
+// NewRepo crea un repositorio vacío para un subtest
+type NewRepo func(t *testing.T) ports.TaskRepository
+
+// TaskRepository ejecuta el contrato completo contra el backend
+func TaskRepository(t *testing.T, newRepo NewRepo) {
+	tests := map[string]func(*testing.T, ports.TaskRepository){
+		"CRUD":             testCRUD,
+		"Filters":          testFilters,
+		"PagePagination":   testPagePagination,
+		"CursorPagination": testCursorPagination,
+		"CompositeSort":    testCompositeSort,
+		"ScoreSort":        testScoreSort,
+		"Search":           testSearch,
+		"Dashboard":        testDashboard,
+		"UserDay":          testUserDay,
+		"Shared":           testShared,
+		"SharedCompleted":  testSharedCompleted,
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			fn(t, newRepo(t))
+		})
+	}
+}
+
+// SharedTasks ejecuta solo los casos de tareas compartidas; lo usan los
+// backends que aplican reglas de estado en Update/Delete (MongoDB)
+func SharedTasks(t *testing.T, newRepo NewRepo) {
+	t.Run("Shared", func(t *testing.T) { testShared(t, newRepo(t)) })
+	t.Run("SharedCompleted", func(t *testing.T) { testSharedCompleted(t, newRepo(t)) })
+}
+
+// ScoreSort ejecuta solo el orden por score de urgencia; lo usan los
+// backends que lo calculan en la base (MongoDB)
+func ScoreSort(t *testing.T, newRepo NewRepo) {
+	t.Run("ScoreSort", func(t *testing.T) { testScoreSort(t, newRepo(t)) })
+}
+
+// base fecha fija con precisión de milisegundos (la que guardan todos los backends)
+var base = time.Now().UTC().Truncate(time.Millisecond)
+
+func newTask(userID, title string, mods ...func(*domain.Task)) *domain.Task {
+	t := &domain.Task{
+		UserID:    userID,
+		Title:     title,
+		SubjectID: "subject-1",
+		DueDate:   base.Add(72 * time.Hour),
+		Status:    domain.StatusTodo,
+		Priority:  domain.PriorityMedium,
+		Type:      domain.TypeAssignment,
+		Tags:      []string{},
+		CreatedAt: base,
+		UpdatedAt: base,
+	}
+	for _, m := range mods {
+		m(t)
+	}
+	return t
+}
+
+func create(t *testing.T, repo ports.TaskRepository, tasks ...*domain.Task) {
+	t.Helper()
+	for _, task := range tasks {
+		if err := repo.Create(context.Background(), task); err != nil {
+			t.Fatalf("create %q: %v", task.Title, err)
+		}
+	}
+}
+
+func find(t *testing.T, repo ports.TaskRepository, f domain.TaskFilter) ([]domain.Task, domain.PageInfo) {
+	t.Helper()
+	tasks, info, err := repo.FindByFilter(context.Background(), f)
+	if err != nil {
+		t.Fatalf("FindByFilter %+v: %v", f, err)
+	}
+	return tasks, info
+}
+
+func titles(tasks []domain.Task) string {
+	out := make([]string, len(tasks))
+	for i, t := range tasks {
+		out[i] = t.Title
+	}
+	return strings.Join(out, ",")
+}
+
+func testCRUD(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	actual := 3
+	task := newTask("crud-user", "Parcial", func(t *domain.Task) {
+		t.Tags = []string{"parcial", "calculo"}
+		t.ActualTimeHours = &actual
+		t.ExternalID = "ics-1"
+		t.GradeWeight = 25
+	})
+	create(t, repo, task)
+	if task.ID == "" {
+		t.Fatal("Create should assign an ID")
+	}
+
+	got, err := repo.GetByID(ctx, task.ID, "crud-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Parcial" || !got.DueDate.Equal(task.DueDate) || strings.Join(got.Tags, ",") != "parcial,calculo" ||
+		got.ActualTimeHours == nil || *got.ActualTimeHours != 3 || got.GradeWeight != 25 || got.CompletedAt != nil {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+	if _, err := repo.GetByID(ctx, task.ID, "other-user"); err == nil {
+		t.Error("GetByID must check ownership")
+	}
+
+	if got, err := repo.GetByExternalID(ctx, "crud-user", "ics-1"); err != nil || got.ID != task.ID {
+		t.Errorf("GetByExternalID: %v %v", got, err)
+	}
+	if _, err := repo.GetByExternalID(ctx, "crud-user", "missing"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	completed := base.Add(time.Hour)
+	task.Status, task.CompletedAt = domain.StatusDone, &completed
+	task.Title = "Parcial corregido"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = repo.GetByID(ctx, task.ID, "crud-user")
+	if got.Title != "Parcial corregido" || got.CompletedAt == nil || !got.CompletedAt.Equal(completed) {
+		t.Errorf("update not persisted: %+v", got)
+	}
+
+	done, err := repo.GetByUserAndStatus(ctx, "crud-user", domain.StatusDone)
+	if err != nil || len(done) != 1 {
+		t.Errorf("GetByUserAndStatus: %v %v", done, err)
+	}
+	all, err := repo.GetAll(ctx, "crud-user")
+	if err != nil || len(all) != 1 {
+		t.Errorf("GetAll: %v %v", all, err)
+	}
+
+	if err := repo.Delete(ctx, task.ID, "other-user"); err == nil {
+		t.Error("Delete must check ownership")
+	}
+	if err := repo.Delete(ctx, task.ID, "crud-user"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByID(ctx, task.ID, "crud-user"); err == nil {
+		t.Error("task should be gone")
+	}
+}
+
+func testFilters(t *testing.T, repo ports.TaskRepository) {
+	user := "filter-user"
+	create(t, repo,
+		newTask(user, "A", func(t *domain.Task) {
+			t.Type, t.Priority, t.Tags, t.EstimatedTimeHours = domain.TypeExam, domain.PriorityHigh, []string{"parcial"}, 6
+		}),
+		newTask(user, "B", func(t *domain.Task) {
+			t.Type, t.Tags, t.EstimatedTimeHours, t.SubjectID = domain.TypeReading, []string{"parcial"}, 8, "subject-2"
+			t.DueDate = base.Add(73 * time.Hour)
+		}),
+		newTask(user, "C", func(t *domain.Task) {
+			t.Status, t.DueDate, t.IsBlocked = domain.StatusInProgress, base.Add(-48*time.Hour), true
+		}),
+		newTask(user, "D", func(t *domain.Task) {
+			t.Status, t.DueDate, t.CompletedAt = domain.StatusDone, base.Add(-24*time.Hour), &base
+		}),
+		newTask("someone-else", "X"),
+	)
+
+	overdue := true
+	queryOf := func(s string) *domain.QueryNode {
+		n, err := domain.ParseQuery(s, domain.QueryOptions{Now: base, Location: time.UTC})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	cases := []struct {
+		name   string
+		filter domain.TaskFilter
+		want   string
+	}{
+		{"all", domain.TaskFilter{}, "C,D,A,B"},
+		{"status", domain.TaskFilter{Status: []string{domain.StatusTodo}}, "A,B"},
+		{"priority", domain.TaskFilter{Priority: []string{domain.PriorityHigh}}, "A"},
+		{"type", domain.TaskFilter{Type: []string{domain.TypeExam, domain.TypeReading}}, "A,B"},
+		{"subject", domain.TaskFilter{SubjectID: "subject-2"}, "B"},
+		{"overdue", domain.TaskFilter{IsOverdue: &overdue}, "C"},
+		{"query", domain.TaskFilter{Query: queryOf("tag:parcial AND estimated>4 AND -type:reading")}, "A"},
+		{"query or", domain.TaskFilter{Query: queryOf("is:blocked OR priority>=high")}, "C,A"},
+		{"query not null", domain.TaskFilter{Query: queryOf("-completed:today")}, "C,A,B"},
+	}
+	for _, c := range cases {
+		c.filter.UserID = user
+		c.filter.Limit = 10
+		tasks, _ := find(t, repo, c.filter)
+		if got := titles(tasks); got != c.want {
+			t.Errorf("%s: got [%s], want [%s]", c.name, got, c.want)
+		}
+	}
+}
+
+func testPagePagination(t *testing.T, repo ports.TaskRepository) {
+	user := "page-user"
+	for i := 0; i < 5; i++ {
+		i := i
+		create(t, repo, newTask(user, fmt.Sprintf("T%d", i), func(t *domain.Task) { t.DueDate = base.Add(time.Duration(i) * time.Hour) }))
+	}
+
+	tasks, info := find(t, repo, domain.TaskFilter{UserID: user, Limit: 2, Page: 2})
+	if titles(tasks) != "T2,T3" || info.Total != 5 || info.TotalPages != 3 || !info.HasNext || !info.HasPrev {
+		t.Errorf("page 2: %s %+v", titles(tasks), info)
+	}
+	tasks, info = find(t, repo, domain.TaskFilter{UserID: user, Limit: 2, Page: 3, SkipCount: true})
+	if titles(tasks) != "T4" || info.Total != -1 || info.HasNext {
+		t.Errorf("page 3: %s %+v", titles(tasks), info)
+	}
+}
+
+func testCursorPagination(t *testing.T, repo ports.TaskRepository) {
+	user := "cursor-user"
+	// Fechas repetidas: el ID desempata
+	for i := 0; i < 7; i++ {
+		i := i
+		create(t, repo, newTask(user, fmt.Sprintf("T%d", i), func(t *domain.Task) { t.DueDate = base.Add(time.Duration(i/2) * time.Hour) }))
+	}
+
+	filter := domain.TaskFilter{UserID: user, Limit: 3, SortBy: "dueDate", SortOrder: "desc"}
+	seen := map[string]bool{}
+	var pages []string
+	for {
+		tasks, info := find(t, repo, filter)
+		for _, task := range tasks {
+			if seen[task.ID] {
+				t.Fatalf("duplicate %s", task.Title)
+			}
+			seen[task.ID] = true
+		}
+		pages = append(pages, titles(tasks))
+		if !info.HasNext {
+			break
+		}
+		filter.After = info.NextCursor
+		if len(pages) > 5 {
+			t.Fatal("cursor pagination does not end")
+		}
+	}
+	if len(seen) != 7 || len(pages) != 3 {
+		t.Fatalf("expected 7 tasks in 3 pages, got %v", pages)
+	}
+
+	// Volver una página desde la última
+	_, info := find(t, repo, filter)
+	filter.After, filter.Before = "", info.PrevCursor
+	prev, _ := find(t, repo, filter)
+	if titles(prev) != pages[1] {
+		t.Errorf("before: got %s, want %s", titles(prev), pages[1])
+	}
+}
+
+func testScoreSort(t *testing.T, repo ports.TaskRepository) {
+	user := "score-user"
+	hours := func(h int) *int { return &h }
+	tasks := []*domain.Task{
+		newTask(user, "exam-tomorrow", func(t *domain.Task) {
+			t.Type, t.Priority, t.DueDate, t.EstimatedTimeHours, t.GradeWeight = domain.TypeExam, domain.PriorityUrgent, base.Add(24*time.Hour), 10, 30
+		}),
+		newTask(user, "blocked-exam", func(t *domain.Task) {
+			t.Type, t.Priority, t.DueDate, t.IsBlocked = domain.TypeExam, domain.PriorityUrgent, base.Add(24*time.Hour), true
+		}),
+		newTask(user, "overdue", func(t *domain.Task) {
+			t.Priority, t.DueDate, t.EstimatedTimeHours, t.ActualTimeHours = domain.PriorityHigh, base.Add(-48*time.Hour), 4, hours(1)
+		}),
+		newTask(user, "lab-3d", func(t *domain.Task) {
+			t.Type, t.Priority, t.DueDate, t.EstimatedTimeHours = domain.TypeLab, domain.PriorityHigh, base.Add(72*time.Hour), 6
+		}),
+		newTask(user, "quiz-week", func(t *domain.Task) {
+			t.Type, t.DueDate, t.GradeWeight = domain.TypeQuiz, base.Add(7*24*time.Hour), 10
+		}),
+		newTask(user, "reading-far", func(t *domain.Task) {
+			t.Type, t.Priority, t.DueDate = domain.TypeReading, domain.PriorityLow, base.Add(30*24*time.Hour)
+		}),
+		newTask(user, "done", func(t *domain.Task) { t.Status = domain.StatusDone }),
+	}
+	create(t, repo, tasks...)
+
+	// Orden esperado según domain.ComputeScore (los scores están bien separados)
+	want := make([]domain.Task, len(tasks))
+	for i, task := range tasks {
+		want[i] = *task
+	}
+	domain.SortByScore(want, time.Now(), true)
+
+	all, _ := find(t, repo, domain.TaskFilter{UserID: user, Limit: 10, SortBy: "score", SortOrder: "desc"})
+	if titles(all) != titles(want) {
+		t.Fatalf("score desc: got %s, want %s", titles(all), titles(want))
+	}
+
+	filter := domain.TaskFilter{UserID: user, Limit: 2, SortBy: "score", SortOrder: "desc"}
+	var walked []domain.Task
+	for {
+		page, info := find(t, repo, filter)
+		walked = append(walked, page...)
+		if !info.HasNext || len(walked) > len(tasks) {
+			break
+		}
+		filter.After = info.NextCursor
+	}
+	if titles(walked) != titles(want) {
+		t.Errorf("score cursor walk: got %s, want %s", titles(walked), titles(want))
+	}
+}
+
+func testCompositeSort(t *testing.T, repo ports.TaskRepository) {
+	user := "sort-user"
+	create(t, repo,
+		newTask(user, "low-early", func(t *domain.Task) { t.Priority, t.DueDate = domain.PriorityLow, base }),
+		newTask(user, "urgent-late", func(t *domain.Task) { t.Priority, t.DueDate = domain.PriorityUrgent, base.Add(2*time.Hour) }),
+		newTask(user, "urgent-early", func(t *domain.Task) { t.Priority, t.DueDate = domain.PriorityUrgent, base.Add(time.Hour) }),
+		newTask(user, "high", func(t *domain.Task) { t.Priority = domain.PriorityHigh }),
+	)
+
+	sort, _ := domain.ParseSort("priority:desc,dueDate:asc")
+	tasks, _ := find(t, repo, domain.TaskFilter{UserID: user, Limit: 10, Sort: sort})
+	if got := titles(tasks); got != "urgent-early,urgent-late,high,low-early" {
+		t.Errorf("priority desc, dueDate asc: %s", got)
+	}
+
+	// El cursor respeta el orden compuesto
+	first, info := find(t, repo, domain.TaskFilter{UserID: user, Limit: 2, Sort: sort})
+	rest, _ := find(t, repo, domain.TaskFilter{UserID: user, Limit: 2, Sort: sort, After: info.NextCursor})
+	if titles(first)+","+titles(rest) != "urgent-early,urgent-late,high,low-early" {
+		t.Errorf("cursor with composite sort: %s / %s", titles(first), titles(rest))
+	}
+}
+
+func testSearch(t *testing.T, repo ports.TaskRepository) {
+	user := "search-user"
+	create(t, repo,
+		newTask(user, "Repaso general", func(t *domain.Task) { t.Description = "Ejercicios de cálculo integral" }),
+		newTask(user, "Parcial de Cálculo", func(t *domain.Task) { t.Tags = []string{"parcial"} }),
+		newTask(user, "Guía", func(t *domain.Task) { t.Tags = []string{"cálculo"} }),
+		newTask(user, "Informe de física"),
+	)
+
+	cases := map[string]string{
+		"calculo":         "Parcial de Cálculo,Guía,Repaso general",
+		"CALC":            "Parcial de Cálculo,Guía,Repaso general",
+		"parcial calculo": "Parcial de Cálculo",
+		"fisca ":          "Informe de física",
+		"quimica ":        "",
+	}
+	for q, want := range cases {
+		tasks, _ := find(t, repo, domain.TaskFilter{UserID: user, Search: q, Limit: 10})
+		if got := titles(tasks); got != want {
+			t.Errorf("%q: got [%s], want [%s]", q, got, want)
+		}
+	}
+
+	// Con otro orden se ignora la relevancia
+	tasks, _ := find(t, repo, domain.TaskFilter{UserID: user, Search: "calculo", SortBy: "title", Limit: 10})
+	if got := titles(tasks); got != "Guía,Parcial de Cálculo,Repaso general" {
+		t.Errorf("search sorted by title: %s", got)
+	}
+	if _, _, err := repo.FindByFilter(context.Background(), domain.TaskFilter{UserID: user, Search: "calculo", After: "x"}); !errors.Is(err, domain.ErrCursorUnsupported) {
+		t.Errorf("expected ErrCursorUnsupported, got %v", err)
+	}
+}
+
+func testDashboard(t *testing.T, repo ports.TaskRepository) {
+	user := "dashboard-user"
+	now := time.Now().UTC()
+	// Semana que empieza el domingo: el borde está incluido
+	weekStart := domain.DashboardOptions{WeekStart: "sunday"}.Windows(now).StartOfWeek.Truncate(time.Millisecond)
+	done, lastWeek := weekStart, weekStart.Add(-time.Millisecond)
+	create(t, repo,
+		newTask(user, "overdue", func(t *domain.Task) { t.DueDate = now.Add(-72 * time.Hour) }),
+		newTask(user, "doing", func(t *domain.Task) { t.Status, t.DueDate = domain.StatusInProgress, now.Add(72*time.Hour) }),
+		newTask(user, "next", func(t *domain.Task) { t.DueDate = now.Add(96 * time.Hour) }),
+		newTask(user, "done", func(t *domain.Task) {
+			t.Status, t.DueDate, t.CompletedAt = domain.StatusDone, now.Add(-48*time.Hour), &done
+		}),
+		newTask(user, "done last week", func(t *domain.Task) {
+			t.Status, t.DueDate, t.CompletedAt = domain.StatusDone, now.Add(-240*time.Hour), &lastWeek
+		}),
+	)
+
+	d, err := repo.GetDashboardStats(context.Background(), user, domain.DashboardOptions{WeekStart: "sunday"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.OverdueCount != 1 || d.TotalPending != 3 || d.TodoCount != 2 || d.InProgressCount != 1 ||
+		d.CompletedThisWeek != 1 || len(d.UpcomingTasks) != 2 {
+		t.Errorf("unexpected dashboard %+v", d)
+	}
+
+	// Límites de las listas (preferencias del usuario)
+	d, err = repo.GetDashboardStats(context.Background(), user, domain.DashboardOptions{UpcomingLimit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.UpcomingTasks) != 1 || d.UpcomingTasks[0].Title != "doing" {
+		t.Errorf("expected only the earliest upcoming task, got %+v", d.UpcomingTasks)
+	}
+}
+
+// testUserDay "hoy" y los rangos de fechas son días calendario en la zona
+// del usuario, con los bordes exactos: inicio incluido, fin excluido
+func testUserDay(t *testing.T, repo ports.TaskRepository) {
+	loc, err := domain.LoadTimeZone("America/Costa_Rica")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := "day-user"
+	opts := domain.DashboardOptions{Location: loc}
+	w := opts.Windows(time.Now())
+	due := func(at time.Time) func(*domain.Task) {
+		return func(t *domain.Task) { t.DueDate = at.UTC() }
+	}
+	create(t, repo,
+		newTask(user, "yesterday", due(w.StartOfDay.Add(-time.Millisecond))),
+		newTask(user, "first", due(w.StartOfDay)),
+		newTask(user, "last", due(w.EndOfDay.Add(-time.Millisecond))),
+		newTask(user, "tomorrow", due(w.EndOfDay)),
+	)
+
+	d, err := repo.GetDashboardStats(context.Background(), user, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(d.TodayTasks))
+	for i, task := range d.TodayTasks {
+		got[i] = task.Title
+	}
+	if strings.Join(got, ",") != "first,last" {
+		t.Errorf("today in %s: got [%s], want [first,last]", loc, strings.Join(got, ","))
+	}
+
+	today, err := repo.DueToday(context.Background(), user, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(today); got != "first,last" {
+		t.Errorf("DueToday in %s: got [%s], want [first,last]", loc, got)
+	}
+
+	// Rango de un día: mismo criterio que filter_requests (fin del día - 1ms)
+	tasks, _ := find(t, repo, domain.TaskFilter{
+		UserID: user, DueDateFrom: w.StartOfDay, DueDateTo: w.EndOfDay.Add(-time.Millisecond), SortBy: "dueDate", Limit: 10,
+	})
+	if got := titles(tasks); got != "first,last" {
+		t.Errorf("due date range: got [%s], want [first,last]", got)
+	}
+}
+
+func testShared(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	owned := newTask("owner", "Proyecto en grupo", func(t *domain.Task) { t.IsGroupWork = true })
+	create(t, repo, owned)
+
+	ana := domain.NewSharedCopy(owned, "ana", domain.RoleEditor, base)
+	beto := domain.NewSharedCopy(owned, "beto", domain.RoleViewer, base.Add(time.Minute))
+	other := newTask("ana", "Propia")
+	create(t, repo, beto, ana, other)
+
+	shared, err := repo.ListShared(ctx, "owner", owned.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shared) != 2 || shared[0].UserID != "ana" || shared[1].UserID != "beto" {
+		t.Fatalf("expected copies of ana and beto by creation, got %+v", shared)
+	}
+	if c := shared[0]; c.SharedTaskID != owned.ID || c.OwnerID != "owner" || c.Role != domain.RoleEditor || c.Title != owned.Title {
+		t.Errorf("copy round trip mismatch: %+v", c)
+	}
+	if shared, _ := repo.ListShared(ctx, "ana", owned.ID); len(shared) != 0 {
+		t.Errorf("ListShared must check the owner, got %+v", shared)
+	}
+
+	// La copia es una tarea más del colaborador, con su propio estado
+	mine, _ := find(t, repo, domain.TaskFilter{UserID: "ana", SortBy: "title", SortOrder: "asc", Limit: 10})
+	if titles(mine) != "Propia,Proyecto en grupo" {
+		t.Errorf("collaborator tasks = %q", titles(mine))
+	}
+	ana.Status = domain.StatusInProgress
+	if err := repo.Update(ctx, ana); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := repo.GetByID(ctx, owned.ID, "owner"); got.Status != domain.StatusTodo {
+		t.Errorf("owner status changed to %s", got.Status)
+	}
+
+	if err := repo.Delete(ctx, beto.ID, "beto"); err != nil {
+		t.Fatal(err)
+	}
+	if shared, _ := repo.ListShared(ctx, "owner", owned.ID); len(shared) != 1 || shared[0].Status != domain.StatusInProgress {
+		t.Errorf("after delete expected only ana in progress, got %+v", shared)
+	}
+}
+
+// testSharedCompleted la sincronización y limpieza de tareas compartidas no
+// aplica las reglas de estado: una copia (o la tarea del dueño) completada o
+// cancelada se sigue actualizando y eliminando con UpdateShared/DeleteShared
+func testSharedCompleted(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	owned := newTask("owner", "Informe final", func(t *domain.Task) {
+		t.IsGroupWork = true
+		t.Status = domain.StatusDone
+	})
+	create(t, repo, owned)
+
+	ana := domain.NewSharedCopy(owned, "ana", domain.RoleEditor, base)
+	ana.Status = domain.StatusDone
+	beto := domain.NewSharedCopy(owned, "beto", domain.RoleViewer, base.Add(time.Minute))
+	beto.Status = domain.StatusCancelled
+	create(t, repo, ana, beto)
+
+	owned.Title = "Informe final (v2)"
+	if err := repo.UpdateShared(ctx, owned); err != nil {
+		t.Fatalf("UpdateShared on a completed owner task: %v", err)
+	}
+	for _, c := range []*domain.Task{ana, beto} {
+		c.CopySharedFields(owned)
+		if err := repo.UpdateShared(ctx, c); err != nil {
+			t.Fatalf("UpdateShared on %s copy: %v", c.Status, err)
+		}
+	}
+	shared, err := repo.ListShared(ctx, "owner", owned.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shared) != 2 || shared[0].Title != owned.Title || shared[0].Status != domain.StatusDone || shared[1].Status != domain.StatusCancelled {
+		t.Fatalf("expected synced copies keeping their status, got %+v", shared)
+	}
+
+	if err := repo.UpdateShared(ctx, &domain.Task{ID: ana.ID, UserID: "beto", Title: "x"}); err == nil {
+		t.Error("UpdateShared must check the user")
+	}
+	if err := repo.DeleteShared(ctx, ana.ID, "beto"); err == nil {
+		t.Error("DeleteShared must check the user")
+	}
+
+	if err := repo.DeleteShared(ctx, ana.ID, "ana"); err != nil {
+		t.Fatalf("DeleteShared on a completed copy: %v", err)
+	}
+	if err := repo.DeleteShared(ctx, owned.ID, "owner"); err != nil {
+		t.Fatalf("DeleteShared on a completed owner task: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, ana.ID, "ana"); err == nil {
+		t.Error("completed copy still exists")
+	}
+	if shared, _ := repo.ListShared(ctx, "owner", owned.ID); len(shared) != 1 || shared[0].UserID != "beto" {
+		t.Errorf("expected only beto left, got %+v", shared)
+	}
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSavedFilterRepository implementa SavedFilterRepository usando MongoDB
+type MongoSavedFilterRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSavedFilterRepository crea una nueva instancia de MongoSavedFilterRepository
+func NewMongoSavedFilterRepository(collection *mongo.Collection) *MongoSavedFilterRepository {
+	return &MongoSavedFilterRepository{
+		collection: collection,
+	}
+}
+
+// Create inserta un nuevo filtro guardado
+func (r *MongoSavedFilterRepository) Create(ctx context.Context, f *domain.SavedFilter) error {
+	if f.ID == "" {
+		f.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, f); err != nil {
+		return fmt.Errorf("error al crear filtro guardado: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene un filtro guardado del usuario
+func (r *MongoSavedFilterRepository) GetByID(ctx context.Context, filterID, userID string) (*domain.SavedFilter, error) {
+	var f domain.SavedFilter
+	err := r.collection.FindOne(ctx, bson.M{"_id": filterID, "userId": userID}).Decode(&f)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSavedFilterNotFound
+		}
+		return nil, fmt.Errorf("error al obtener filtro guardado: %w", err)
+	}
+
+	return &f, nil
+}
+
+// ListByUser lista los filtros del usuario ordenados por fecha de creación
+func (r *MongoSavedFilterRepository) ListByUser(ctx context.Context, userID string) ([]domain.SavedFilter, error) {
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar filtros guardados: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var filters []domain.SavedFilter
+	if err = cursor.All(ctx, &filters); err != nil {
+		return nil, fmt.Errorf("error al decodificar filtros guardados: %w", err)
+	}
+
+	if filters == nil {
+		filters = []domain.SavedFilter{}
+	}
+
+	return filters, nil
+}
+
+// Update reemplaza nombre y parámetros del filtro
+func (r *MongoSavedFilterRepository) Update(ctx context.Context, f *domain.SavedFilter) error {
+	update := bson.M{"$set": bson.M{
+		"name":      f.Name,
+		"params":    f.Params,
+		"updatedAt": f.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": f.ID, "userId": f.UserID}, update)
+	if err != nil {
+		return fmt.Errorf("error al actualizar filtro guardado: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina un filtro guardado del usuario
+func (r *MongoSavedFilterRepository) Delete(ctx context.Context, filterID, userID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": filterID, "userId": userID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar filtro guardado: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/application/ports"
+	"uniflow-api/internal/domain"
+	"uniflow-api/internal/infrastructure/search"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxSearchCandidates tope de tareas que se puntúan en memoria por búsqueda
+const maxSearchCandidates = 2000
+
+// findBySearch busca en dos pasos: Mongo reduce las tareas a candidatas
+// ($text si existe el índice, si no regex insensible a tildes) y
+// search.Rank calcula la relevancia. Si no hay ninguna coincidencia se
+// puntúan las tareas del filtro sin condición de texto, para encontrar
+// términos con errores de tipeo.
+func (r *MongoTaskRepository) findBySearch(ctx context.Context, mongoFilter bson.M, filter ports.TaskFilter) ([]domain.Task, domain.PageInfo, error) {
+	q := search.ParseQuery(filter.Search)
+	if q.Empty() {
+		return search.Paginate(nil, filter, time.Now())
+	}
+
+	candidates, err := r.searchCandidates(ctx, mongoFilter, q)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	hits := search.Rank(candidates, q)
+
+	if len(hits) == 0 {
+		all, err := r.findLimited(ctx, mongoFilter)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+		hits = search.Rank(all, q)
+	}
+
+	return search.Paginate(hits, filter, time.Now())
+}
+
+// searchCandidates tareas que pueden coincidir con la búsqueda
+func (r *MongoTaskRepository) searchCandidates(ctx context.Context, mongoFilter bson.M, q search.Query) ([]domain.Task, error) {
+	if !r.textIndexMissing.Load() {
+		if text := textSearchFilter(q); text != nil {
+			tasks, err := r.findLimited(ctx, bson.M{"$and": bson.A{mongoFilter, text}})
+			if err == nil || !isTextIndexMissing(err) {
+				return tasks, err
+			}
+			r.textIndexMissing.Store(true)
+			log.Printf("⚠️ Índice de texto de tareas no encontrado: la búsqueda usa regex (ver `migrate status`)")
+		}
+	}
+	return r.findLimited(ctx, bson.M{"$and": bson.A{mongoFilter, regexSearchFilter(q)}})
+}
+
+// findLimited tareas del filtro, hasta maxSearchCandidates
+func (r *MongoTaskRepository) findLimited(ctx context.Context, mongoFilter bson.M) ([]domain.Task, error) {
+	cursor, err := r.collection.Find(ctx, mongoFilter, options.Find().SetLimit(maxSearchCandidates))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []domain.Task
+	if err = cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// textSearchFilter condición $text con los términos completos (el índice de
+// texto no sirve para prefijos; esos se filtran con regex). nil si todos
+// los términos son prefijos.
+func textSearchFilter(q search.Query) bson.M {
+	var words []string
+	var prefixes bson.A
+	for _, t := range q.Terms {
+		if t.Prefix {
+			prefixes = append(prefixes, termRegexFilter(t.Text))
+		} else {
+			words = append(words, t.Text)
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	text := bson.M{"$text": bson.M{"$search": strings.Join(words, " "), "$diacriticSensitive": false}}
+	if len(prefixes) == 0 {
+		return text
+	}
+	return bson.M{"$and": append(bson.A{text}, prefixes...)}
+}
+
+// regexSearchFilter exige cada término en title, description o tags
+func regexSearchFilter(q search.Query) bson.M {
+	conds := make(bson.A, len(q.Terms))
+	for i, t := range q.Terms {
+		conds[i] = termRegexFilter(t.Text)
+	}
+	return bson.M{"$and": conds}
+}
+
+func termRegexFilter(term string) bson.M {
+	re := bson.M{"$regex": foldedPattern(term), "$options": "i"}
+	return bson.M{"$or": bson.A{
+		bson.M{"title": re},
+		bson.M{"description": re},
+		bson.M{"tags": re},
+	}}
+}
+
+// accentClasses variantes con tilde de cada letra ya normalizada
+var accentClasses = map[rune]string{
+	'a': "[aáàâäã]",
+	'e': "[eéèêë]",
+	'i': "[iíìîï]",
+	'o': "[oóòôöõ]",
+	'u': "[uúùûü]",
+	'n': "[nñ]",
+	'c': "[cç]",
+}
+
+// foldedPattern regex que encuentra el término (normalizado por
+// search.Fold) con o sin tildes
+func foldedPattern(term string) string {
+	var b strings.Builder
+	for _, r := range term {
+		if class, ok := accentClasses[r]; ok {
+			b.WriteString(class)
+		} else {
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// isTextIndexMissing indica si $text falló porque no existe el índice de texto
+func isTextIndexMissing(err error) bool {
+	var ce mongo.CommandError
+	if errors.As(err, &ce) && ce.Code == 27 { // IndexNotFound
+		return true
+	}
+	return strings.Contains(err.Error(), "text index required")
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoTemplateRepository implementa TemplateRepository usando MongoDB
+type MongoTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoTemplateRepository crea una nueva instancia de MongoTemplateRepository
+func NewMongoTemplateRepository(collection *mongo.Collection) *MongoTemplateRepository {
+	return &MongoTemplateRepository{
+		collection: collection,
+	}
+}
+
+// Create inserta una nueva plantilla
+func (r *MongoTemplateRepository) Create(ctx context.Context, t *domain.TaskTemplate) error {
+	if t.ID == "" {
+		t.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, t); err != nil {
+		return fmt.Errorf("error al crear plantilla: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene una plantilla del usuario
+func (r *MongoTemplateRepository) GetByID(ctx context.Context, templateID, userID string) (*domain.TaskTemplate, error) {
+	var t domain.TaskTemplate
+	err := r.collection.FindOne(ctx, bson.M{"_id": templateID, "userId": userID}).Decode(&t)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("error al obtener plantilla: %w", err)
+	}
+
+	return &t, nil
+}
+
+// ListByUser lista las plantillas del usuario ordenadas por fecha de creación
+func (r *MongoTemplateRepository) ListByUser(ctx context.Context, userID string) ([]domain.TaskTemplate, error) {
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar plantillas: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var templates []domain.TaskTemplate
+	if err = cursor.All(ctx, &templates); err != nil {
+		return nil, fmt.Errorf("error al decodificar plantillas: %w", err)
+	}
+
+	if templates == nil {
+		templates = []domain.TaskTemplate{}
+	}
+
+	return templates, nil
+}
+
+// Update reemplaza el contenido de la plantilla
+func (r *MongoTemplateRepository) Update(ctx context.Context, t *domain.TaskTemplate) error {
+	update := bson.M{"$set": bson.M{
+		"name":               t.Name,
+		"description":        t.Description,
+		"type":               t.Type,
+		"priority":           t.Priority,
+		"estimatedTimeHours": t.EstimatedTimeHours,
+		"tags":               t.Tags,
+		"items":              t.Items,
+		"updatedAt":          t.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": t.ID, "userId": t.UserID}, update)
+	if err != nil {
+		return fmt.Errorf("error al actualizar plantilla: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina una plantilla del usuario
+func (r *MongoTemplateRepository) Delete(ctx context.Context, templateID, userID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": templateID, "userId": userID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar plantilla: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return nil
+}
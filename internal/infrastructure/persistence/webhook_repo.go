@@ -0,0 +1,219 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"uniflow-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWebhookRepository implementa WebhookRepository usando MongoDB
+type MongoWebhookRepository struct {
+	hooks      *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewMongoWebhookRepository crea una nueva instancia de MongoWebhookRepository
+func NewMongoWebhookRepository(hooks, deliveries *mongo.Collection) *MongoWebhookRepository {
+	return &MongoWebhookRepository{
+		hooks:      hooks,
+		deliveries: deliveries,
+	}
+}
+
+// Create inserta un nuevo webhook
+func (r *MongoWebhookRepository) Create(ctx context.Context, hook *domain.Webhook) error {
+	if hook.ID == "" {
+		hook.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.hooks.InsertOne(ctx, hook); err != nil {
+		return fmt.Errorf("error al crear webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene un webhook del dueño indicado
+func (r *MongoWebhookRepository) GetByID(ctx context.Context, hookID, userID string) (*domain.Webhook, error) {
+	var hook domain.Webhook
+	err := r.hooks.FindOne(ctx, bson.M{"_id": hookID, "userId": userID}).Decode(&hook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("error al obtener webhook: %w", err)
+	}
+
+	return &hook, nil
+}
+
+// ListByUser lista los webhooks del dueño indicado
+func (r *MongoWebhookRepository) ListByUser(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return r.find(ctx, bson.M{"userId": userID})
+}
+
+// ListActive lista los webhooks activos del usuario y los de sistema
+func (r *MongoWebhookRepository) ListActive(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return r.find(ctx, bson.M{
+		"active": true,
+		"userId": bson.M{"$in": []string{userID, ""}},
+	})
+}
+
+func (r *MongoWebhookRepository) find(ctx context.Context, filter bson.M) ([]domain.Webhook, error) {
+	opts := options.Find()
+	opts.SetSort(bson.M{"createdAt": 1})
+
+	cursor, err := r.hooks.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var hooks []domain.Webhook
+	if err = cursor.All(ctx, &hooks); err != nil {
+		return nil, fmt.Errorf("error al decodificar webhooks: %w", err)
+	}
+
+	if hooks == nil {
+		hooks = []domain.Webhook{}
+	}
+
+	return hooks, nil
+}
+
+// Update actualiza URL, eventos, descripción y estado del webhook
+func (r *MongoWebhookRepository) Update(ctx context.Context, hook *domain.Webhook) error {
+	filter := bson.M{"_id": hook.ID, "userId": hook.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"url":            hook.URL,
+			"events":         hook.Events,
+			"description":    hook.Description,
+			"active":         hook.Active,
+			"failureCount":   hook.FailureCount,
+			"disabledAt":     hook.DisabledAt,
+			"disabledReason": hook.DisabledReason,
+			"updatedAt":      hook.UpdatedAt,
+		},
+	}
+
+	result, err := r.hooks.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error al actualizar webhook: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina un webhook y su historial de entregas
+func (r *MongoWebhookRepository) Delete(ctx context.Context, hookID, userID string) error {
+	result, err := r.hooks.DeleteOne(ctx, bson.M{"_id": hookID, "userId": userID})
+	if err != nil {
+		return fmt.Errorf("error al eliminar webhook: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhookId": hookID}); err != nil {
+		return fmt.Errorf("error al eliminar entregas del webhook: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFailure incrementa atómicamente los fallos consecutivos
+func (r *MongoWebhookRepository) RecordFailure(ctx context.Context, hookID string) (int, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var hook domain.Webhook
+	err := r.hooks.FindOneAndUpdate(ctx, bson.M{"_id": hookID}, bson.M{"$inc": bson.M{"failureCount": 1}}, opts).Decode(&hook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, domain.ErrWebhookNotFound
+		}
+		return 0, fmt.Errorf("error al registrar fallo del webhook: %w", err)
+	}
+
+	return hook.FailureCount, nil
+}
+
+// ResetFailures pone en cero los fallos consecutivos
+func (r *MongoWebhookRepository) ResetFailures(ctx context.Context, hookID string) error {
+	_, err := r.hooks.UpdateOne(ctx, bson.M{"_id": hookID, "failureCount": bson.M{"$ne": 0}}, bson.M{"$set": bson.M{"failureCount": 0}})
+	if err != nil {
+		return fmt.Errorf("error al reiniciar fallos del webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Disable desactiva el webhook indicando el motivo
+func (r *MongoWebhookRepository) Disable(ctx context.Context, hookID, reason string, at time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"active":         false,
+			"disabledAt":     at,
+			"disabledReason": reason,
+			"updatedAt":      at,
+		},
+	}
+
+	if _, err := r.hooks.UpdateOne(ctx, bson.M{"_id": hookID}, update); err != nil {
+		return fmt.Errorf("error al desactivar webhook: %w", err)
+	}
+
+	return nil
+}
+
+// AddDelivery registra un intento de entrega
+func (r *MongoWebhookRepository) AddDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	if d.ID == "" {
+		d.ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := r.deliveries.InsertOne(ctx, d); err != nil {
+		return fmt.Errorf("error al registrar entrega de webhook: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries lista las entregas más recientes de un webhook
+func (r *MongoWebhookRepository) ListDeliveries(ctx context.Context, hookID string, limit int) ([]domain.WebhookDelivery, error) {
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.deliveries.Find(ctx, bson.M{"webhookId": hookID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar entregas de webhook: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []domain.WebhookDelivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("error al decodificar entregas de webhook: %w", err)
+	}
+
+	if deliveries == nil {
+		deliveries = []domain.WebhookDelivery{}
+	}
+
+	return deliveries, nil
+}
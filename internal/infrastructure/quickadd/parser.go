@@ -0,0 +1,611 @@
+package quickadd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// Options contexto con el que se interpreta el texto
+type Options struct {
+	Now      time.Time
+	Location *time.Location // zona horaria del usuario para fechas relativas
+	Subjects []string       // subjectIds conocidos del usuario
+}
+
+// Match fragmento del texto reconocido como un campo
+type Match struct {
+	Field string `json:"field"` // dueDate, priority, tag, subject
+	Text  string `json:"text"`
+}
+
+// Draft tarea interpretada a partir del texto libre
+type Draft struct {
+	Title     string
+	SubjectID string
+	Type      string
+	Priority  string
+	Tags      []string
+	DueDate   time.Time // cero si no se reconoció fecha ni hora
+	Matches   []Match
+	Warnings  []string
+}
+
+// Task construye la tarea a crear a partir del borrador
+func (d *Draft) Task(userID string, now time.Time) *domain.Task {
+	tags := d.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &domain.Task{
+		UserID:       userID,
+		Title:        d.Title,
+		SubjectID:    d.SubjectID,
+		DueDate:      d.DueDate,
+		Status:       domain.StatusTodo,
+		Priority:     d.Priority,
+		Type:         d.Type,
+		Tags:         tags,
+		IsGroupWork:  d.Type == domain.TypeGroupWork,
+		GroupMembers: []string{},
+		Attachments:  []string{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Hora por defecto cuando solo se reconoce el día
+const (
+	defaultHour   = 23
+	defaultMinute = 59
+)
+
+var (
+	numericDateRe = regexp.MustCompile(`^(\d{1,2})([/.-])(\d{1,2})(?:[/.-](\d{2}|\d{4}))?$`)
+	isoDateRe     = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	clockRe       = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m|p\.m|h|hrs)?$`)
+	dayNumberRe   = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|º|°)?$`)
+)
+
+type token struct {
+	raw  string
+	norm string
+	used bool
+}
+
+// parser estado del parseo de una frase
+type parser struct {
+	opts   Options
+	tokens []token
+	draft  Draft
+
+	today   time.Time // medianoche de hoy en la zona del usuario
+	date    *time.Time
+	exact   *time.Time // "en 2 horas": instante exacto
+	hasTime bool
+	hour    int
+	minute  int
+}
+
+// Parse interpreta una frase en español o inglés como
+// "Parcial de Cálculo viernes 10am prioridad alta #repaso".
+// Reconoce fecha (relativa o explícita) y hora, prioridad, #tags, @materia
+// y la materia mencionada en el título; el resto del texto forma el título.
+func Parse(text string, opts Options) Draft {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	now := opts.Now.In(opts.Location)
+
+	p := &parser{
+		opts:  opts,
+		today: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, opts.Location),
+		draft: Draft{Priority: domain.PriorityMedium},
+	}
+	for _, raw := range strings.Fields(text) {
+		p.tokens = append(p.tokens, token{raw: raw, norm: fold(raw)})
+	}
+
+	p.parseMarkers()
+	p.parsePriority()
+	p.parseDates()
+	p.parseTimes()
+
+	p.draft.Title = p.title()
+	if p.draft.SubjectID == "" {
+		p.matchSubject()
+	}
+	p.draft.Type = domain.InferTaskType(p.draft.Title)
+	p.draft.DueDate = p.dueDate()
+
+	if p.draft.Title == "" {
+		p.warn("no quedó texto para el título")
+	}
+	if p.draft.DueDate.IsZero() {
+		p.warn("no se reconoció una fecha de entrega")
+	}
+	return p.draft
+}
+
+// consume marca tokens [i, i+n) como reconocidos, junto con los conectores previos
+func (p *parser) consume(i, n int, field string) {
+	parts := make([]string, 0, n)
+	for k := i; k < i+n; k++ {
+		p.tokens[k].used = true
+		parts = append(parts, p.tokens[k].raw)
+	}
+	p.draft.Matches = append(p.draft.Matches, Match{Field: field, Text: strings.Join(parts, " ")})
+
+	if field != "dueDate" {
+		return
+	}
+	for k := i - 1; k >= 0 && !p.tokens[k].used && connectors[p.tokens[k].norm]; k-- {
+		p.tokens[k].used = true
+	}
+}
+
+func (p *parser) free(i int) bool {
+	return i >= 0 && i < len(p.tokens) && !p.tokens[i].used
+}
+
+func (p *parser) norm(i int) string {
+	if !p.free(i) {
+		return ""
+	}
+	return p.tokens[i].norm
+}
+
+// prev palabra anterior a i (reconocida o no)
+func (p *parser) prev(i int) string {
+	if i <= 0 || i > len(p.tokens) {
+		return ""
+	}
+	return p.tokens[i-1].norm
+}
+
+func (p *parser) warn(format string, args ...interface{}) {
+	p.draft.Warnings = append(p.draft.Warnings, fmt.Sprintf(format, args...))
+}
+
+// parseMarkers #tags y @materia explícita
+func (p *parser) parseMarkers() {
+	for i := range p.tokens {
+		raw := strings.TrimRight(p.tokens[i].raw, ",.;:")
+		switch {
+		case len(raw) > 1 && raw[0] == '#':
+			p.draft.Tags = append(p.draft.Tags, raw[1:])
+			p.consume(i, 1, "tag")
+		case len(raw) > 1 && raw[0] == '@':
+			p.draft.SubjectID = p.resolveSubject(raw[1:])
+			p.consume(i, 1, "subject")
+		}
+	}
+}
+
+// resolveSubject busca la materia explícita entre las conocidas;
+// si no existe se usa tal cual (materia nueva)
+func (p *parser) resolveSubject(name string) string {
+	want := fold(name)
+	for _, s := range p.opts.Subjects {
+		if fold(s) == want {
+			return s
+		}
+	}
+	for _, s := range p.opts.Subjects {
+		if strings.HasPrefix(fold(s), want) {
+			return s
+		}
+	}
+	return name
+}
+
+// parsePriority "prioridad alta", "priority high", "!alta", "urgente"
+func (p *parser) parsePriority() {
+	for i := range p.tokens {
+		n := p.norm(i)
+		switch {
+		case n == "prioridad" || n == "priority":
+			if v, ok := priorityWords[p.norm(i+1)]; ok {
+				p.draft.Priority = v
+				p.consume(i, 2, "priority")
+			}
+		case strings.HasPrefix(n, "!"):
+			if v, ok := priorityWords[strings.TrimLeft(n, "!")]; ok {
+				p.draft.Priority = v
+				p.consume(i, 1, "priority")
+			}
+		case n == "urgente" || n == "urgent":
+			p.draft.Priority = domain.PriorityUrgent
+			p.consume(i, 1, "priority")
+		}
+	}
+}
+
+// parseDates reconoce la primera fecha del texto
+func (p *parser) parseDates() {
+	for i := 0; i < len(p.tokens) && p.date == nil && p.exact == nil; i++ {
+		if !p.free(i) {
+			continue
+		}
+		if n, d, ok := p.relativeDate(i); ok {
+			if !d.IsZero() {
+				p.setDate(d)
+			}
+			p.consume(i, n, "dueDate")
+			continue
+		}
+		if n, d, ok := p.calendarDate(i); ok {
+			p.setDate(d)
+			p.consume(i, n, "dueDate")
+		}
+	}
+}
+
+func (p *parser) setDate(d time.Time) {
+	p.date = &d
+}
+
+// relativeDate hoy, mañana, pasado mañana, viernes, próxima semana, en 3 días
+func (p *parser) relativeDate(i int) (int, time.Time, bool) {
+	n0, n1, n2 := p.norm(i), p.norm(i+1), p.norm(i+2)
+
+	switch {
+	case n0 == "pasado" && n1 == "manana":
+		return 2, p.today.AddDate(0, 0, 2), true
+	case n0 == "day" && n1 == "after" && n2 == "tomorrow":
+		return 3, p.today.AddDate(0, 0, 2), true
+	case n0 == "hoy" || n0 == "today":
+		return 1, p.today, true
+	case n0 == "tonight":
+		p.setTimeDefault(20, 0)
+		return 1, p.today, true
+	case n0 == "esta" && n1 == "noche":
+		p.setTimeDefault(20, 0)
+		return 2, p.today, true
+	case n0 == "manana" || n0 == "tomorrow":
+		// "en la mañana" / "por la mañana" es la mañana del día, no mañana
+		if prev := p.norm(i - 1); prev == "la" {
+			return 0, time.Time{}, false
+		}
+		return 1, p.today.AddDate(0, 0, 1), true
+	case (n0 == "proxima" && n1 == "semana") || (n0 == "next" && n1 == "week"):
+		return 2, p.nextWeekday(time.Monday), true
+	case n0 == "semana" && n1 == "que" && n2 == "viene":
+		return 3, p.nextWeekday(time.Monday), true
+	}
+
+	if wd, ok := weekdays[n0]; ok && !p.monthWithDay(i) {
+		return 1, p.nextWeekday(wd), true
+	}
+
+	// "en 3 días", "in 2 weeks", "en 2 horas"
+	if n0 == "en" || n0 == "in" {
+		count, ok := parseCount(n1)
+		if !ok {
+			return 0, time.Time{}, false
+		}
+		switch n2 {
+		case "dia", "dias", "day", "days":
+			return 3, p.today.AddDate(0, 0, count), true
+		case "semana", "semanas", "week", "weeks":
+			return 3, p.today.AddDate(0, 0, 7*count), true
+		case "hora", "horas", "hour", "hours":
+			exact := p.opts.Now.In(p.opts.Location).Add(time.Duration(count) * time.Hour)
+			p.exact = &exact
+			return 3, time.Time{}, true
+		}
+	}
+	return 0, time.Time{}, false
+}
+
+// monthWithDay indica si el token i es un mes seguido del día ("mar 15"):
+// entonces "mar" es marzo y no martes
+func (p *parser) monthWithDay(i int) bool {
+	if _, ok := months[p.norm(i)]; !ok {
+		return false
+	}
+	_, ok := dayNumber(p.norm(i + 1))
+	return ok
+}
+
+// nextWeekday próxima ocurrencia del día (nunca hoy)
+func (p *parser) nextWeekday(wd time.Weekday) time.Time {
+	days := (int(wd) - int(p.today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return p.today.AddDate(0, 0, days)
+}
+
+// calendarDate 15/11, 15/11/2025, 2025-11-15, 15 de noviembre, nov 15
+func (p *parser) calendarDate(i int) (int, time.Time, bool) {
+	n0 := p.norm(i)
+
+	if m := isoDateRe.FindStringSubmatch(n0); m != nil {
+		y, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		d, _ := strconv.Atoi(m[3])
+		if t, ok := p.makeDate(y, mo, d); ok {
+			return 1, t, true
+		}
+	}
+
+	if m := numericDateRe.FindStringSubmatch(n0); m != nil {
+		// "cap 4-5" o "ejercicios 3.2" no son fechas: con - o . hace falta el
+		// año o una palabra de fecha antes ("para el 4-5", "due 4.5")
+		if m[2] != "/" && m[4] == "" && !dateKeywords[p.prev(i)] {
+			return 0, time.Time{}, false
+		}
+		// Día primero (es); si el segundo número no puede ser mes, se asume mm/dd (en)
+		d, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[3])
+		if mo > 12 && d <= 12 {
+			d, mo = mo, d
+		}
+		y := 0
+		if m[4] != "" {
+			y, _ = strconv.Atoi(m[4])
+			if y < 100 {
+				y += 2000
+			}
+		}
+		if t, ok := p.makeDate(y, mo, d); ok {
+			return 1, t, true
+		}
+		return 0, time.Time{}, false
+	}
+
+	// "15 de noviembre [de 2025]" / "15 nov"
+	if day, ok := dayNumber(n0); ok {
+		j := i + 1
+		if p.norm(j) == "de" {
+			j++
+		}
+		if mo, ok := months[p.norm(j)]; ok {
+			n, y := p.optionalYear(j + 1)
+			if t, ok := p.makeDate(y, int(mo), day); ok {
+				return j + 1 - i + n, t, true
+			}
+		}
+	}
+
+	// "noviembre 15" / "nov 15th, 2025"
+	if mo, ok := months[n0]; ok {
+		if day, ok := dayNumber(p.norm(i + 1)); ok {
+			n, y := p.optionalYear(i + 2)
+			if t, ok := p.makeDate(y, int(mo), day); ok {
+				return 2 + n, t, true
+			}
+		}
+	}
+	return 0, time.Time{}, false
+}
+
+// optionalYear lee "2025" o "de 2025" a partir de j
+func (p *parser) optionalYear(j int) (int, int) {
+	n := 0
+	if p.norm(j) == "de" {
+		n, j = 1, j+1
+	}
+	if y, err := strconv.Atoi(p.norm(j)); err == nil && y >= 2000 && y < 2100 {
+		return n + 1, y
+	}
+	return 0, 0
+}
+
+// makeDate valida la fecha; sin año usa la próxima ocurrencia
+func (p *parser) makeDate(y, mo, d int) (time.Time, bool) {
+	if mo < 1 || mo > 12 || d < 1 || d > 31 {
+		return time.Time{}, false
+	}
+	explicitYear := y != 0
+	if !explicitYear {
+		y = p.today.Year()
+	}
+	t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, p.opts.Location)
+	if t.Day() != d {
+		return time.Time{}, false // 31/02
+	}
+	if !explicitYear && t.Before(p.today) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t, true
+}
+
+// parseTimes 10am, 10:30, 3 pm, 15h, "a las 4", "at 4", mediodía
+func (p *parser) parseTimes() {
+	for i := 0; i < len(p.tokens) && !p.hasTime; i++ {
+		n0, n1 := p.norm(i), p.norm(i+1)
+		switch {
+		case n0 == "":
+			continue
+		case n0 == "mediodia" || n0 == "noon":
+			p.setTime(12, 0)
+			p.consume(i, 1, "dueDate")
+			continue
+		case n0 == "medianoche" || n0 == "midnight":
+			p.setTime(23, 59)
+			p.consume(i, 1, "dueDate")
+			continue
+		}
+
+		m := clockRe.FindStringSubmatch(n0)
+		if m == nil {
+			continue
+		}
+		h, _ := strconv.Atoi(m[1])
+		minute := 0
+		if m[2] != "" {
+			minute, _ = strconv.Atoi(m[2])
+		}
+		suffix, n := m[3], 1
+		if suffix == "" && (n1 == "am" || n1 == "pm" || n1 == "a.m" || n1 == "p.m") {
+			suffix, n = n1, 2
+		}
+
+		// Un número suelto solo es hora después de "a las" / "at"
+		prev := p.norm(i - 1)
+		bare := suffix == "" && m[2] == ""
+		if bare && prev != "las" && prev != "at" {
+			continue
+		}
+		// "2h" suele ser duración, no hora
+		if (suffix == "h" || suffix == "hrs") && m[2] == "" && h < 7 {
+			continue
+		}
+
+		switch suffix {
+		case "pm", "p.m":
+			if h < 12 {
+				h += 12
+			}
+		case "am", "a.m":
+			if h == 12 {
+				h = 0
+			}
+		case "":
+			// "a las 3" en contexto académico es de la tarde
+			if bare && h >= 1 && h <= 7 {
+				h += 12
+			}
+		}
+		if h > 23 || minute > 59 {
+			continue
+		}
+
+		p.setTime(h, minute)
+		p.consume(i, n, "dueDate")
+	}
+}
+
+func (p *parser) setTime(h, m int) {
+	p.hasTime, p.hour, p.minute = true, h, m
+}
+
+// setTimeDefault fija la hora solo si el texto no trae una explícita
+func (p *parser) setTimeDefault(h, m int) {
+	if !p.hasTime {
+		p.hour, p.minute = h, m
+	}
+}
+
+// dueDate combina fecha y hora reconocidas
+func (p *parser) dueDate() time.Time {
+	if p.exact != nil {
+		return *p.exact
+	}
+	h, m := defaultHour, defaultMinute
+	if p.hasTime || p.hour != 0 {
+		h, m = p.hour, p.minute
+	}
+
+	switch {
+	case p.date != nil:
+		return time.Date(p.date.Year(), p.date.Month(), p.date.Day(), h, m, 0, 0, p.opts.Location)
+	case p.hasTime:
+		// Solo hora: hoy si aún no pasó, si no mañana
+		t := time.Date(p.today.Year(), p.today.Month(), p.today.Day(), h, m, 0, 0, p.opts.Location)
+		if !t.After(p.opts.Now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// title une el texto no reconocido, sin conectores sueltos al final
+func (p *parser) title() string {
+	var parts []string
+	for _, t := range p.tokens {
+		if !t.used {
+			parts = append(parts, t.raw)
+		}
+	}
+	for len(parts) > 0 && connectors[fold(parts[len(parts)-1])] {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.TrimRight(strings.Join(parts, " "), " ,;:-")
+}
+
+// matchSubject busca en el título una palabra que coincida con una materia conocida.
+// "Cálculo" coincide con "calculo-1"; "progra" con "programacion".
+func (p *parser) matchSubject() {
+	if len(p.opts.Subjects) == 0 {
+		p.warn("no se indicó la materia (usa @materia o subjectId)")
+		return
+	}
+
+	titleWords := alnumWords(p.draft.Title)
+	best, bestScore, tied := "", 0, []string{}
+	for _, s := range p.opts.Subjects {
+		score := subjectScore(titleWords, alnumWords(s))
+		switch {
+		case score == 0:
+		case score > bestScore:
+			best, bestScore, tied = s, score, []string{s}
+		case score == bestScore:
+			tied = append(tied, s)
+		}
+	}
+
+	switch {
+	case bestScore == 0:
+		p.warn("no se reconoció la materia (usa @materia o subjectId)")
+	case len(tied) > 1:
+		p.warn("materia ambigua: %s", strings.Join(tied, ", "))
+	default:
+		p.draft.SubjectID = best
+		p.draft.Matches = append(p.draft.Matches, Match{Field: "subject", Text: best})
+	}
+}
+
+// subjectScore largo de la mejor coincidencia entre palabras (0 si ninguna)
+func subjectScore(titleWords, subjectWords []string) int {
+	best := 0
+	for _, sw := range subjectWords {
+		if len(sw) < 3 || isNumber(sw) {
+			continue
+		}
+		for _, tw := range titleWords {
+			short, long := tw, sw
+			if len(short) > len(long) {
+				short, long = long, short
+			}
+			if short == long || (len(short) >= 4 && strings.HasPrefix(long, short)) {
+				if len(short) > best {
+					best = len(short)
+				}
+			}
+		}
+	}
+	return best
+}
+
+func parseCount(s string) (int, bool) {
+	if n, ok := numberWords[s]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n > 0 && n <= 365
+}
+
+func dayNumber(s string) (int, bool) {
+	m := dayNumberRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	d, _ := strconv.Atoi(m[1])
+	return d, d >= 1 && d <= 31
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
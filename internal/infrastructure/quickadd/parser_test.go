@@ -0,0 +1,112 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+)
+
+// Lunes 6 de octubre de 2025, 09:00 en Costa Rica
+var (
+	testLoc, _ = time.LoadLocation("America/Costa_Rica")
+	testNow    = time.Date(2025, 10, 6, 9, 0, 0, 0, testLoc)
+	testOpts   = Options{Now: testNow, Location: testLoc, Subjects: []string{"calculo-1", "fisica-2", "programacion"}}
+)
+
+func TestParseSpanish(t *testing.T) {
+	d := Parse("Parcial de Cálculo viernes 10am prioridad alta #repaso", testOpts)
+
+	if d.Title != "Parcial de Cálculo" {
+		t.Errorf("title = %q", d.Title)
+	}
+	if d.Type != "exam" || d.Priority != "high" || d.SubjectID != "calculo-1" {
+		t.Errorf("type/priority/subject = %s/%s/%s", d.Type, d.Priority, d.SubjectID)
+	}
+	if len(d.Tags) != 1 || d.Tags[0] != "repaso" {
+		t.Errorf("tags = %v", d.Tags)
+	}
+	want := time.Date(2025, 10, 10, 10, 0, 0, 0, testLoc)
+	if !d.DueDate.Equal(want) {
+		t.Errorf("due = %v, want %v", d.DueDate, want)
+	}
+	if len(d.Warnings) != 0 {
+		t.Errorf("unexpected warnings %v", d.Warnings)
+	}
+}
+
+func TestParseEnglish(t *testing.T) {
+	d := Parse("Physics lab report due tomorrow at 3 @fisica !urgent", testOpts)
+
+	if d.Title != "Physics lab report" || d.SubjectID != "fisica-2" || d.Priority != "urgent" {
+		t.Errorf("unexpected draft %+v", d)
+	}
+	want := time.Date(2025, 10, 7, 15, 0, 0, 0, testLoc)
+	if !d.DueDate.Equal(want) {
+		t.Errorf("due = %v, want %v", d.DueDate, want)
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	cases := []struct {
+		text string
+		want time.Time
+	}{
+		{"Tarea hoy", time.Date(2025, 10, 6, 23, 59, 0, 0, testLoc)},
+		{"Tarea pasado mañana", time.Date(2025, 10, 8, 23, 59, 0, 0, testLoc)},
+		{"Tarea lunes", time.Date(2025, 10, 13, 23, 59, 0, 0, testLoc)}, // hoy es lunes: el próximo
+		{"Tarea en 3 días", time.Date(2025, 10, 9, 23, 59, 0, 0, testLoc)},
+		{"Essay in 2 weeks", time.Date(2025, 10, 20, 23, 59, 0, 0, testLoc)},
+		{"Tarea 15/11 a las 14:30", time.Date(2025, 11, 15, 14, 30, 0, 0, testLoc)},
+		{"Tarea 15 de marzo", time.Date(2026, 3, 15, 23, 59, 0, 0, testLoc)}, // ya pasó este año
+		{"Essay nov 3rd 5pm", time.Date(2025, 11, 3, 17, 0, 0, 0, testLoc)},
+		{"Tarea 2025-12-01", time.Date(2025, 12, 1, 23, 59, 0, 0, testLoc)},
+		{"Tarea 8am", time.Date(2025, 10, 7, 8, 0, 0, 0, testLoc)}, // ya pasó hoy
+		{"Tarea mediodía", time.Date(2025, 10, 6, 12, 0, 0, 0, testLoc)},
+		{"Tarea para el 4-11", time.Date(2025, 11, 4, 23, 59, 0, 0, testLoc)},
+		{"Essay due 4.11", time.Date(2025, 11, 4, 23, 59, 0, 0, testLoc)},
+		{"Tarea 4-11-2025", time.Date(2025, 11, 4, 23, 59, 0, 0, testLoc)},
+		{"Parcial vie 10am", time.Date(2025, 10, 10, 10, 0, 0, 0, testLoc)},
+		{"Quiz mié", time.Date(2025, 10, 8, 23, 59, 0, 0, testLoc)},
+		{"Lectura sáb", time.Date(2025, 10, 11, 23, 59, 0, 0, testLoc)},
+		{"Tarea mar", time.Date(2025, 10, 7, 23, 59, 0, 0, testLoc)},
+		{"Tarea mar 15", time.Date(2026, 3, 15, 23, 59, 0, 0, testLoc)}, // marzo, no martes
+	}
+	for _, c := range cases {
+		d := Parse(c.text, testOpts)
+		if !d.DueDate.Equal(c.want) {
+			t.Errorf("%q: due = %v, want %v", c.text, d.DueDate, c.want)
+		}
+		if d.Title == "" {
+			t.Errorf("%q: empty title", c.text)
+		}
+	}
+}
+
+func TestParseKeepsNumbersInTitle(t *testing.T) {
+	d := Parse("Lab 3 de Progra para el jueves", testOpts)
+	if d.Title != "Lab 3 de Progra" || d.SubjectID != "programacion" || d.Type != "lab" {
+		t.Errorf("unexpected draft %+v", d)
+	}
+}
+
+func TestParseSectionNumbersAreNotDates(t *testing.T) {
+	for _, text := range []string{"Leer cap 4-5", "Ejercicios sección 3.2"} {
+		d := Parse(text, testOpts)
+		if d.Title != text || !d.DueDate.IsZero() {
+			t.Errorf("%q: expected no date and the full title, got %+v", text, d)
+		}
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	d := Parse("Estudiar algo", testOpts)
+	if d.SubjectID != "" || !d.DueDate.IsZero() || len(d.Warnings) != 2 {
+		t.Errorf("expected subject and date warnings, got %+v", d)
+	}
+
+	// Materias ambiguas no se asignan
+	opts := testOpts
+	opts.Subjects = []string{"quimica-1", "quimica-2"}
+	if d := Parse("Quiz de química mañana", opts); d.SubjectID != "" || len(d.Warnings) != 1 {
+		t.Errorf("expected ambiguous subject warning, got %+v", d)
+	}
+}
@@ -0,0 +1,87 @@
+package quickadd
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// connectors palabras que acompañan a una fecha u hora ("para el viernes",
+// "due on friday") y se eliminan del título junto con ella
+var connectors = map[string]bool{
+	"el": true, "la": true, "las": true, "los": true, "a": true, "al": true,
+	"de": true, "del": true, "para": true, "antes": true, "hasta": true,
+	"este": true, "esta": true, "proximo": true, "proxima": true,
+	"on": true, "at": true, "by": true, "due": true, "for": true, "the": true,
+	"this": true, "next": true, "before": true, "until": true,
+}
+
+// dateKeywords palabras que anuncian una fecha; habilitan "4-5" y "4.5"
+// como fecha sin año ("para el 4-5", "due on 4.5")
+var dateKeywords = map[string]bool{
+	"el": true, "on": true, "due": true,
+}
+
+// weekdays nombres y abreviaturas ("mar" también es marzo: ver monthWithDay)
+var weekdays = map[string]time.Weekday{
+	"domingo": time.Sunday, "lunes": time.Monday, "martes": time.Tuesday,
+	"miercoles": time.Wednesday, "jueves": time.Thursday, "viernes": time.Friday,
+	"sabado": time.Saturday, "dom": time.Sunday, "lun": time.Monday, "mar": time.Tuesday,
+	"mie": time.Wednesday, "jue": time.Thursday, "vie": time.Friday, "sab": time.Saturday,
+	"sunday": time.Sunday,
+	"monday": time.Monday, "tuesday": time.Tuesday,
+	"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+	"saturday": time.Saturday, "mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday,
+}
+
+// months nombres y abreviaturas; solo se reconocen junto a un número de día
+var months = map[string]time.Month{
+	"enero": time.January, "febrero": time.February, "marzo": time.March,
+	"abril": time.April, "mayo": time.May, "junio": time.June, "julio": time.July,
+	"agosto": time.August, "septiembre": time.September, "setiembre": time.September,
+	"octubre": time.October, "noviembre": time.November, "diciembre": time.December,
+	"january": time.January, "february": time.February, "march": time.March,
+	"april": time.April, "may": time.May, "june": time.June, "july": time.July,
+	"august": time.August, "september": time.September, "october": time.October,
+	"november": time.November, "december": time.December,
+	"ene": time.January, "jan": time.January, "feb": time.February, "mar": time.March,
+	"abr": time.April, "apr": time.April, "jun": time.June, "jul": time.July,
+	"ago": time.August, "aug": time.August, "sep": time.September, "sept": time.September,
+	"set": time.September, "oct": time.October, "nov": time.November,
+	"dic": time.December, "dec": time.December,
+}
+
+var numberWords = map[string]int{
+	"un": 1, "una": 1, "uno": 1, "a": 1, "an": 1, "one": 1,
+	"dos": 2, "two": 2, "tres": 3, "three": 3, "cuatro": 4, "four": 4,
+	"cinco": 5, "five": 5, "seis": 6, "six": 6, "siete": 7, "seven": 7,
+}
+
+var priorityWords = map[string]string{
+	"baja": "low", "low": "low",
+	"media": "medium", "medium": "medium", "normal": "medium",
+	"alta": "high", "high": "high",
+	"urgente": "urgent", "urgent": "urgent",
+}
+
+// accents mapa de letras acentuadas a su forma base
+var accents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"Á", "a", "É", "e", "Í", "i", "Ó", "o", "Ú", "u", "Ü", "u", "Ñ", "n",
+)
+
+// fold normaliza una palabra: minúsculas, sin tildes ni puntuación final
+func fold(s string) string {
+	s = strings.ToLower(accents.Replace(s))
+	return strings.TrimRightFunc(s, func(r rune) bool {
+		return r == ',' || r == '.' || r == ';' || r == ':' || r == '!' || r == '?' || r == ')'
+	})
+}
+
+// alnumWords divide un texto normalizado en palabras alfanuméricas
+func alnumWords(s string) []string {
+	return strings.FieldsFunc(fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
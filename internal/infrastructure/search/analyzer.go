@@ -0,0 +1,132 @@
+// Package search implementa la búsqueda de texto de tareas: tokenización
+// insensible a tildes (español/inglés), coincidencias exactas, por prefijo y
+// aproximadas, ranking por relevancia y fragmentos resaltados. Lo usan el
+// repositorio en memoria (con su índice invertido) y Mongo (para reordenar
+// los candidatos que devuelve la base).
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token palabra normalizada con su posición (en bytes) en el texto original
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// foldRune quita tildes y diéresis y pasa a minúscula (una runa por runa,
+// para conservar las posiciones del texto original)
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	switch r {
+	case 'á', 'à', 'â', 'ä', 'ã':
+		return 'a'
+	case 'é', 'è', 'ê', 'ë':
+		return 'e'
+	case 'í', 'ì', 'î', 'ï':
+		return 'i'
+	case 'ó', 'ò', 'ô', 'ö', 'õ':
+		return 'o'
+	case 'ú', 'ù', 'û', 'ü':
+		return 'u'
+	case 'ñ':
+		return 'n'
+	case 'ç':
+		return 'c'
+	}
+	return r
+}
+
+// Fold normaliza un texto completo (minúsculas, sin tildes)
+func Fold(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+// stopwords palabras sin valor de búsqueda en español e inglés
+var stopwords = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "el": true, "los": true, "lo": true,
+	"un": true, "una": true, "unos": true, "unas": true, "y": true, "o": true, "e": true,
+	"en": true, "con": true, "para": true, "por": true, "al": true, "que": true, "se": true,
+	"su": true, "sus": true, "a": true, "the": true, "an": true, "of": true, "and": true,
+	"or": true, "to": true, "in": true, "on": true, "for": true, "with": true, "at": true,
+	"is": true, "it": true,
+}
+
+// Tokenize divide el texto en palabras alfanuméricas normalizadas y sin
+// stopwords. Cada término pasa por stem, así "Exámenes" y "examen" coinciden.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	var b strings.Builder
+	start := -1
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		term := b.String()
+		if !stopwords[term] {
+			tokens = append(tokens, Token{Term: stem(term), Start: start, End: end})
+		}
+		b.Reset()
+		start = -1
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if (r == '\'' || r == '’') && start >= 0 {
+			// Apóstrofo dentro de la palabra ("student's" → "students")
+			i += size
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			b.WriteRune(foldRune(r))
+		} else {
+			flush(i)
+		}
+		i += size
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+// Terms solo los términos de Tokenize
+func Terms(text string) []string {
+	tokens := Tokenize(text)
+	terms := make([]string, len(tokens))
+	for i, t := range tokens {
+		terms[i] = t.Term
+	}
+	return terms
+}
+
+// stem reducción ligera de plurales: "examenes" → "examen", "tareas" → "tarea",
+// "tasks" → "task". No pretende ser un stemmer completo: el prefijo y la
+// coincidencia aproximada cubren el resto.
+func stem(term string) string {
+	n := len(term)
+	if n <= 3 {
+		return term
+	}
+	if strings.HasSuffix(term, "es") && n > 4 {
+		switch term[n-3] {
+		case 'n', 'l', 'r', 'd', 'j', 'z':
+			return term[:n-2]
+		}
+	}
+	if term[n-1] == 's' {
+		switch term[n-2] {
+		case 's', 'u', 'i':
+			return term
+		}
+		return term[:n-1]
+	}
+	return term
+}
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"uniflow-api/internal/domain"
+)
+
+// Marcas de resaltado (el resto del texto se escapa como HTML)
+const (
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+	ellipsis  = "…"
+)
+
+// SnippetLength largo aproximado (en caracteres) de los fragmentos de la descripción
+const SnippetLength = 160
+
+// Highlights fragmentos resaltados de los campos donde hubo coincidencias
+// (title, description, tags)
+func Highlights(t *domain.Task, q Query) map[string]string {
+	out := make(map[string]string)
+	if s, ok := Highlight(t.Title, q, 0); ok {
+		out["title"] = s
+	}
+	if s, ok := Highlight(t.Description, q, SnippetLength); ok {
+		out["description"] = s
+	}
+	if s, ok := Highlight(strings.Join(t.Tags, ", "), q, 0); ok {
+		out["tags"] = s
+	}
+	return out
+}
+
+// Highlight envuelve en <mark> las palabras de text que coinciden con la
+// búsqueda. Con maxLen > 0 recorta una ventana alrededor de la primera
+// coincidencia. ok es false si no hubo ninguna.
+func Highlight(text string, q Query, maxLen int) (string, bool) {
+	var matches []Token
+	for _, tok := range Tokenize(text) {
+		if q.matchesAny(tok.Term) {
+			matches = append(matches, tok)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	start, end := 0, len(text)
+	if maxLen > 0 && utf8.RuneCountInString(text) > maxLen {
+		start, end = window(text, matches[0].Start, maxLen)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+	pos := start
+	for _, m := range matches {
+		if m.Start < start {
+			continue
+		}
+		if m.End > end {
+			break
+		}
+		b.WriteString(html.EscapeString(text[pos:m.Start]))
+		b.WriteString(markOpen)
+		b.WriteString(html.EscapeString(text[m.Start:m.End]))
+		b.WriteString(markClose)
+		pos = m.End
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString(ellipsis)
+	}
+	return b.String(), true
+}
+
+// window límites (en bytes) de un fragmento de unos maxLen caracteres que
+// empieza un poco antes de la posición at, ajustados a límites de palabra
+func window(text string, at, maxLen int) (int, int) {
+	// Retroceder hasta una cuarta parte del fragmento como contexto
+	start := at
+	for n := 0; start > 0 && n < maxLen/4; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	if start > 0 {
+		if i := strings.IndexByte(text[start:at], ' '); i >= 0 {
+			start += i + 1
+		}
+	}
+
+	end := start
+	for n := 0; end < len(text) && n < maxLen; n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	if end < len(text) {
+		if i := strings.LastIndexByte(text[at:end], ' '); i > 0 {
+			end = at + i
+		}
+	}
+	return start, end
+}
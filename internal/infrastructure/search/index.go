@@ -0,0 +1,169 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"uniflow-api/internal/domain"
+)
+
+// Index índice invertido término → tareas para el repositorio en memoria,
+// separado por usuario: una búsqueda solo recorre el vocabulario de quien
+// busca. No es seguro para uso concurrente: el repositorio lo protege con su lock.
+type Index struct {
+	users map[string]*userIndex // UserID → índice
+	owner map[string]string     // ID → UserID (para quitar)
+}
+
+// userIndex índice de las tareas de un usuario
+type userIndex struct {
+	postings map[string]map[string]struct{}
+	terms    []string                    // términos ordenados, para buscar por prefijo
+	byLen    map[int]map[string]struct{} // largo en runas → términos, para los aproximados
+	docs     map[string][]string         // ID → términos indexados (para quitar)
+}
+
+// NewIndex crea un índice vacío
+func NewIndex() *Index {
+	return &Index{
+		users: make(map[string]*userIndex),
+		owner: make(map[string]string),
+	}
+}
+
+// Add indexa la tarea (reemplaza la versión anterior si existía)
+func (ix *Index) Add(t *domain.Task) {
+	ix.Remove(t.ID)
+	u, ok := ix.users[t.UserID]
+	if !ok {
+		u = &userIndex{
+			postings: make(map[string]map[string]struct{}),
+			byLen:    make(map[int]map[string]struct{}),
+			docs:     make(map[string][]string),
+		}
+		ix.users[t.UserID] = u
+	}
+	u.add(t.ID, newDocument(t).all())
+	ix.owner[t.ID] = t.UserID
+}
+
+// Remove quita la tarea del índice
+func (ix *Index) Remove(id string) {
+	userID, ok := ix.owner[id]
+	if !ok {
+		return
+	}
+	u := ix.users[userID]
+	u.remove(id)
+	if len(u.docs) == 0 {
+		delete(ix.users, userID)
+	}
+	delete(ix.owner, id)
+}
+
+// Candidates IDs de las tareas del usuario que contienen todos los términos
+// de la búsqueda (exactos, por prefijo o aproximados). El orden final lo da Rank.
+func (ix *Index) Candidates(userID string, q Query) map[string]struct{} {
+	u, ok := ix.users[userID]
+	if !ok {
+		return make(map[string]struct{})
+	}
+
+	var result map[string]struct{}
+	for _, term := range q.Terms {
+		matched := make(map[string]struct{})
+		for _, docTerm := range u.matching(term) {
+			for id := range u.postings[docTerm] {
+				if result == nil {
+					matched[id] = struct{}{}
+				} else if _, ok := result[id]; ok {
+					matched[id] = struct{}{}
+				}
+			}
+		}
+		result = matched
+		if len(result) == 0 {
+			break
+		}
+	}
+	if result == nil {
+		result = make(map[string]struct{})
+	}
+	return result
+}
+
+func (u *userIndex) add(id string, terms []string) {
+	for _, term := range terms {
+		ids, ok := u.postings[term]
+		if !ok {
+			ids = make(map[string]struct{})
+			u.postings[term] = ids
+			i := sort.SearchStrings(u.terms, term)
+			u.terms = append(u.terms, "")
+			copy(u.terms[i+1:], u.terms[i:])
+			u.terms[i] = term
+
+			n := utf8.RuneCountInString(term)
+			if u.byLen[n] == nil {
+				u.byLen[n] = make(map[string]struct{})
+			}
+			u.byLen[n][term] = struct{}{}
+		}
+		ids[id] = struct{}{}
+	}
+	u.docs[id] = terms
+}
+
+func (u *userIndex) remove(id string) {
+	for _, term := range u.docs[id] {
+		if ids, ok := u.postings[term]; ok {
+			delete(ids, id)
+			if len(ids) == 0 {
+				delete(u.postings, term)
+				if i := sort.SearchStrings(u.terms, term); i < len(u.terms) && u.terms[i] == term {
+					u.terms = append(u.terms[:i], u.terms[i+1:]...)
+				}
+				n := utf8.RuneCountInString(term)
+				if delete(u.byLen[n], term); len(u.byLen[n]) == 0 {
+					delete(u.byLen, n)
+				}
+			}
+		}
+	}
+	delete(u.docs, id)
+}
+
+// matching términos del usuario que coinciden con t: el exacto, los que
+// empiezan por t (rango de la lista ordenada) y los aproximados, que solo
+// pueden tener un largo a maxEdits runas o menos del de t
+func (u *userIndex) matching(t Term) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	keep := func(docTerm string) {
+		if _, ok := seen[docTerm]; !ok {
+			seen[docTerm] = struct{}{}
+			out = append(out, docTerm)
+		}
+	}
+
+	if _, ok := u.postings[t.Text]; ok {
+		keep(t.Text)
+	}
+	if t.Prefix {
+		for i := sort.SearchStrings(u.terms, t.Text); i < len(u.terms) && strings.HasPrefix(u.terms[i], t.Text); i++ {
+			keep(u.terms[i])
+		}
+	}
+	if edits := maxEdits(t.Text); edits > 0 {
+		n := utf8.RuneCountInString(t.Text)
+		for l := n - edits; l <= n+edits; l++ {
+			for docTerm := range u.byLen[l] {
+				if t.matchQuality(docTerm) > 0 {
+					keep(docTerm)
+				}
+			}
+		}
+	}
+	return out
+}
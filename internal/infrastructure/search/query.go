@@ -0,0 +1,205 @@
+package search
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"uniflow-api/internal/domain"
+)
+
+// Peso de cada campo en el score
+const (
+	weightTitle       = 3.0
+	weightTags        = 2.0
+	weightDescription = 1.0
+
+	// titleBonus se suma cuando todos los términos aparecen en el título
+	titleBonus = 1.0
+)
+
+// Calidad de la coincidencia de un término
+const (
+	qualityExact  = 1.0
+	qualityPrefix = 0.75
+	qualityFuzzy1 = 0.5
+	qualityFuzzy2 = 0.35
+)
+
+// Term término de una búsqueda
+type Term struct {
+	Text   string
+	Prefix bool // "calc*" o último término mientras se escribe
+}
+
+// Query búsqueda ya normalizada. Todos los términos son obligatorios.
+type Query struct {
+	Raw   string
+	Terms []Term
+}
+
+// ParseQuery normaliza el texto buscado. Los términos que terminan en * y
+// el último (si no le sigue un espacio: el usuario sigue escribiendo)
+// coinciden también por prefijo ("calc" encuentra "cálculo").
+func ParseQuery(raw string) Query {
+	q := Query{Raw: strings.TrimSpace(raw)}
+	tokens := Tokenize(raw)
+	for i, t := range tokens {
+		prefix := i == len(tokens)-1 && t.End == len(raw)
+		if t.End < len(raw) && raw[t.End] == '*' {
+			prefix = true
+		}
+		q.Terms = append(q.Terms, Term{Text: t.Term, Prefix: prefix})
+	}
+	return q
+}
+
+// Empty indica que la búsqueda no tiene términos útiles (solo stopwords o símbolos)
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0
+}
+
+// maxEdits distancia de edición tolerada según el largo del término
+func maxEdits(term string) int {
+	n := utf8.RuneCountInString(term)
+	switch {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// matchQuality calidad con que el término de la búsqueda coincide con un
+// término del documento (0 si no coincide)
+func (t Term) matchQuality(docTerm string) float64 {
+	if docTerm == t.Text {
+		return qualityExact
+	}
+	if t.Prefix && strings.HasPrefix(docTerm, t.Text) {
+		return qualityPrefix
+	}
+	edits := maxEdits(t.Text)
+	if edits == 0 {
+		return 0
+	}
+	switch d := editDistance(t.Text, docTerm, edits); {
+	case d > edits:
+		return 0
+	case d == 1:
+		return qualityFuzzy1
+	}
+	return qualityFuzzy2
+}
+
+// matchesAny indica si algún término de la búsqueda coincide con docTerm
+func (q Query) matchesAny(docTerm string) bool {
+	for _, t := range q.Terms {
+		if t.matchQuality(docTerm) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance distancia de edición entre a y b contando la transposición
+// de dos letras vecinas como un solo error ("calcluo" → "calculo"); devuelve
+// limit+1 en cuanto se sabe que la supera
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// document términos de cada campo de una tarea
+type document struct {
+	title       []string
+	tags        []string
+	description []string
+}
+
+func newDocument(t *domain.Task) document {
+	return document{
+		title:       Terms(t.Title),
+		tags:        Terms(strings.Join(t.Tags, " ")),
+		description: Terms(t.Description),
+	}
+}
+
+// all todos los términos del documento (para el índice)
+func (d document) all() []string {
+	out := make([]string, 0, len(d.title)+len(d.tags)+len(d.description))
+	out = append(out, d.title...)
+	out = append(out, d.tags...)
+	return append(out, d.description...)
+}
+
+// bestQuality mejor coincidencia del término entre los de un campo
+func bestQuality(t Term, terms []string) float64 {
+	best := 0.0
+	for _, dt := range terms {
+		if q := t.matchQuality(dt); q > best {
+			best = q
+			if best == qualityExact {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// Score relevancia de la tarea para la búsqueda; 0 si falta algún término
+func Score(t *domain.Task, q Query) float64 {
+	if q.Empty() {
+		return 0
+	}
+	doc := newDocument(t)
+	score := 0.0
+	allInTitle := true
+	for _, term := range q.Terms {
+		title := bestQuality(term, doc.title)
+		best := max(
+			weightTitle*title,
+			weightTags*bestQuality(term, doc.tags),
+			weightDescription*bestQuality(term, doc.description),
+		)
+		if best == 0 {
+			return 0
+		}
+		if title == 0 {
+			allInTitle = false
+		}
+		score += best
+	}
+	if allInTitle {
+		score += titleBonus
+	}
+	return score
+}
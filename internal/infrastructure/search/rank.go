@@ -0,0 +1,70 @@
+package search
+
+import (
+	"sort"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// Hit tarea encontrada con su relevancia
+type Hit struct {
+	Task  domain.Task
+	Score float64
+}
+
+// Rank puntúa las tareas y devuelve las que coinciden, de mayor a menor
+// relevancia (desempata por ID para que la paginación sea estable)
+func Rank(tasks []domain.Task, q Query) []Hit {
+	hits := make([]Hit, 0)
+	for i := range tasks {
+		if s := Score(&tasks[i], q); s > 0 {
+			hits = append(hits, Hit{Task: tasks[i], Score: s})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Task.ID < hits[j].Task.ID
+	})
+	return hits
+}
+
+// Paginate pagina los resultados de una búsqueda. Por relevancia solo hay
+// paginación por página (el score depende del texto buscado, no sirve de
+// clave para un cursor); con otro orden se comporta como un listado normal.
+func Paginate(hits []Hit, f domain.TaskFilter, now time.Time) ([]domain.Task, domain.PageInfo, error) {
+	tasks := make([]domain.Task, len(hits))
+	for i, h := range hits {
+		tasks[i] = h.Task
+	}
+
+	if !f.SortsByRelevance() {
+		return domain.PaginateInMemory(tasks, f, now)
+	}
+	if f.IsCursorMode() {
+		return nil, domain.PageInfo{}, domain.ErrCursorUnsupported
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+	start := 0
+	if f.Page > 1 {
+		start = (f.Page - 1) * limit
+	}
+	var rows []domain.Task
+	if start < len(tasks) {
+		rows = tasks[start:min(len(tasks), start+limit+1)]
+	}
+
+	total := int64(-1)
+	if !f.SkipCount {
+		total = int64(len(tasks))
+	}
+	page, info := domain.BuildPage(rows, f, total, now)
+	info.NextCursor, info.PrevCursor = "", ""
+	return page, info, nil
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestTokenizeFoldsAccentsAndPlurals(t *testing.T) {
+	got := strings.Join(Terms("Exámenes de CÁLCULO y Tareas: Niño's tasks"), ",")
+	want := "examen,calculo,tarea,nino,task"
+	if got != want {
+		t.Errorf("terms = %s, want %s", got, want)
+	}
+
+	tokens := Tokenize("Año lectivo")
+	if tokens[0].Start != 0 || tokens[0].End != len("Año") || tokens[1].Term != "lectivo" {
+		t.Errorf("unexpected offsets %+v", tokens)
+	}
+}
+
+func TestParseQueryPrefixTerms(t *testing.T) {
+	q := ParseQuery("parcial calc")
+	if len(q.Terms) != 2 || q.Terms[0].Prefix || !q.Terms[1].Prefix {
+		t.Errorf("only the last term should be a prefix: %+v", q.Terms)
+	}
+	q = ParseQuery("lab* informe ")
+	if !q.Terms[0].Prefix || q.Terms[1].Prefix {
+		t.Errorf("explicit * marks a prefix, trailing space closes the last term: %+v", q.Terms)
+	}
+	if !ParseQuery("de la ").Empty() {
+		t.Error("stopwords only should be empty")
+	}
+}
+
+func TestRankMatchesExactPrefixAndFuzzy(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "calc", Title: "Parcial de Cálculo", Tags: []string{"matematica"}},
+		{ID: "desc", Title: "Repaso", Description: "Ejercicios para el parcial de calculo integral"},
+		{ID: "tag", Title: "Guía", Tags: []string{"cálculo"}},
+		{ID: "phys", Title: "Informe de física", Description: "Laboratorio de péndulo"},
+	}
+
+	ids := func(hits []Hit) string {
+		out := make([]string, len(hits))
+		for i, h := range hits {
+			out[i] = h.Task.ID
+		}
+		return strings.Join(out, ",")
+	}
+
+	cases := map[string]string{
+		"calculo":            "calc,tag,desc", // título > tags > descripción
+		"CALC":               "calc,tag,desc", // prefijo sin tildes
+		"calculos":           "calc,tag,desc", // plural
+		"calcluo ":           "calc,tag,desc", // error de tipeo (término completo)
+		"parcial calculo":    "calc,desc",     // todos los términos son obligatorios
+		"fisica laboratorio": "phys",
+		"pendulo":            "phys",
+		"quimica ":           "",
+	}
+	for query, want := range cases {
+		if got := ids(Rank(tasks, ParseQuery(query))); got != want {
+			t.Errorf("%q: got [%s], want [%s]", query, got, want)
+		}
+	}
+}
+
+func TestIndexCandidates(t *testing.T) {
+	ix := NewIndex()
+	a := &domain.Task{ID: "a", UserID: "u1", Title: "Ensayo de historia"}
+	b := &domain.Task{ID: "b", UserID: "u1", Title: "Historia del arte", Tags: []string{"ensayo"}}
+	other := &domain.Task{ID: "c", UserID: "u2", Title: "Ensayo de historia"}
+	ix.Add(a)
+	ix.Add(b)
+	ix.Add(other)
+
+	if got := ix.Candidates("u1", ParseQuery("ensayo histo")); len(got) != 2 {
+		t.Errorf("expected both tasks, got %v", got)
+	}
+
+	b.Tags = nil
+	ix.Add(b)
+	if got := ix.Candidates("u1", ParseQuery("ensayo histo")); len(got) != 1 {
+		t.Errorf("re-indexed task should no longer match, got %v", got)
+	}
+
+	// Prefijo corto (lista ordenada) y error de tipeo, solo entre las del usuario
+	if got := ix.Candidates("u1", ParseQuery("ar")); len(got) != 1 {
+		t.Errorf("expected the prefix match, got %v", got)
+	}
+	if got := ix.Candidates("u2", ParseQuery("histroia")); len(got) != 1 {
+		t.Errorf("expected only u2's task, got %v", got)
+	}
+
+	ix.Remove("a")
+	if got := ix.Candidates("u1", ParseQuery("ensayo")); len(got) != 0 {
+		t.Errorf("removed task should not match, got %v", got)
+	}
+	if got := ix.Candidates("u2", ParseQuery("ensayo")); len(got) != 1 {
+		t.Errorf("other user's task should still match, got %v", got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	q := ParseQuery("calculo")
+
+	got, ok := Highlight("Parcial de Cálculo <II>", q, 0)
+	if !ok || got != "Parcial de <mark>Cálculo</mark> &lt;II&gt;" {
+		t.Errorf("highlight = %q", got)
+	}
+	if _, ok := Highlight("Informe de física", q, 0); ok {
+		t.Error("expected no match")
+	}
+
+	long := strings.Repeat("texto de relleno ", 20) + "repasar cálculo integral " + strings.Repeat("más relleno ", 20)
+	got, _ = Highlight(long, q, 60)
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>cálculo</mark>") {
+		t.Errorf("snippet = %q", got)
+	}
+	if n := len([]rune(got)); n > 60+len(markOpen)+len(markClose)+2 {
+		t.Errorf("snippet too long (%d): %q", n, got)
+	}
+}
+
+func TestPaginateRelevance(t *testing.T) {
+	now := time.Now()
+	hits := []Hit{
+		{Task: domain.Task{ID: "a", DueDate: now.Add(3 * time.Hour)}, Score: 3},
+		{Task: domain.Task{ID: "b", DueDate: now.Add(1 * time.Hour)}, Score: 2},
+		{Task: domain.Task{ID: "c", DueDate: now.Add(2 * time.Hour)}, Score: 1},
+	}
+
+	page, info, err := Paginate(hits, domain.TaskFilter{Search: "x", Limit: 2, Page: 2}, now)
+	if err != nil || len(page) != 1 || page[0].ID != "c" || info.Total != 3 || info.HasNext || !info.HasPrev {
+		t.Errorf("unexpected page %v %+v %v", page, info, err)
+	}
+
+	page, _, _ = Paginate(hits, domain.TaskFilter{Search: "x", SortBy: "dueDate", Limit: 2}, now)
+	if len(page) != 2 || page[0].ID != "b" || page[1].ID != "c" {
+		t.Errorf("explicit sort should override relevance: %v", page)
+	}
+
+	if _, _, err := Paginate(hits, domain.TaskFilter{Search: "x", After: "abc"}, now); err != domain.ErrCursorUnsupported {
+		t.Errorf("expected ErrCursorUnsupported, got %v", err)
+	}
+}
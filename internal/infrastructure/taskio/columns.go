@@ -0,0 +1,141 @@
+package taskio
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// Columns columnas canónicas de exportación/importación, en orden
+var Columns = []string{
+	"id",
+	"externalId",
+	"title",
+	"description",
+	"subjectId",
+	"periodId",
+	"dueDate",
+	"status",
+	"priority",
+	"type",
+	"estimatedTimeHours",
+	"actualTimeHours",
+	"gradeWeight",
+	"isBlocked",
+	"tags",
+	"isGroupWork",
+	"groupMembers",
+	"createdAt",
+	"updatedAt",
+	"completedAt",
+}
+
+// listSeparator separador de listas (tags, groupMembers) dentro de una celda
+const listSeparator = "|"
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+// formulaPrefixes caracteres iniciales con los que Excel, Sheets o LibreOffice
+// interpretan una celda como fórmula
+const formulaPrefixes = "=+-@\t\r"
+
+// csvSafe antepone ' a las celdas que una planilla ejecutaría como fórmula
+// (inyección CSV: "=HYPERLINK(...)" en un título). Solo para CSV; JSON y
+// NDJSON exportan el valor tal cual.
+func csvSafe(v string) string {
+	if v != "" && strings.IndexByte(formulaPrefixes, v[0]) >= 0 {
+		return "'" + v
+	}
+	return v
+}
+
+// csvUnescape revierte csvSafe al reimportar una exportación
+func csvUnescape(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.IndexByte(formulaPrefixes, v[1]) >= 0 {
+		return v[1:]
+	}
+	return v
+}
+
+// Record convierte una tarea en una fila CSV con el orden de Columns; las
+// celdas que empiezan como una fórmula se escapan (ver csvSafe)
+func Record(t *domain.Task) []string {
+	actual := ""
+	if t.ActualTimeHours != nil {
+		actual = strconv.Itoa(*t.ActualTimeHours)
+	}
+	completed := ""
+	if t.CompletedAt != nil {
+		completed = t.CompletedAt.Format(timeLayout)
+	}
+
+	record := []string{
+		t.ID,
+		t.ExternalID,
+		t.Title,
+		t.Description,
+		t.SubjectID,
+		t.PeriodID,
+		t.DueDate.Format(timeLayout),
+		t.Status,
+		t.Priority,
+		t.Type,
+		strconv.Itoa(t.EstimatedTimeHours),
+		actual,
+		strconv.FormatFloat(t.GradeWeight, 'f', -1, 64),
+		strconv.FormatBool(t.IsBlocked),
+		strings.Join(t.Tags, listSeparator),
+		strconv.FormatBool(t.IsGroupWork),
+		strings.Join(t.GroupMembers, listSeparator),
+		t.CreatedAt.Format(timeLayout),
+		t.UpdatedAt.Format(timeLayout),
+		completed,
+	}
+	for i, v := range record {
+		record[i] = csvSafe(v)
+	}
+	return record
+}
+
+// isColumn indica si name es una columna canónica
+func isColumn(name string) bool {
+	for _, c := range Columns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList separa una celda de lista aceptando "|", ";" o ","
+func splitList(s string) []string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '|' || r == ';' || r == ','
+	})
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// parseTime acepta RFC 3339, "2006-01-02 15:04" y "2006-01-02" (fin del día) en loc
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	d, err := time.ParseInLocation("2006-01-02", s, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.Add(24*time.Hour - time.Minute), nil
+}
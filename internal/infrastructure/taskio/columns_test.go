@@ -0,0 +1,56 @@
+package taskio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestRecordEscapesFormulas(t *testing.T) {
+	task := &domain.Task{
+		ID:          "task-1",
+		Title:       `=HYPERLINK("http://evil.example","Ver notas")`,
+		Description: "+1 punto extra",
+		SubjectID:   "@calc",
+		Tags:        []string{"-urgente"},
+		DueDate:     time.Date(2025, 10, 10, 15, 0, 0, 0, time.UTC),
+		Status:      domain.StatusTodo,
+		Priority:    domain.PriorityHigh,
+		Type:        domain.TypeAssignment,
+	}
+
+	record := Record(task)
+	want := map[string]string{
+		"id":          "task-1",
+		"title":       `'=HYPERLINK("http://evil.example","Ver notas")`,
+		"description": "'+1 punto extra",
+		"subjectId":   "'@calc",
+		"tags":        "'-urgente",
+		"dueDate":     "2025-10-10T15:00:00Z",
+	}
+	for i, col := range Columns {
+		if w, ok := want[col]; ok && record[i] != w {
+			t.Errorf("%s = %q, want %q", col, record[i], w)
+		}
+	}
+
+	// Reimportar la exportación recupera los valores originales
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(Columns)
+	_ = w.Write(record)
+	w.Flush()
+	rows, err := ReadCSV(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rows[0].Fields["title"]; got != task.Title {
+		t.Errorf("title round trip = %q", got)
+	}
+	if got := rows[0].Fields["tags"]; got != "-urgente" {
+		t.Errorf("tags round trip = %q", got)
+	}
+}
@@ -0,0 +1,293 @@
+package taskio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+// Row fila de entrada ya normalizada a columnas canónicas
+type Row struct {
+	Line   int
+	Fields map[string]string
+}
+
+// Mapping traduce nombres de columna/clave de origen a columnas canónicas.
+// Ej: {"Título": "title", "Entrega": "dueDate"}. Las columnas que ya tienen
+// nombre canónico no necesitan mapeo.
+type Mapping map[string]string
+
+// Validate verifica que todos los destinos sean columnas canónicas
+func (m Mapping) Validate() error {
+	for src, dst := range m {
+		if !isColumn(dst) {
+			return fmt.Errorf("mapping inválido: %q -> %q no es una columna conocida", src, dst)
+		}
+	}
+	return nil
+}
+
+func (m Mapping) resolve(name string) string {
+	name = strings.TrimSpace(name)
+	if dst, ok := m[name]; ok {
+		return dst
+	}
+	// Coincidencia sin distinguir mayúsculas con columnas canónicas
+	for _, c := range Columns {
+		if strings.EqualFold(c, name) {
+			return c
+		}
+	}
+	return ""
+}
+
+// ReadCSV lee un CSV con encabezado. Las columnas sin mapeo se ignoran y las
+// celdas escapadas por Record recuperan su valor original.
+func ReadCSV(r io.Reader, m Mapping) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("CSV vacío")
+		}
+		return nil, fmt.Errorf("error al leer encabezado CSV: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // BOM de Excel
+	}
+
+	targets := make([]string, len(header))
+	for i, h := range header {
+		targets[i] = m.resolve(h)
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, fmt.Errorf("error de formato CSV: %w", err)
+		}
+
+		row := Row{Line: line, Fields: make(map[string]string)}
+		for i, v := range record {
+			if i < len(targets) && targets[i] != "" {
+				row.Fields[targets[i]] = csvUnescape(strings.TrimSpace(v))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ReadJSON lee un arreglo JSON de objetos.
+// Line es la posición (1-based) del objeto en la entrada.
+func ReadJSON(r io.Reader, m Mapping) ([]Row, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("JSON inválido: %w", err)
+	}
+
+	var objects []map[string]interface{}
+	if delim, ok := tok.(json.Delim); ok && delim == '[' {
+		for dec.More() {
+			var obj map[string]interface{}
+			if err := dec.Decode(&obj); err != nil {
+				return nil, fmt.Errorf("JSON inválido en el elemento %d: %w", len(objects)+1, err)
+			}
+			objects = append(objects, obj)
+		}
+	} else {
+		return nil, fmt.Errorf("JSON inválido: se esperaba un arreglo de objetos")
+	}
+
+	return objectsToRows(objects, m), nil
+}
+
+// ReadNDJSON lee un objeto JSON por línea
+func ReadNDJSON(r io.Reader, m Mapping) ([]Row, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var objects []map[string]interface{}
+	for {
+		var obj map[string]interface{}
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("NDJSON inválido en el objeto %d: %w", len(objects)+1, err)
+		}
+		objects = append(objects, obj)
+	}
+	return objectsToRows(objects, m), nil
+}
+
+// ReadJSONAuto detecta arreglo JSON o NDJSON a partir del primer carácter
+func ReadJSONAuto(data []byte, m Mapping) ([]Row, error) {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(string(data), "\ufeff"))
+	if strings.HasPrefix(trimmed, "[") {
+		return ReadJSON(strings.NewReader(trimmed), m)
+	}
+	return ReadNDJSON(strings.NewReader(trimmed), m)
+}
+
+func objectsToRows(objects []map[string]interface{}, m Mapping) []Row {
+	rows := make([]Row, 0, len(objects))
+	for i, obj := range objects {
+		row := Row{Line: i + 1, Fields: make(map[string]string)}
+		for k, v := range obj {
+			if target := m.resolve(k); target != "" {
+				row.Fields[target] = stringify(v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	case []interface{}:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, stringify(item))
+		}
+		return strings.Join(parts, listSeparator)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// ToDrafts convierte filas en borradores de tarea. Los campos vacíos toman
+// valores por defecto (status todo, prioridad medium, tipo inferido del título);
+// la validación de reglas de negocio la hace Task.IsValid en el servicio.
+func ToDrafts(rows []Row, userID string, loc *time.Location, now time.Time) []domain.ImportDraft {
+	drafts := make([]domain.ImportDraft, 0, len(rows))
+	for _, row := range rows {
+		task, err := rowToTask(row.Fields, loc, now)
+		task.UserID = userID
+		drafts = append(drafts, domain.ImportDraft{Line: row.Line, Task: task, Err: err})
+	}
+	return drafts
+}
+
+func rowToTask(f map[string]string, loc *time.Location, now time.Time) (domain.Task, error) {
+	t := domain.Task{
+		ID:           f["id"], // El servicio lo usa para encontrar la tarea exportada
+		ExternalID:   f["externalId"],
+		Title:        f["title"],
+		Description:  f["description"],
+		SubjectID:    f["subjectId"],
+		PeriodID:     f["periodId"],
+		Status:       f["status"],
+		Priority:     f["priority"],
+		Type:         f["type"],
+		Tags:         splitList(f["tags"]),
+		GroupMembers: splitList(f["groupMembers"]),
+		Attachments:  []string{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if t.Status == "" {
+		t.Status = domain.StatusTodo
+	}
+	if t.Priority == "" {
+		t.Priority = domain.PriorityMedium
+	}
+	if t.Type == "" {
+		t.Type = domain.InferTaskType(t.Title)
+	}
+
+	if v := f["dueDate"]; v == "" {
+		return t, fmt.Errorf("dueDate es requerido")
+	} else if d, err := parseTime(v, loc); err != nil {
+		return t, fmt.Errorf("dueDate inválido: %q", v)
+	} else {
+		t.DueDate = d
+	}
+
+	if v := f["completedAt"]; v != "" {
+		d, err := parseTime(v, loc)
+		if err != nil {
+			return t, fmt.Errorf("completedAt inválido: %q", v)
+		}
+		t.CompletedAt = &d
+	} else if t.Status == domain.StatusDone {
+		t.CompletedAt = &now
+	}
+
+	var err error
+	if t.EstimatedTimeHours, err = parseInt(f, "estimatedTimeHours"); err != nil {
+		return t, err
+	}
+	if v := f["actualTimeHours"]; v != "" {
+		actual, err := parseInt(f, "actualTimeHours")
+		if err != nil {
+			return t, err
+		}
+		t.ActualTimeHours = &actual
+	}
+	if v := f["gradeWeight"]; v != "" {
+		if t.GradeWeight, err = strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64); err != nil {
+			return t, fmt.Errorf("gradeWeight inválido: %q", v)
+		}
+	}
+	if t.IsBlocked, err = parseBool(f, "isBlocked"); err != nil {
+		return t, err
+	}
+	if t.IsGroupWork, err = parseBool(f, "isGroupWork"); err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
+
+func parseInt(f map[string]string, key string) (int, error) {
+	v := f[key]
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s inválido: %q", key, v)
+	}
+	return n, nil
+}
+
+func parseBool(f map[string]string, key string) (bool, error) {
+	switch strings.ToLower(f[key]) {
+	case "", "false", "0", "no":
+		return false, nil
+	case "true", "1", "yes", "si", "sí", "x":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s inválido: %q", key, f[key])
+	}
+}
@@ -0,0 +1,65 @@
+package taskio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"uniflow-api/internal/domain"
+)
+
+func TestReadCSVWithMapping(t *testing.T) {
+	input := "Título,Materia,Entrega,Horas,Etiquetas,Ignorada\n" +
+		"Parcial 1,calc,2025-10-10,4,parcial|repaso,x\n" +
+		"\"Lectura, cap 3\",calc,10/10/2025,2,,y\n"
+
+	rows, err := ReadCSV(strings.NewReader(input), Mapping{
+		"Título":    "title",
+		"Materia":   "subjectId",
+		"Entrega":   "dueDate",
+		"Horas":     "estimatedTimeHours",
+		"Etiquetas": "tags",
+	})
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].Line != 2 || rows[1].Line != 3 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+	if _, ok := rows[0].Fields["Ignorada"]; ok {
+		t.Error("unmapped column should be ignored")
+	}
+
+	drafts := ToDrafts(rows, "user-1", time.UTC, time.Now())
+	first := drafts[0]
+	if first.Err != nil {
+		t.Fatalf("unexpected error: %v", first.Err)
+	}
+	if first.Task.Type != domain.TypeExam || first.Task.EstimatedTimeHours != 4 || len(first.Task.Tags) != 2 {
+		t.Errorf("unexpected draft %+v", first.Task)
+	}
+	if drafts[1].Err == nil || !strings.Contains(drafts[1].Err.Error(), "dueDate") {
+		t.Errorf("expected dueDate error, got %v", drafts[1].Err)
+	}
+}
+
+func TestReadJSONAuto(t *testing.T) {
+	array := `[{"title":"Ensayo","subjectId":"hist","dueDate":"2025-10-10T10:00:00Z","tags":["a","b"],"gradeWeight":15}]`
+	ndjson := "{\"title\":\"Ensayo\",\"subjectId\":\"hist\",\"dueDate\":\"2025-10-10\"}\n{\"title\":\"Quiz\",\"subjectId\":\"hist\",\"dueDate\":\"2025-10-11\"}\n"
+
+	rows, err := ReadJSONAuto([]byte(array), nil)
+	if err != nil || len(rows) != 1 || rows[0].Fields["tags"] != "a|b" || rows[0].Fields["gradeWeight"] != "15" {
+		t.Fatalf("unexpected array rows %+v (err %v)", rows, err)
+	}
+
+	rows, err = ReadJSONAuto([]byte(ndjson), nil)
+	if err != nil || len(rows) != 2 || rows[1].Line != 2 {
+		t.Fatalf("unexpected ndjson rows %+v (err %v)", rows, err)
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	if err := (Mapping{"Nombre": "nombre"}).Validate(); err == nil {
+		t.Error("expected error for unknown target column")
+	}
+}